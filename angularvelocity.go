@@ -0,0 +1,90 @@
+package glm
+
+// QuatFromRotationVector returns the rotation of angle |v| around v. This is
+// the exponential map from rotation vectors to unit quaternions.
+func QuatFromRotationVector(v *Vec3) Quat {
+	q := Quat{0, v.Mul(0.5)}
+	q.Exponentiate()
+	return q
+}
+
+// RotationVector returns the rotation vector of q1, its axis scaled by its
+// angle. The shortest of the two rotations represented by q1 and -q1 is used so
+// the length is at most π. This is the inverse of QuatFromRotationVector.
+func (q1 *Quat) RotationVector() Vec3 {
+	q := *q1
+	if q.W < 0 {
+		q.ScaleWith(-1)
+	}
+	q.Logarithmize()
+	return q.V.Mul(2)
+}
+
+// IntegrateWorld returns the orientation q1 after rotating at the world space
+// angular velocity omega (radians per second) for dt seconds.
+//
+// Unlike AddScaledVec this uses the exponential map so it's exact for any
+// timestep as long as omega is constant over it.
+func (q1 *Quat) IntegrateWorld(omega *Vec3, dt float32) Quat {
+	var q Quat
+	q.IntegrateWorldOf(q1, omega, dt)
+	return q
+}
+
+// IntegrateWorldOf is a memory friendly version of IntegrateWorld.
+func (q1 *Quat) IntegrateWorldOf(q2 *Quat, omega *Vec3, dt float32) {
+	v := omega.Mul(dt)
+	d := QuatFromRotationVector(&v)
+	q1.MulOf(&d, q2)
+}
+
+// IntegrateWorldWith is a memory friendly version of IntegrateWorld.
+func (q1 *Quat) IntegrateWorldWith(omega *Vec3, dt float32) {
+	q2 := *q1
+	q1.IntegrateWorldOf(&q2, omega, dt)
+}
+
+// IntegrateBody returns the orientation q1 after rotating at the body space
+// (local to q1) angular velocity omega (radians per second) for dt seconds.
+func (q1 *Quat) IntegrateBody(omega *Vec3, dt float32) Quat {
+	var q Quat
+	q.IntegrateBodyOf(q1, omega, dt)
+	return q
+}
+
+// IntegrateBodyOf is a memory friendly version of IntegrateBody.
+func (q1 *Quat) IntegrateBodyOf(q2 *Quat, omega *Vec3, dt float32) {
+	v := omega.Mul(dt)
+	d := QuatFromRotationVector(&v)
+	q1.MulOf(q2, &d)
+}
+
+// IntegrateBodyWith is a memory friendly version of IntegrateBody.
+func (q1 *Quat) IntegrateBodyWith(omega *Vec3, dt float32) {
+	v := omega.Mul(dt)
+	d := QuatFromRotationVector(&v)
+	q1.MulWith(&d)
+}
+
+// QuatAngularVelocity returns the constant world space angular velocity that
+// takes the orientation q0 to the orientation q1 in dt seconds. It's the
+// inverse of IntegrateWorld and always takes the shortest path.
+func QuatAngularVelocity(q0, q1 *Quat, dt float32) Vec3 {
+	inv := q0.Conjugated()
+	d := q1.Mul(&inv)
+	v := d.RotationVector()
+	v.MulWith(1 / dt)
+	return v
+}
+
+// QuatAngularVelocityBody returns the constant body space angular velocity
+// that takes the orientation q0 to the orientation q1 in dt seconds. It's the
+// inverse of IntegrateBody and always takes the shortest path.
+func QuatAngularVelocityBody(q0, q1 *Quat, dt float32) Vec3 {
+	var d Quat
+	d.ConjugateOf(q0)
+	d.MulWith(q1)
+	v := d.RotationVector()
+	v.MulWith(1 / dt)
+	return v
+}
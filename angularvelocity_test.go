@@ -0,0 +1,95 @@
+package glm
+
+import (
+	"testing"
+)
+
+func TestQuatFromRotationVector(t *testing.T) {
+	t.Parallel()
+	tests := []Vec3{
+		{0, 0, 0},
+		{1, 0, 0},
+		{0, -2, 0},
+		{0.3, 0.4, 1.2},
+	}
+
+	for i, test := range tests {
+		q := QuatFromRotationVector(&test)
+		angle, axis := q.AxisAngle()
+		want := QuatRotate(angle, &axis)
+		if !FloatEqualThreshold(angle, test.Len(), 1e-4) || !q.EqualThreshold(&want, 1e-4) {
+			t.Errorf("[%d] QuatFromRotationVector(%v) = %v", i, test, q)
+		}
+		if v := q.RotationVector(); !v.EqualThreshold(&test, 1e-4) {
+			t.Errorf("[%d] RotationVector = %v, want %v", i, v, test)
+		}
+	}
+}
+
+func TestQuat_Integrate(t *testing.T) {
+	t.Parallel()
+	q0 := QuatRotate(0.5, &Vec3{0, 0.6, 0.8})
+	omega := Vec3{0, 0, 2}
+	const dt = 0.75
+
+	// Integrating a constant angular velocity in many small first order steps
+	// converges to the exact result.
+	ref := q0
+	for i := 0; i < 10000; i++ {
+		ref.AddScaledVec(dt/10000, &omega)
+		ref.Normalize()
+	}
+
+	w := q0.IntegrateWorld(&omega, dt)
+	if !w.OrientationEqualThreshold(&ref, 1e-4) {
+		t.Errorf("IntegrateWorld = %v, want %v", w, ref)
+	}
+	w = q0
+	w.IntegrateWorldWith(&omega, dt)
+	if !w.OrientationEqualThreshold(&ref, 1e-4) {
+		t.Errorf("IntegrateWorldWith = %v, want %v", w, ref)
+	}
+
+	// The body angular velocity is the world one seen from the start frame.
+	inv := q0.Conjugated()
+	body := inv.Rotate(&omega)
+	b := q0.IntegrateBody(&body, dt)
+	if !b.OrientationEqualThreshold(&ref, 1e-4) {
+		t.Errorf("IntegrateBody = %v, want %v", b, ref)
+	}
+	b = q0
+	b.IntegrateBodyWith(&body, dt)
+	if !b.OrientationEqualThreshold(&ref, 1e-4) {
+		t.Errorf("IntegrateBodyWith = %v, want %v", b, ref)
+	}
+}
+
+func TestQuatAngularVelocity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		q0    Quat
+		omega Vec3
+		dt    float32
+	}{
+		{QuatIdent(), Vec3{1, 0, 0}, 0.5},
+		{QuatRotate(1, &Vec3{0, 1, 0}), Vec3{0.2, -0.4, 1}, 0.1},
+		{QuatRotate(-2, &Vec3{0.6, 0, 0.8}), Vec3{3, 0, 1}, 0.25},
+	}
+
+	for i, test := range tests {
+		q1 := test.q0.IntegrateWorld(&test.omega, test.dt)
+		if w := QuatAngularVelocity(&test.q0, &q1, test.dt); !w.EqualThreshold(&test.omega, 1e-3) {
+			t.Errorf("[%d] QuatAngularVelocity = %v, want %v", i, w, test.omega)
+		}
+		q1 = test.q0.IntegrateBody(&test.omega, test.dt)
+		if w := QuatAngularVelocityBody(&test.q0, &q1, test.dt); !w.EqualThreshold(&test.omega, 1e-3) {
+			t.Errorf("[%d] QuatAngularVelocityBody = %v, want %v", i, w, test.omega)
+		}
+
+		// -q1 is the same orientation and must give the same velocity.
+		q1.ScaleWith(-1)
+		if w := QuatAngularVelocityBody(&test.q0, &q1, test.dt); !w.EqualThreshold(&test.omega, 1e-3) {
+			t.Errorf("[%d] QuatAngularVelocityBody(-q1) = %v, want %v", i, w, test.omega)
+		}
+	}
+}
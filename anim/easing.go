@@ -0,0 +1,231 @@
+// Package anim implements easing functions, keyframed animation tracks of
+// scalars, vectors and quaternions and glTF 2.0 animation samplers.
+package anim
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// Easing maps the progress of an animation, from 0 to 1, to the progress of
+// the animated value. It returns 0 at 0 and 1 at 1 but can overshoot in
+// between.
+//
+// The easings are the usual ones from Robert Penner. In versions start slow,
+// Out versions end slow and InOut versions do both.
+type Easing func(t float32) float32
+
+// Linear is the identity easing.
+func Linear(t float32) float32 {
+	return t
+}
+
+// InQuad eases in with t².
+func InQuad(t float32) float32 {
+	return t * t
+}
+
+// OutQuad eases out with t².
+func OutQuad(t float32) float32 {
+	return 1 - (1-t)*(1-t)
+}
+
+// InOutQuad eases in and out with t².
+func InOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	u := 2 - 2*t
+	return 1 - u*u/2
+}
+
+// InCubic eases in with t³.
+func InCubic(t float32) float32 {
+	return t * t * t
+}
+
+// OutCubic eases out with t³.
+func OutCubic(t float32) float32 {
+	u := 1 - t
+	return 1 - u*u*u
+}
+
+// InOutCubic eases in and out with t³.
+func InOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	u := 2 - 2*t
+	return 1 - u*u*u/2
+}
+
+// InExpo eases in exponentially.
+func InExpo(t float32) float32 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+// OutExpo eases out exponentially.
+func OutExpo(t float32) float32 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// InOutExpo eases in and out exponentially.
+func InOutExpo(t float32) float32 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return math.Pow(2, 20*t-10) / 2
+	}
+	return 1 - math.Pow(2, 10-20*t)/2
+}
+
+// The periods of the elastic oscillations.
+const (
+	elasticPeriod      = 2 * math.Pi / 3
+	elasticInOutPeriod = 2 * math.Pi / 4.5
+)
+
+// InElastic eases in with an oscillation growing like a spring pulled back
+// before release.
+func InElastic(t float32) float32 {
+	if t <= 0 || t >= 1 {
+		return math.Clamp(t, 0, 1)
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((10*t-10.75)*elasticPeriod)
+}
+
+// OutElastic eases out with a decaying oscillation around the end, like a
+// spring.
+func OutElastic(t float32) float32 {
+	if t <= 0 || t >= 1 {
+		return math.Clamp(t, 0, 1)
+	}
+	return math.Pow(2, -10*t)*math.Sin((10*t-0.75)*elasticPeriod) + 1
+}
+
+// InOutElastic eases in and out with oscillations.
+func InOutElastic(t float32) float32 {
+	switch {
+	case t <= 0 || t >= 1:
+		return math.Clamp(t, 0, 1)
+	case t < 0.5:
+		return -math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*elasticInOutPeriod) / 2
+	}
+	return math.Pow(2, 10-20*t)*math.Sin((20*t-11.125)*elasticInOutPeriod)/2 + 1
+}
+
+// backOvershoot makes the back easings overshoot by 10%.
+const backOvershoot = 1.70158
+
+// InBack eases in by first moving slightly backward.
+func InBack(t float32) float32 {
+	return (backOvershoot+1)*t*t*t - backOvershoot*t*t
+}
+
+// OutBack eases out by overshooting the end slightly.
+func OutBack(t float32) float32 {
+	u := t - 1
+	return 1 + (backOvershoot+1)*u*u*u + backOvershoot*u*u
+}
+
+// InOutBack eases in and out, moving slightly backward at the start and
+// overshooting the end.
+func InOutBack(t float32) float32 {
+	const c = backOvershoot * 1.525
+	if t < 0.5 {
+		u := 2 * t
+		return u * u * ((c+1)*u - c) / 2
+	}
+	u := 2*t - 2
+	return (u*u*((c+1)*u+c) + 2) / 2
+}
+
+// InBounce eases in with bounces of growing height.
+func InBounce(t float32) float32 {
+	return 1 - OutBounce(1-t)
+}
+
+// OutBounce eases out like a ball bouncing to rest at the end.
+func OutBounce(t float32) float32 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
+
+// InOutBounce eases in and out with bounces.
+func InOutBounce(t float32) float32 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
+
+// CubicBezier returns the easing of the CSS timing function cubic-bezier(x1,
+// y1, x2, y2), the Bezier curve from (0, 0) to (1, 1) with these control
+// points, y as a function of x. x1 and x2 must be in [0, 1], y1 and y2 can go
+// outside to overshoot.
+//
+//	CubicBezier(0.25, 0.1, 0.25, 1)  // ease
+//	CubicBezier(0.42, 0, 1, 1)       // ease-in
+//	CubicBezier(0, 0, 0.58, 1)       // ease-out
+//	CubicBezier(0.42, 0, 0.58, 1)    // ease-in-out
+func CubicBezier(x1, y1, x2, y2 float32) Easing {
+	// The polynomial coefficients of both coordinates, a t³ + b t² + c t.
+	cx, cy := 3*x1, 3*y1
+	bx, by := 3*(x2-x1)-cx, 3*(y2-y1)-cy
+	ax, ay := 1-cx-bx, 1-cy-by
+	curveX := func(t float32) float32 { return ((ax*t+bx)*t + cx) * t }
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return math.Clamp(x, 0, 1)
+		}
+		// Solve curveX(t) = x with Newton's method, falling back to
+		// bisection where the slope is too flat.
+		const epsilon = 1e-6
+		t := x
+		solved := false
+		for i := 0; i < 8; i++ {
+			f := curveX(t) - x
+			if math.Abs(f) < epsilon {
+				solved = true
+				break
+			}
+			d := (3*ax*t+2*bx)*t + cx
+			if math.Abs(d) < epsilon {
+				break
+			}
+			t -= f / d
+		}
+		if !solved {
+			lo, hi := float32(0), float32(1)
+			t = x
+			for i := 0; i < 32 && hi-lo > epsilon; i++ {
+				if curveX(t) < x {
+					lo = t
+				} else {
+					hi = t
+				}
+				t = (lo + hi) / 2
+			}
+		}
+		return ((ay*t+by)*t + cy) * t
+	}
+}
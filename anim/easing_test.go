@@ -0,0 +1,83 @@
+package anim
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+func TestEasings(t *testing.T) {
+	t.Parallel()
+	easings := []struct {
+		name   string
+		in     Easing
+		out    Easing
+		inOut  Easing
+		middle float32
+	}{
+		{"Quad", InQuad, OutQuad, InOutQuad, 0.25},
+		{"Cubic", InCubic, OutCubic, InOutCubic, 0.125},
+		{"Expo", InExpo, OutExpo, InOutExpo, 1.0 / 32},
+		{"Elastic", InElastic, OutElastic, InOutElastic, -0.015625},
+		{"Back", InBack, OutBack, InOutBack, -0.0876975},
+		{"Bounce", InBounce, OutBounce, InOutBounce, 0.234375},
+	}
+	for _, e := range easings[:3] {
+		// InOut is In then Out at double speed, the others use different
+		// constants for InOut.
+		for _, x := range []float32{0.1, 0.3, 0.45, 0.8} {
+			want := e.in(2*x) / 2
+			if x > 0.5 {
+				want = 0.5 + e.out(2*x-1)/2
+			}
+			if got := e.inOut(x); math.Abs(got-want) > 1e-5 {
+				t.Errorf("InOut%s(%f) = %f, want %f", e.name, x, got, want)
+			}
+		}
+	}
+	for _, e := range easings {
+		for _, f := range []Easing{e.in, e.out, e.inOut} {
+			if f(0) != 0 || math.Abs(f(1)-1) > 1e-6 {
+				t.Errorf("%s: f(0) = %f, f(1) = %f", e.name, f(0), f(1))
+			}
+		}
+		if got := e.in(0.5); math.Abs(got-e.middle) > 1e-5 {
+			t.Errorf("In%s(0.5) = %f, want %f", e.name, got, e.middle)
+		}
+		for _, x := range []float32{0.1, 0.3, 0.45, 0.8} {
+			// Out is In reversed and InOut is symmetric.
+			if in, out := e.in(x), e.out(1-x); math.Abs(in+out-1) > 1e-5 {
+				t.Errorf("In%s(%f) = %f, Out%s(%f) = %f", e.name, x, in, e.name, 1-x, out)
+			}
+			if a, b := e.inOut(x), e.inOut(1-x); math.Abs(a+b-1) > 1e-5 {
+				t.Errorf("InOut%s(%f) = %f, InOut%s(%f) = %f", e.name, x, a, e.name, 1-x, b)
+			}
+		}
+	}
+	if Linear(0.3) != 0.3 {
+		t.Errorf("Linear(0.3) = %f", Linear(0.3))
+	}
+}
+
+func TestCubicBezier(t *testing.T) {
+	t.Parallel()
+	linear := CubicBezier(1.0/3, 1.0/3, 2.0/3, 2.0/3)
+	for _, x := range []float32{0, 0.1, 0.5, 0.77, 1} {
+		if y := linear(x); math.Abs(y-x) > 1e-5 {
+			t.Errorf("linear(%f) = %f", x, y)
+		}
+	}
+
+	for _, c := range [][4]float32{{0.25, 0.1, 0.25, 1}, {0.42, 0, 1, 1}, {0, 0, 0.58, 1}, {0.68, -0.55, 0.27, 1.55}, {0.1, 0.7, 1, 0.1}} {
+		ease := CubicBezier(c[0], c[1], c[2], c[3])
+		// Compare with the curve sampled densely.
+		for i := 0; i <= 10000; i += 37 {
+			s := float32(i) / 10000
+			u := 1 - s
+			x := 3*u*u*s*c[0] + 3*u*s*s*c[2] + s*s*s
+			y := 3*u*u*s*c[1] + 3*u*s*s*c[3] + s*s*s
+			if got := ease(x); math.Abs(got-y) > 1e-3 {
+				t.Errorf("cubic-bezier%v(%f) = %f, want %f", c, x, got, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package anim
+
+import (
+	"sort"
+
+	"github.com/engoengine/glm"
+)
+
+// SamplerInterpolation is the interpolation of a glTF animation sampler.
+type SamplerInterpolation int
+
+// The interpolations of the glTF animation samplers.
+const (
+	// SamplerStep is STEP, the value of a keyframe is kept until the next
+	// one.
+	SamplerStep SamplerInterpolation = iota
+	// SamplerLinear is LINEAR, the values are interpolated linearly, with
+	// QuatSlerp for rotations.
+	SamplerLinear
+	// SamplerCubicSpline is CUBICSPLINE, the values are interpolated with a
+	// cubic Hermite spline whose tangents are stored with the keyframes.
+	SamplerCubicSpline
+)
+
+// Path is the property of a node animated by a glTF channel.
+type Path int
+
+// The paths of the glTF channels.
+const (
+	PathTranslation Path = iota
+	PathRotation
+	PathScale
+	PathWeights
+)
+
+// Sampler is a glTF animation sampler, the keyframe times and the values to
+// interpolate between them. The values are decoded floats, normalized integer
+// accessors must be converted first.
+type Sampler struct {
+	// Input are the keyframe times in seconds, increasing.
+	Input []float32
+	// Output are the keyframe values, their components one after the other.
+	// Rotations are stored x, y, z, w. With SamplerCubicSpline each keyframe
+	// has the in-tangent, the value and the out-tangent, in that order.
+	Output        []float32
+	Interpolation SamplerInterpolation
+}
+
+// Cursor remembers the keyframe found by the last sample of a Sampler, so
+// playing forward doesn't search for it each time. The zero value is ready to
+// use. A Cursor must only be used with one Sampler at a time.
+type Cursor struct {
+	key int
+}
+
+// locate returns the keyframe i before time and the progress u from keyframe
+// i to i+1. Outside of the keyframes time is clamped to the first or last
+// one, with u = 0. c, if not nil, is used and updated to speed up the search.
+func (s *Sampler) locate(time float32, c *Cursor) (i int, u float32) {
+	in := s.Input
+	n := len(in)
+	if n < 2 || time <= in[0] {
+		return 0, 0
+	}
+	if time >= in[n-1] {
+		return n - 1, 0
+	}
+	i = -1
+	if c != nil {
+		// Most of the time the time is in the same interval as the last
+		// sample, or the next one.
+		for k := c.key; k < c.key+2 && k < n-1; k++ {
+			if in[k] <= time && time < in[k+1] {
+				i = k
+				break
+			}
+		}
+	}
+	if i < 0 {
+		i = sort.Search(n-1, func(j int) bool { return in[j+1] > time })
+	}
+	if c != nil {
+		c.key = i
+	}
+	return i, (time - in[i]) / (in[i+1] - in[i])
+}
+
+// value returns the value of keyframe i, stride components long.
+func (s *Sampler) value(i, stride int) []float32 {
+	if s.Interpolation == SamplerCubicSpline {
+		i = 3*i + 1
+	}
+	return s.Output[i*stride : (i+1)*stride]
+}
+
+// Sample writes in dst the value of s at time, its length is the number of
+// components of the values. c may be nil.
+func (s *Sampler) Sample(dst []float32, time float32, c *Cursor) {
+	stride := len(dst)
+	i, u := s.locate(time, c)
+	if u == 0 || s.Interpolation == SamplerStep {
+		copy(dst, s.value(i, stride))
+		return
+	}
+	a, b := s.value(i, stride), s.value(i+1, stride)
+	if s.Interpolation == SamplerLinear {
+		for j := range dst {
+			dst[j] = a[j] + u*(b[j]-a[j])
+		}
+		return
+	}
+
+	// The out-tangent of i follows its value, the in-tangent of i+1 precedes
+	// its value. The tangents are per second.
+	dt := s.Input[i+1] - s.Input[i]
+	out := s.Output[(3*i+2)*stride : (3*i+3)*stride]
+	in := s.Output[(3*i+3)*stride : (3*i+4)*stride]
+	u2, u3 := u*u, u*u*u
+	h00, h10, h01, h11 := 2*u3-3*u2+1, (u3-2*u2+u)*dt, -2*u3+3*u2, (u3-u2)*dt
+	for j := range dst {
+		dst[j] = h00*a[j] + h10*out[j] + h01*b[j] + h11*in[j]
+	}
+}
+
+// SampleVec3 returns the value of s at time for translations and scales. c may
+// be nil.
+func (s *Sampler) SampleVec3(time float32, c *Cursor) glm.Vec3 {
+	var v glm.Vec3
+	s.Sample(v[:], time, c)
+	return v
+}
+
+// SampleQuat returns the value of s at time for rotations. Linear
+// interpolation uses QuatSlerp along the shortest path and cubic spline
+// interpolation normalizes its result. c may be nil.
+func (s *Sampler) SampleQuat(time float32, c *Cursor) glm.Quat {
+	if s.Interpolation == SamplerLinear {
+		i, u := s.locate(time, c)
+		a := quatXYZW(s.value(i, 4))
+		if u == 0 {
+			return a
+		}
+		b := quatXYZW(s.value(i+1, 4))
+		if a.Dot(&b) < 0 {
+			b.ScaleWith(-1)
+		}
+		return glm.QuatSlerp(&a, &b, u)
+	}
+	var v [4]float32
+	s.Sample(v[:], time, c)
+	q := quatXYZW(v[:])
+	q.Normalize()
+	return q
+}
+
+// Duration returns the time of the last keyframe of s.
+func (s *Sampler) Duration() float32 {
+	if len(s.Input) == 0 {
+		return 0
+	}
+	return s.Input[len(s.Input)-1]
+}
+
+// quatXYZW returns the quaternion stored x, y, z, w in v.
+func quatXYZW(v []float32) glm.Quat {
+	return glm.Quat{W: v[3], V: glm.Vec3{v[0], v[1], v[2]}}
+}
+
+// Channel is a glTF animation channel, a sampler animating a property of a
+// node. It keeps the Cursor of its sampler so a Channel must not be applied
+// concurrently.
+type Channel struct {
+	Sampler *Sampler
+	// Node is the index of the animated node.
+	Node   int
+	Path   Path
+	cursor Cursor
+}
+
+// Apply samples c at time and writes the result in the property of pose it
+// animates, or in weights for PathWeights. weights must have one element per
+// morph target of the node.
+func (c *Channel) Apply(time float32, pose *glm.TRS, weights []float32) {
+	switch c.Path {
+	case PathTranslation:
+		pose.Translation = c.Sampler.SampleVec3(time, &c.cursor)
+	case PathRotation:
+		pose.Rotation = c.Sampler.SampleQuat(time, &c.cursor)
+	case PathScale:
+		pose.Scale = c.Sampler.SampleVec3(time, &c.cursor)
+	case PathWeights:
+		c.Sampler.Sample(weights, time, &c.cursor)
+	}
+}
+
+// Animation is a glTF animation, channels playing together.
+type Animation struct {
+	Channels []Channel
+}
+
+// Duration returns the time of the last keyframe of the animation.
+func (a *Animation) Duration() float32 {
+	var d float32
+	for i := range a.Channels {
+		if s := a.Channels[i].Sampler.Duration(); s > d {
+			d = s
+		}
+	}
+	return d
+}
+
+// Apply samples all the channels at time and writes the results in the poses
+// and morph target weights of their nodes, indexed by node. weights may be nil
+// if no channel animates weights. The properties no channel animates are left
+// unchanged.
+func (a *Animation) Apply(time float32, poses []glm.TRS, weights [][]float32) {
+	for i := range a.Channels {
+		c := &a.Channels[i]
+		var w []float32
+		if c.Path == PathWeights {
+			w = weights[c.Node]
+		}
+		c.Apply(time, &poses[c.Node], w)
+	}
+}
@@ -0,0 +1,131 @@
+package anim
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestSampler_Sample(t *testing.T) {
+	t.Parallel()
+	input := []float32{1, 2, 4}
+	values := []float32{0, 10, 20, 30, 60, 90, 60, 90, 120}
+	// The tangents of the straight lines between the values.
+	cubic := []float32{
+		30, 50, 70, 0, 10, 20, 30, 50, 70,
+		30, 50, 70, 30, 60, 90, 15, 15, 15,
+		15, 15, 15, 60, 90, 120, 0, 0, 0,
+	}
+	tests := []struct {
+		interpolation SamplerInterpolation
+		time          float32
+		want          [3]float32
+	}{
+		{SamplerStep, 0, [3]float32{0, 10, 20}},
+		{SamplerStep, 1.9, [3]float32{0, 10, 20}},
+		{SamplerStep, 2, [3]float32{30, 60, 90}},
+		{SamplerStep, 3, [3]float32{30, 60, 90}},
+		{SamplerStep, 5, [3]float32{60, 90, 120}},
+		{SamplerLinear, 1.5, [3]float32{15, 35, 55}},
+		{SamplerLinear, 3, [3]float32{45, 75, 105}},
+		{SamplerLinear, 0, [3]float32{0, 10, 20}},
+		{SamplerCubicSpline, 1, [3]float32{0, 10, 20}},
+		{SamplerCubicSpline, 1.5, [3]float32{15, 35, 55}},
+		{SamplerCubicSpline, 3, [3]float32{45, 75, 105}},
+		{SamplerCubicSpline, 4, [3]float32{60, 90, 120}},
+		{SamplerCubicSpline, 9, [3]float32{60, 90, 120}},
+	}
+	for i, test := range tests {
+		s := Sampler{Input: input, Output: values, Interpolation: test.interpolation}
+		if test.interpolation == SamplerCubicSpline {
+			s.Output = cubic
+		}
+		var got [3]float32
+		s.Sample(got[:], test.time, nil)
+		for j := range got {
+			if math.Abs(got[j]-test.want[j]) > 1e-4 {
+				t.Errorf("[%d] Sample(%f) = %v, want %v", i, test.time, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSampler_Cursor(t *testing.T) {
+	t.Parallel()
+	s := Sampler{Interpolation: SamplerLinear}
+	for i := 0; i < 20; i++ {
+		s.Input = append(s.Input, float32(i)*0.5)
+		s.Output = append(s.Output, float32(i*i))
+	}
+	var c Cursor
+	// Forward, backward and jumping around.
+	times := []float32{0.1, 0.2, 0.6, 1.2, 1.3, 9, 0.3, 4.4, 4.6, 4.4, -1, 20, 2.2}
+	for _, time := range times {
+		var want, got [1]float32
+		s.Sample(want[:], time, nil)
+		s.Sample(got[:], time, &c)
+		if got != want {
+			t.Errorf("Sample(%f) with cursor = %f, want %f", time, got[0], want[0])
+		}
+	}
+}
+
+func TestSampler_SampleQuat(t *testing.T) {
+	t.Parallel()
+	z := glm.Vec3{0, 0, 1}
+	q0, q1 := glm.QuatRotate(0, &z), glm.QuatRotate(math.Pi/2, &z)
+	// q1 is stored negated, it's the same rotation and linear interpolation
+	// must still take the shortest path.
+	s := Sampler{
+		Input:         []float32{0, 1},
+		Output:        []float32{q0.V[0], q0.V[1], q0.V[2], q0.W, -q1.V[0], -q1.V[1], -q1.V[2], -q1.W},
+		Interpolation: SamplerLinear,
+	}
+	for _, time := range []float32{0, 0.25, 0.5, 1, 2} {
+		want := glm.QuatRotate(math.Min(time, 1)*math.Pi/2, &z)
+		if got := s.SampleQuat(time, nil); !got.OrientationEqualThreshold(&want, 1e-4) {
+			t.Errorf("linear: SampleQuat(%f) = %v, want %v", time, got, want)
+		}
+	}
+
+	// With zero tangents the result is normalized and symmetric.
+	s.Interpolation = SamplerCubicSpline
+	s.Output = []float32{
+		0, 0, 0, 0, q0.V[0], q0.V[1], q0.V[2], q0.W, 0, 0, 0, 0,
+		0, 0, 0, 0, q1.V[0], q1.V[1], q1.V[2], q1.W, 0, 0, 0, 0,
+	}
+	half := glm.QuatRotate(math.Pi/4, &z)
+	if got := s.SampleQuat(0.5, nil); !got.OrientationEqualThreshold(&half, 1e-4) {
+		t.Errorf("cubic: SampleQuat(0.5) = %v, want %v", got, half)
+	}
+	if got := s.SampleQuat(0.2, nil); math.Abs(got.Len()-1) > 1e-5 {
+		t.Errorf("cubic: SampleQuat(0.2) = %v, not normalized", got)
+	}
+}
+
+func TestAnimation_Apply(t *testing.T) {
+	t.Parallel()
+	translation := Sampler{Input: []float32{0, 2}, Output: []float32{0, 0, 0, 4, 2, 0}, Interpolation: SamplerLinear}
+	weights := Sampler{Input: []float32{0, 1, 3}, Output: []float32{1, 0, 0, 1, 0.5, 0.5}, Interpolation: SamplerStep}
+	a := Animation{Channels: []Channel{
+		{Sampler: &translation, Node: 1, Path: PathTranslation},
+		{Sampler: &weights, Node: 0, Path: PathWeights},
+	}}
+	if d := a.Duration(); d != 3 {
+		t.Errorf("Duration() = %f, want 3", d)
+	}
+
+	poses := []glm.TRS{glm.TRSIdent(), glm.TRSIdent()}
+	w := [][]float32{make([]float32, 2), nil}
+	a.Apply(1.5, poses, w)
+	if want := (glm.Vec3{3, 1.5, 0}); poses[1].Translation != want {
+		t.Errorf("Translation = %v, want %v", poses[1].Translation, want)
+	}
+	if ident := glm.TRSIdent(); poses[0] != ident || poses[1].Scale != ident.Scale {
+		t.Errorf("unanimated properties changed: %v", poses)
+	}
+	if w[0][0] != 0 || w[0][1] != 1 {
+		t.Errorf("weights = %v, want [0 1]", w[0])
+	}
+}
@@ -0,0 +1,245 @@
+package anim
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Interpolation is how a track interpolates between its keyframes.
+type Interpolation int
+
+// The interpolations of the tracks.
+const (
+	// InterpolateStep keeps the value of a keyframe until the next one.
+	InterpolateStep Interpolation = iota
+	// InterpolateLinear interpolates linearly, with QuatSlerp for
+	// quaternions.
+	InterpolateLinear
+	// InterpolateCubic interpolates with a Catmull-Rom spline through the
+	// keyframes, with SQUAD for quaternions. It's smooth where linear
+	// interpolation turns sharply at each keyframe.
+	InterpolateCubic
+)
+
+// Wrap is what a track does outside of the time range of its keyframes.
+type Wrap int
+
+// The wrap modes of the tracks.
+const (
+	// Clamp holds the first and last values.
+	Clamp Wrap = iota
+	// Loop repeats the track from the start. The last keyframe should be
+	// the same as the first, the cubic interpolation goes through it
+	// smoothly.
+	Loop
+	// PingPong plays the track forward then backward.
+	PingPong
+)
+
+// locate returns the keyframe i before time, after wrapping it, and the
+// progress u from keyframe i to i+1. u is 0 if there is no keyframe i+1.
+func locate(times []float32, wrap Wrap, time float32) (i int, u float32) {
+	n := len(times)
+	if n < 2 {
+		return 0, 0
+	}
+	start, duration := times[0], times[n-1]-times[0]
+	if duration > 0 {
+		switch wrap {
+		case Loop:
+			time = start + mod(time-start, duration)
+		case PingPong:
+			time = start + mod(time-start, 2*duration)
+			if time > start+duration {
+				time = 2*(start+duration) - time
+			}
+		}
+	}
+	if time <= start {
+		return 0, 0
+	}
+	if time >= times[n-1] {
+		return n - 2, 1
+	}
+	i = sort.Search(n-1, func(j int) bool { return times[j+1] > time })
+	return i, (time - times[i]) / (times[i+1] - times[i])
+}
+
+// mod returns x modulo y, in [0, y).
+func mod(x, y float32) float32 {
+	m := math.Mod(x, y)
+	if m < 0 {
+		m += y
+	}
+	return m
+}
+
+// neighbours returns the keyframes before i and after i+1 used by the cubic
+// interpolation, and their times. With Loop they wrap around, skipping the
+// last keyframe which is the first one again, otherwise they're clamped to i
+// and i+1.
+func neighbours(times []float32, wrap Wrap, i int) (prev, next int, tp, tn float32) {
+	n := len(times)
+	duration := times[n-1] - times[0]
+	prev, next, tp, tn = i, i+1, times[i], times[i+1]
+	if i > 0 {
+		prev, tp = i-1, times[i-1]
+	} else if wrap == Loop {
+		prev, tp = n-2, times[n-2]-duration
+	}
+	if i+2 < n {
+		next, tn = i+2, times[i+2]
+	} else if wrap == Loop {
+		next, tn = 1, times[1]+duration
+	}
+	return prev, next, tp, tn
+}
+
+// sample writes in v the interpolation of keyframes at u from keyframe i to
+// i+1. key returns the components of a keyframe.
+func sample(v []float32, times []float32, key func(i int) []float32, interpolation Interpolation, wrap Wrap, easing Easing, i int, u float32) {
+	if u == 0 || interpolation == InterpolateStep {
+		if u >= 1 {
+			i++
+		}
+		copy(v, key(i))
+		return
+	}
+	if easing != nil {
+		u = easing(u)
+	}
+	a, b := key(i), key(i+1)
+	if interpolation == InterpolateLinear {
+		for j := range v {
+			v[j] = a[j] + u*(b[j]-a[j])
+		}
+		return
+	}
+
+	// Cubic Hermite interpolation with the Catmull-Rom tangents of
+	// non-uniformly spaced keyframes, one-sided at the ends unless looping.
+	dt := times[i+1] - times[i]
+	prev, next, tp, tn := neighbours(times, wrap, i)
+	p, q := key(prev), key(next)
+	dp, dq := times[i+1]-tp, tn-times[i]
+	u2, u3 := u*u, u*u*u
+	h00, h10, h01, h11 := 2*u3-3*u2+1, u3-2*u2+u, -2*u3+3*u2, u3-u2
+	for j := range v {
+		m0 := (b[j] - p[j]) / dp * dt
+		m1 := (q[j] - a[j]) / dq * dt
+		v[j] = h00*a[j] + h10*m0 + h01*b[j] + h11*m1
+	}
+}
+
+// FloatTrack is an animation of a float32 value by keyframes. Times must be
+// increasing, with one value per time.
+type FloatTrack struct {
+	Times         []float32
+	Values        []float32
+	Interpolation Interpolation
+	Wrap          Wrap
+	// Easing, if not nil, eases the progress between each pair of
+	// keyframes.
+	Easing Easing
+}
+
+// Sample returns the value of the track at time. The track must have at least
+// one keyframe.
+func (t *FloatTrack) Sample(time float32) float32 {
+	var v [1]float32
+	i, u := locate(t.Times, t.Wrap, time)
+	sample(v[:], t.Times, func(k int) []float32 { return t.Values[k : k+1] }, t.Interpolation, t.Wrap, t.Easing, i, u)
+	return v[0]
+}
+
+// Vec2Track is an animation of a Vec2 value by keyframes, see FloatTrack.
+type Vec2Track struct {
+	Times         []float32
+	Values        []glm.Vec2
+	Interpolation Interpolation
+	Wrap          Wrap
+	Easing        Easing
+}
+
+// Sample returns the value of the track at time. The track must have at least
+// one keyframe.
+func (t *Vec2Track) Sample(time float32) glm.Vec2 {
+	var v glm.Vec2
+	i, u := locate(t.Times, t.Wrap, time)
+	sample(v[:], t.Times, func(k int) []float32 { return t.Values[k][:] }, t.Interpolation, t.Wrap, t.Easing, i, u)
+	return v
+}
+
+// Vec3Track is an animation of a Vec3 value by keyframes, see FloatTrack.
+type Vec3Track struct {
+	Times         []float32
+	Values        []glm.Vec3
+	Interpolation Interpolation
+	Wrap          Wrap
+	Easing        Easing
+}
+
+// Sample returns the value of the track at time. The track must have at least
+// one keyframe.
+func (t *Vec3Track) Sample(time float32) glm.Vec3 {
+	var v glm.Vec3
+	i, u := locate(t.Times, t.Wrap, time)
+	sample(v[:], t.Times, func(k int) []float32 { return t.Values[k][:] }, t.Interpolation, t.Wrap, t.Easing, i, u)
+	return v
+}
+
+// Vec4Track is an animation of a Vec4 value by keyframes, see FloatTrack.
+type Vec4Track struct {
+	Times         []float32
+	Values        []glm.Vec4
+	Interpolation Interpolation
+	Wrap          Wrap
+	Easing        Easing
+}
+
+// Sample returns the value of the track at time. The track must have at least
+// one keyframe.
+func (t *Vec4Track) Sample(time float32) glm.Vec4 {
+	var v glm.Vec4
+	i, u := locate(t.Times, t.Wrap, time)
+	sample(v[:], t.Times, func(k int) []float32 { return t.Values[k][:] }, t.Interpolation, t.Wrap, t.Easing, i, u)
+	return v
+}
+
+// QuatTrack is an animation of a rotation by keyframes, see FloatTrack. The
+// values must be unit quaternions, they are interpolated along the shortest
+// path. SQUAD ignores the spacing of the keyframes, the cubic interpolation
+// only has a continuous angular velocity if they are evenly spaced.
+type QuatTrack struct {
+	Times         []float32
+	Values        []glm.Quat
+	Interpolation Interpolation
+	Wrap          Wrap
+	Easing        Easing
+}
+
+// Sample returns the value of the track at time. The track must have at least
+// one keyframe.
+func (t *QuatTrack) Sample(time float32) glm.Quat {
+	i, u := locate(t.Times, t.Wrap, time)
+	if u == 0 || t.Interpolation == InterpolateStep {
+		if u >= 1 {
+			i++
+		}
+		return t.Values[i]
+	}
+	if t.Easing != nil {
+		u = t.Easing(u)
+	}
+	if t.Interpolation == InterpolateLinear {
+		b := t.Values[i+1]
+		if t.Values[i].Dot(&b) < 0 {
+			b.ScaleWith(-1)
+		}
+		return glm.QuatSlerp(&t.Values[i], &b, u)
+	}
+	prev, next, _, _ := neighbours(t.Times, t.Wrap, i)
+	return glm.QuatSquadSpline(&t.Values[prev], &t.Values[i], &t.Values[i+1], &t.Values[next], u)
+}
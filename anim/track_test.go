@@ -0,0 +1,140 @@
+package anim
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestFloatTrack(t *testing.T) {
+	t.Parallel()
+	track := FloatTrack{Times: []float32{1, 2, 4}, Values: []float32{10, 20, 0}}
+	tests := []struct {
+		interpolation Interpolation
+		wrap          Wrap
+		time, want    float32
+	}{
+		{InterpolateLinear, Clamp, 0, 10},
+		{InterpolateLinear, Clamp, 1.5, 15},
+		{InterpolateLinear, Clamp, 3, 10},
+		{InterpolateLinear, Clamp, 5, 0},
+		{InterpolateStep, Clamp, 1.9, 10},
+		{InterpolateStep, Clamp, 2, 20},
+		{InterpolateStep, Clamp, 5, 0},
+		{InterpolateLinear, Loop, 4.5, 15},
+		{InterpolateLinear, Loop, -0.5, 15},
+		{InterpolateLinear, PingPong, 4.5, 5},
+		{InterpolateLinear, PingPong, 6.5, 15},
+		{InterpolateLinear, PingPong, 7.5, 15},
+		{InterpolateCubic, Clamp, 2, 20},
+		{InterpolateCubic, Clamp, 4, 0},
+	}
+	for i, test := range tests {
+		track.Interpolation, track.Wrap = test.interpolation, test.wrap
+		if got := track.Sample(test.time); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("[%d] Sample(%f) = %f, want %f", i, test.time, got, test.want)
+		}
+	}
+
+	track = FloatTrack{Times: []float32{0, 1}, Values: []float32{0, 1}, Interpolation: InterpolateLinear, Easing: InQuad}
+	if got := track.Sample(0.5); got != 0.25 {
+		t.Errorf("eased Sample(0.5) = %f, want 0.25", got)
+	}
+	single := FloatTrack{Times: []float32{3}, Values: []float32{7}, Interpolation: InterpolateCubic, Wrap: Loop}
+	if got := single.Sample(10); got != 7 {
+		t.Errorf("single keyframe Sample(10) = %f, want 7", got)
+	}
+}
+
+func TestVec3Track(t *testing.T) {
+	t.Parallel()
+	// Cubic interpolation reproduces motion at constant velocity, even with
+	// uneven keyframes.
+	v := glm.Vec3{1, -2, 0.5}
+	times := []float32{0, 0.5, 2, 2.25, 4}
+	track := Vec3Track{Times: times, Interpolation: InterpolateCubic}
+	for _, time := range times {
+		track.Values = append(track.Values, v.Mul(time))
+	}
+	for _, time := range []float32{0.1, 0.7, 2.1, 3} {
+		want := v.Mul(time)
+		if got := track.Sample(time); !got.EqualThreshold(&want, 1e-4) {
+			t.Errorf("Sample(%f) = %v, want %v", time, got, want)
+		}
+	}
+
+	v2 := Vec2Track{Times: []float32{0, 1}, Values: []glm.Vec2{{0, 0}, {2, 4}}, Interpolation: InterpolateLinear}
+	if got := v2.Sample(0.25); got != (glm.Vec2{0.5, 1}) {
+		t.Errorf("Vec2Track Sample(0.25) = %v", got)
+	}
+	v4 := Vec4Track{Times: []float32{0, 1}, Values: []glm.Vec4{{0, 0, 0, 0}, {4, 4, 4, 4}}, Interpolation: InterpolateLinear, Wrap: PingPong}
+	if got := v4.Sample(1.25); got != (glm.Vec4{3, 3, 3, 3}) {
+		t.Errorf("Vec4Track Sample(1.25) = %v", got)
+	}
+}
+
+func TestQuatTrack(t *testing.T) {
+	t.Parallel()
+	z := glm.Vec3{0, 0, 1}
+	q0, q1, q2 := glm.QuatRotate(0, &z), glm.QuatRotate(math.Pi/2, &z), glm.QuatRotate(math.Pi, &z)
+	// -q2 is the same rotation, the interpolation still takes the short way.
+	q2.ScaleWith(-1)
+	track := QuatTrack{Times: []float32{0, 1, 2}, Values: []glm.Quat{q0, q1, q2}}
+	for _, interpolation := range []Interpolation{InterpolateLinear, InterpolateCubic} {
+		track.Interpolation = interpolation
+		var angle float32
+		for _, time := range []float32{0, 0.5, 1, 1.5, 2} {
+			got := track.Sample(time)
+			if math.Abs(got.V[0])+math.Abs(got.V[1]) > 1e-5 {
+				t.Errorf("interpolation %d: Sample(%f) = %v, not around Z", interpolation, time, got)
+			}
+			// The rotation keeps going the same way.
+			a := 2 * math.Atan2(got.V[2], got.W)
+			if time > 0 && a <= angle {
+				t.Errorf("interpolation %d: Sample(%f) angle = %f after %f", interpolation, time, a, angle)
+			}
+			angle = a
+			want := glm.QuatRotate(time*math.Pi/2, &z)
+			if (interpolation == InterpolateLinear || time == 1) && !got.OrientationEqualThreshold(&want, 1e-4) {
+				t.Errorf("interpolation %d: Sample(%f) = %v, want %v", interpolation, time, got, want)
+			}
+		}
+	}
+	track.Interpolation = InterpolateStep
+	if got := track.Sample(1.5); got != q1 {
+		t.Errorf("step Sample(1.5) = %v, want %v", got, q1)
+	}
+}
+
+func TestTrack_LoopCubic(t *testing.T) {
+	t.Parallel()
+	// The velocity is the same on both sides of the loop point, the last
+	// keyframe being the first one again.
+	const h = 1e-3
+	times := []float32{0, 1, 2.5, 3}
+	track := FloatTrack{Times: times, Values: []float32{0, 2, 1, 0}, Interpolation: InterpolateCubic, Wrap: Loop}
+	before := (track.Sample(3) - track.Sample(3-h)) / h
+	after := (track.Sample(3+h) - track.Sample(3)) / h
+	if math.Abs(before-after) > 0.05 {
+		t.Errorf("velocity %f before the loop point and %f after", before, after)
+	}
+
+	x, y := glm.Vec3{1, 0, 0}, glm.Vec3{0, 1, 0}
+	q0, q1, q2 := glm.QuatRotate(0.3, &x), glm.QuatRotate(1, &y), glm.QuatRotate(-0.5, &x)
+	// SQUAD is only smooth with evenly spaced keyframes.
+	quats := QuatTrack{Times: []float32{0, 1, 2, 3}, Values: []glm.Quat{q0, q1, q2, q0}, Interpolation: InterpolateCubic, Wrap: Loop}
+	// The angular velocity, q(t)^-1 * q(t+h) as an axis scaled by the angle.
+	velocity := func(time float32) glm.Vec3 {
+		a, b := quats.Sample(time), quats.Sample(time+h)
+		a.Invert()
+		d := a.Mul(&b)
+		if d.W < 0 {
+			d.ScaleWith(-1)
+		}
+		angle, axis := d.AxisAngle()
+		return axis.Mul(angle / h)
+	}
+	if v0, v1 := velocity(3-h), velocity(3); !v0.EqualThreshold(&v1, 0.05) {
+		t.Errorf("angular velocity %v before the loop point and %v after", v0, v1)
+	}
+}
@@ -0,0 +1,130 @@
+package camera
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Arcball turns mouse drags into rotations, as described by Ken Shoemake in
+// "ARCBALL: A User Interface for Specifying Three-Dimensional Orientation Using
+// a Mouse". Window coordinates are projected on a virtual ball centered on the
+// viewport and the rotation is twice the one between the two points on the
+// ball. A drag across the ball turns it a full turn, and since the rotations
+// don't depend on the path of the cursor, moving it back undoes them.
+//
+// The ball and the rotations are in view space, +Z pointing to the viewer. To
+// rotate a world space object use q' = o * r * o^-1 where o is the camera
+// orientation.
+type Arcball struct {
+	// X, Y, Width and Height are the viewport, with the same meaning as the
+	// arguments of glm.Project.
+	X, Y, Width, Height int
+
+	// Radius is the radius of the ball relative to the smallest half size of
+	// the viewport, 0 means 1.
+	Radius float32
+
+	// Bell uses Bell's virtual trackball, the ball is continued with a
+	// hyperbolic sheet instead of its silhouette. Rotations are smoother
+	// when the cursor leaves the ball.
+	Bell bool
+
+	// Axis constrains the rotations around this view space axis. The zero
+	// value means no constraint.
+	Axis glm.Vec3
+
+	// Follow makes the rotation the one between the two points on the ball,
+	// the point under the cursor stays under it, instead of twice that.
+	Follow bool
+}
+
+// SpherePoint returns the unit view space vector from the center of the ball
+// to the point under the window coordinates x, y. The Axis constraint is
+// ignored.
+func (a *Arcball) SpherePoint(x, y float32) glm.Vec3 {
+	radius := a.Radius
+	if radius == 0 {
+		radius = 1
+	}
+	radius *= 0.5 * float32(a.Width)
+	if a.Height < a.Width {
+		radius *= float32(a.Height) / float32(a.Width)
+	}
+
+	p := glm.Vec3{
+		(x - float32(a.X) - 0.5*float32(a.Width)) / radius,
+		(y - float32(a.Y) - 0.5*float32(a.Height)) / radius,
+		0,
+	}
+	d2 := p[0]*p[0] + p[1]*p[1]
+	switch {
+	case a.Bell && d2 > 0.5:
+		p[2] = 0.5 / math.Sqrt(d2)
+		p.Normalize()
+	case d2 <= 1:
+		p[2] = math.Sqrt(1 - d2)
+	default:
+		p.Normalize()
+	}
+	return p
+}
+
+// constrain projects the unit vector p on the plane perpendicular to the unit
+// vector axis.
+func constrain(p, axis *glm.Vec3) glm.Vec3 {
+	c := *p
+	c.AddScaledVec(-p.Dot(axis), axis)
+	if l := c.Len(); l > 1e-6 {
+		c.MulWith(1 / l)
+		// Stay on the front of the ball.
+		if c[2] < 0 {
+			c.MulWith(-1)
+		}
+		return c
+	}
+	// p is the axis, any perpendicular vector will do.
+	return perpendicular(axis)
+}
+
+// perpendicular returns a unit vector perpendicular to the unit vector v.
+func perpendicular(v *glm.Vec3) glm.Vec3 {
+	ref := glm.Vec3{1, 0, 0}
+	if math.Abs(v[0]) > 0.9 {
+		ref = glm.Vec3{0, 1, 0}
+	}
+	p := v.Cross(&ref)
+	p.Normalize()
+	return p
+}
+
+// Rotation returns the view space rotation of a drag from the window
+// coordinates x0, y0 to x1, y1. It's Shoemake's p1 * p0^-1 of the points on the
+// ball seen as quaternions, the rotation between them applied twice, or once
+// with Follow. With a constraint the rotation is around Axis only.
+func (a *Arcball) Rotation(x0, y0, x1, y1 float32) glm.Quat {
+	p0, p1 := a.SpherePoint(x0, y0), a.SpherePoint(x1, y1)
+	if a.Axis != (glm.Vec3{}) {
+		axis := a.Axis.Normalized()
+		p0, p1 = constrain(&p0, &axis), constrain(&p1, &axis)
+	}
+	if a.Follow {
+		return glm.QuatBetweenVectors(&p0, &p1)
+	}
+	return glm.Quat{W: p0.Dot(&p1), V: p0.Cross(&p1)}
+}
+
+// ClosestAxis returns the index of the axis whose constraint circle passes the
+// closest to the point under the window coordinates x, y, as Shoemake does to
+// pick a constraint automatically. It returns -1 if axes is empty.
+func (a *Arcball) ClosestAxis(axes []glm.Vec3, x, y float32) int {
+	p := a.SpherePoint(x, y)
+	best, max := -1, float32(-2)
+	for i := range axes {
+		axis := axes[i].Normalized()
+		c := constrain(&p, &axis)
+		if d := c.Dot(&p); d > max {
+			best, max = i, d
+		}
+	}
+	return best
+}
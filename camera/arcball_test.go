@@ -0,0 +1,125 @@
+package camera
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestArcball_SpherePoint(t *testing.T) {
+	t.Parallel()
+	a := Arcball{X: 100, Y: 50, Width: 400, Height: 200}
+	tests := []struct {
+		bell bool
+		x, y float32
+		want glm.Vec3
+	}{
+		{false, 300, 150, glm.Vec3{0, 0, 1}},
+		{false, 400, 150, glm.Vec3{1, 0, 0}},
+		{false, 300, 50, glm.Vec3{0, -1, 0}},
+		{false, 350, 150, glm.Vec3{0.5, 0, math.Sqrt(0.75)}},
+		{false, 500, 150, glm.Vec3{1, 0, 0}},
+		{true, 300, 150, glm.Vec3{0, 0, 1}},
+		{true, 350, 150, glm.Vec3{0.5, 0, math.Sqrt(0.75)}},
+		// On the hyperbolic sheet z = 0.5 / 2.
+		{true, 500, 150, glm.Vec3{2 / math.Sqrt(4.0625), 0, 0.25 / math.Sqrt(4.0625)}},
+	}
+	for i, test := range tests {
+		a.Bell = test.bell
+		if p := a.SpherePoint(test.x, test.y); !vecNear(&p, &test.want, 1e-5) {
+			t.Errorf("[%d] SpherePoint(%f, %f) = %v, want %v", i, test.x, test.y, p, test.want)
+		}
+	}
+}
+
+func TestArcball_Rotation(t *testing.T) {
+	t.Parallel()
+	drags := [][4]float32{
+		{400, 300, 400, 300},
+		{400, 300, 450, 300},
+		{380, 250, 500, 410},
+		{100, 300, 700, 300},
+		{650, 550, 200, 80},
+	}
+	for _, bell := range []bool{false, true} {
+		for _, follow := range []bool{false, true} {
+			a := Arcball{Width: 800, Height: 600, Bell: bell, Follow: follow}
+			for i, d := range drags {
+				q := a.Rotation(d[0], d[1], d[2], d[3])
+				if math.Abs(q.Len()-1) > 1e-5 {
+					t.Errorf("[%t,%t,%d] |q| = %f", bell, follow, i, q.Len())
+				}
+				// The point under the cursor follows it, or goes as far again
+				// past it.
+				p0, p1 := a.SpherePoint(d[0], d[1]), a.SpherePoint(d[2], d[3])
+				want := p1
+				if !follow {
+					want = p1.Mul(2 * p0.Dot(&p1))
+					want.SubWith(&p0)
+				}
+				if r := q.Rotate(&p0); !vecNear(&r, &want, 1e-4) {
+					t.Errorf("[%t,%t,%d] q.Rotate(%v) = %v, want %v", bell, follow, i, p0, r, want)
+				}
+			}
+		}
+	}
+
+	// Dragging back and forth along any path comes back to the start.
+	a := Arcball{Width: 800, Height: 600}
+	path := [][2]float32{{380, 250}, {500, 410}, {650, 550}, {200, 80}, {380, 250}}
+	total := glm.QuatIdent()
+	for i := 1; i < len(path); i++ {
+		q := a.Rotation(path[i-1][0], path[i-1][1], path[i][0], path[i][1])
+		total = q.Mul(&total)
+	}
+	if x, r := (glm.Vec3{1, 0, 0}), total.Rotate(&glm.Vec3{1, 0, 0}); !vecNear(&r, &x, 1e-4) {
+		t.Errorf("closed path rotation = %v", total)
+	}
+
+	// Dragging right turns around +Y.
+	q := a.Rotation(400, 300, 500, 300)
+	if angle, axis := q.AxisAngle(); angle <= 0 || !vecNear(&axis, &glm.Vec3{0, 1, 0}, 1e-5) {
+		t.Errorf("drag right = %f around %v", angle, axis)
+	}
+}
+
+func TestArcball_Axis(t *testing.T) {
+	t.Parallel()
+	axes := []glm.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+	a := Arcball{Width: 800, Height: 600}
+	for i, axis := range axes {
+		a.Axis = axis
+		n := axis.Normalized()
+		for j, d := range [][4]float32{{400, 300, 450, 320}, {380, 250, 500, 410}, {650, 550, 200, 80}} {
+			q := a.Rotation(d[0], d[1], d[2], d[3])
+			angle, got := q.AxisAngle()
+			if angle < 1e-4 {
+				continue
+			}
+			if c := got.Cross(&n); c.Len() > 1e-3 {
+				t.Errorf("[%d,%d] rotation axis %v, want %v", i, j, got, n)
+			}
+		}
+	}
+
+	a.Axis = glm.Vec3{}
+	tests := []struct {
+		x, y float32
+		want int
+	}{
+		// Near the vertical line through the center, the X axis circle.
+		{402, 500, 0},
+		// Near the horizontal line, the Y axis circle.
+		{600, 298, 1},
+		// Near the silhouette, the Z axis circle.
+		{612, 512, 2},
+	}
+	for i, test := range tests {
+		if got := a.ClosestAxis(axes[:3], test.x, test.y); got != test.want {
+			t.Errorf("[%d] ClosestAxis = %d, want %d", i, got, test.want)
+		}
+	}
+	if got := a.ClosestAxis(nil, 0, 0); got != -1 {
+		t.Errorf("ClosestAxis(nil) = %d, want -1", got)
+	}
+}
@@ -0,0 +1,268 @@
+// Package camera ties the view and projection functions of glm together. A
+// Camera caches its matrices and only recomputes them when something changed,
+// the controllers turn user input into a camera position and orientation.
+//
+// Like glm.LookAtV and glm.QuatLookAtV, cameras look down -Z and have +Y up in
+// their local space.
+package camera
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// dirty flags of the cached matrices.
+const (
+	dirtyView = 1 << iota
+	dirtyProj
+	dirtyViewProj
+	dirtyInvView
+	dirtyInvProj
+	dirtyInvViewProj
+
+	dirtyAllView = dirtyView | dirtyViewProj | dirtyInvView | dirtyInvViewProj
+	dirtyAllProj = dirtyProj | dirtyViewProj | dirtyInvProj | dirtyInvViewProj
+)
+
+// Camera is a position, an orientation and a projection. The view,
+// projection, view-projection and inverse matrices are computed lazily and
+// cached until the camera changes.
+type Camera struct {
+	position    glm.Vec3
+	orientation glm.Quat
+
+	ortho                    bool
+	fovy, aspect             float32
+	left, right, bottom, top float32
+	near, far                float32
+	clip                     glm.ClipSpace
+
+	viewportX, viewportY, viewportW, viewportH int
+
+	dirty                         uint8
+	view, proj, viewProj          glm.Mat4
+	invView, invProj, invViewProj glm.Mat4
+}
+
+// NewPerspective returns a camera at the origin looking down -Z with a
+// perspective projection. far may be glm.InfPos for an infinite far plane.
+func NewPerspective(fovy, aspect, near, far float32) *Camera {
+	c := &Camera{orientation: glm.QuatIdent(), dirty: dirtyAllView | dirtyAllProj}
+	c.SetPerspective(fovy, aspect, near, far)
+	return c
+}
+
+// NewOrtho returns a camera at the origin looking down -Z with an orthographic
+// projection.
+func NewOrtho(left, right, bottom, top, near, far float32) *Camera {
+	c := &Camera{orientation: glm.QuatIdent(), dirty: dirtyAllView | dirtyAllProj}
+	c.SetOrtho(left, right, bottom, top, near, far)
+	return c
+}
+
+// SetPerspective makes c use a perspective projection. far may be glm.InfPos
+// for an infinite far plane.
+func (c *Camera) SetPerspective(fovy, aspect, near, far float32) {
+	c.ortho = false
+	c.fovy, c.aspect, c.near, c.far = fovy, aspect, near, far
+	c.dirty |= dirtyAllProj
+}
+
+// SetOrtho makes c use an orthographic projection.
+func (c *Camera) SetOrtho(left, right, bottom, top, near, far float32) {
+	c.ortho = true
+	c.left, c.right, c.bottom, c.top = left, right, bottom, top
+	c.near, c.far = near, far
+	c.aspect = (right - left) / (top - bottom)
+	c.dirty |= dirtyAllProj
+}
+
+// IsOrtho returns true if c uses an orthographic projection.
+func (c *Camera) IsOrtho() bool {
+	return c.ortho
+}
+
+// SetAspect changes the aspect ratio (width / height) of the projection. For
+// orthographic projections the width is changed around its center.
+func (c *Camera) SetAspect(aspect float32) {
+	c.aspect = aspect
+	if c.ortho {
+		cx, hw := (c.left+c.right)*0.5, (c.top-c.bottom)*aspect*0.5
+		c.left, c.right = cx-hw, cx+hw
+	}
+	c.dirty |= dirtyAllProj
+}
+
+// Aspect returns the aspect ratio (width / height) of the projection.
+func (c *Camera) Aspect() float32 {
+	return c.aspect
+}
+
+// SetClipSpace changes the clip space convention of the projection, the zero
+// value is OpenGL's. When cs is left-handed the view matrix is as well.
+func (c *Camera) SetClipSpace(cs glm.ClipSpace) {
+	c.clip = cs
+	c.dirty |= dirtyAllView | dirtyAllProj
+}
+
+// ClipSpace returns the clip space convention of the projection.
+func (c *Camera) ClipSpace() glm.ClipSpace {
+	return c.clip
+}
+
+// SetViewport sets the window rectangle the camera renders to, in the same
+// units as glm.Project. It also updates the aspect ratio to match.
+func (c *Camera) SetViewport(x, y, width, height int) {
+	c.viewportX, c.viewportY, c.viewportW, c.viewportH = x, y, width, height
+	if height != 0 {
+		c.SetAspect(float32(width) / float32(height))
+	}
+}
+
+// Viewport returns the window rectangle the camera renders to.
+func (c *Camera) Viewport() (x, y, width, height int) {
+	return c.viewportX, c.viewportY, c.viewportW, c.viewportH
+}
+
+// Position returns the position of c in world space.
+func (c *Camera) Position() glm.Vec3 {
+	return c.position
+}
+
+// SetPosition moves c to p.
+func (c *Camera) SetPosition(p *glm.Vec3) {
+	c.position = *p
+	c.dirty |= dirtyAllView
+}
+
+// Orientation returns the rotation from camera space to world space.
+func (c *Camera) Orientation() glm.Quat {
+	return c.orientation
+}
+
+// SetOrientation sets the rotation from camera space to world space.
+func (c *Camera) SetOrientation(q *glm.Quat) {
+	c.orientation = q.Normalized()
+	c.dirty |= dirtyAllView
+}
+
+// LookAt moves c to eye and turns it towards center, up is a hint for the
+// vertical direction. It's the same as glm.LookAtV.
+func (c *Camera) LookAt(eye, center, up *glm.Vec3) {
+	m := glm.LookAtV(eye, center, up)
+	r := m.Mat3()
+	r.Transpose()
+	q := glm.Mat3ToQuat(&r)
+	c.position = *eye
+	c.SetOrientation(&q)
+}
+
+// Forward returns the direction c is looking at in world space.
+func (c *Camera) Forward() glm.Vec3 {
+	return c.orientation.Rotate(&glm.Vec3{0, 0, -1})
+}
+
+// Right returns the right direction of c in world space.
+func (c *Camera) Right() glm.Vec3 {
+	return c.orientation.Rotate(&glm.Vec3{1, 0, 0})
+}
+
+// Up returns the up direction of c in world space.
+func (c *Camera) Up() glm.Vec3 {
+	return c.orientation.Rotate(&glm.Vec3{0, 1, 0})
+}
+
+// View returns the matrix that transforms world space into view space.
+func (c *Camera) View() glm.Mat4 {
+	if c.dirty&dirtyView != 0 {
+		inv := c.orientation.Conjugated()
+		c.view = inv.Mat4()
+		t := inv.Rotate(&c.position)
+		c.view[12], c.view[13], c.view[14] = -t[0], -t[1], -t[2]
+		if c.clip.LeftHanded {
+			c.view[2], c.view[6], c.view[10], c.view[14] = -c.view[2], -c.view[6], -c.view[10], -c.view[14]
+		}
+		c.dirty &^= dirtyView
+	}
+	return c.view
+}
+
+// Projection returns the matrix that transforms view space into clip space.
+func (c *Camera) Projection() glm.Mat4 {
+	if c.dirty&dirtyProj != 0 {
+		if c.ortho {
+			c.proj = c.clip.Ortho(c.left, c.right, c.bottom, c.top, c.near, c.far)
+		} else {
+			c.proj = c.clip.Perspective(c.fovy, c.aspect, c.near, c.far)
+		}
+		c.dirty &^= dirtyProj
+	}
+	return c.proj
+}
+
+// ViewProjection returns the matrix that transforms world space into clip
+// space, Projection() * View().
+func (c *Camera) ViewProjection() glm.Mat4 {
+	if c.dirty&dirtyViewProj != 0 {
+		v, p := c.View(), c.Projection()
+		c.viewProj.Mul4Of(&p, &v)
+		c.dirty &^= dirtyViewProj
+	}
+	return c.viewProj
+}
+
+// InverseView returns the matrix that transforms view space into world space.
+func (c *Camera) InverseView() glm.Mat4 {
+	if c.dirty&dirtyInvView != 0 {
+		v := c.View()
+		c.invView.InverseOf(&v)
+		c.dirty &^= dirtyInvView
+	}
+	return c.invView
+}
+
+// InverseProjection returns the matrix that transforms clip space into view
+// space.
+func (c *Camera) InverseProjection() glm.Mat4 {
+	if c.dirty&dirtyInvProj != 0 {
+		p := c.Projection()
+		c.invProj.InverseOf(&p)
+		c.dirty &^= dirtyInvProj
+	}
+	return c.invProj
+}
+
+// InverseViewProjection returns the matrix that transforms clip space into
+// world space.
+func (c *Camera) InverseViewProjection() glm.Mat4 {
+	if c.dirty&dirtyInvViewProj != 0 {
+		vp := c.ViewProjection()
+		c.invViewProj.InverseOf(&vp)
+		c.dirty &^= dirtyInvViewProj
+	}
+	return c.invViewProj
+}
+
+// Project transforms the world space point p to window coordinates (with
+// depth) in the viewport of c.
+func (c *Camera) Project(p *glm.Vec3) glm.Vec3 {
+	v, proj := c.View(), c.Projection()
+	return c.clip.Project(p, &v, &proj, c.viewportX, c.viewportY, c.viewportW, c.viewportH)
+}
+
+// UnProject transforms the window coordinates win (with depth) in the
+// viewport of c to world space.
+func (c *Camera) UnProject(win *glm.Vec3) glm.Vec3 {
+	v, proj := c.View(), c.Projection()
+	return c.clip.UnProject(win, &v, &proj, c.viewportX, c.viewportY, c.viewportW, c.viewportH)
+}
+
+// ScreenRay returns the world space ray going through the window coordinates
+// x, y of the viewport. The origin is on the near plane and the direction is
+// normalized. It works for both perspective and orthographic projections.
+//
+// Window coordinates follow glm.Project, with OpenGL's convention y goes up
+// from the bottom of the viewport.
+func (c *Camera) ScreenRay(x, y float32) glm.Ray {
+	v, proj := c.View(), c.Projection()
+	return c.clip.UnProjectRay(x, y, &v, &proj, c.viewportX, c.viewportY, c.viewportW, c.viewportH)
+}
@@ -0,0 +1,127 @@
+package camera
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestCamera_LookAt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		eye, center, up glm.Vec3
+	}{
+		{glm.Vec3{0, 0, 0}, glm.Vec3{0, 0, -1}, glm.Vec3{0, 1, 0}},
+		{glm.Vec3{1, 2, 3}, glm.Vec3{4, -1, 0}, glm.Vec3{0, 1, 0}},
+		{glm.Vec3{-5, 0, 2}, glm.Vec3{0, 0, 0}, glm.Vec3{0, 0, 1}},
+	}
+	c := NewPerspective(math.Pi/3, 1, 0.1, 100)
+	for i, test := range tests {
+		c.LookAt(&test.eye, &test.center, &test.up)
+		want := glm.LookAtV(&test.eye, &test.center, &test.up)
+		if v := c.View(); !matNear(&v, &want, 1e-5) {
+			t.Errorf("[%d] View() = %v, want %v", i, v, want)
+		}
+		dir := test.center.Sub(&test.eye)
+		dir.Normalize()
+		if f := c.Forward(); !vecNear(&f, &dir, 1e-5) {
+			t.Errorf("[%d] Forward() = %v, want %v", i, f, dir)
+		}
+	}
+}
+
+func TestCamera_Cache(t *testing.T) {
+	t.Parallel()
+	c := NewPerspective(math.Pi/2, 1.5, 0.5, 50)
+	c.SetPosition(&glm.Vec3{1, 2, 3})
+	ident := glm.Ident4()
+
+	check := func(step string) {
+		v, p, vp := c.View(), c.Projection(), c.ViewProjection()
+		if want := p.Mul4(&v); !matNear(&vp, &want, 1e-5) {
+			t.Errorf("%s: ViewProjection() = %v, want %v", step, vp, want)
+		}
+		pairs := [][2]glm.Mat4{
+			{c.InverseView(), v},
+			{c.InverseProjection(), p},
+			{c.InverseViewProjection(), vp},
+		}
+		for j, pair := range pairs {
+			if m := pair[0].Mul4(&pair[1]); !matNear(&m, &ident, 1e-4) {
+				t.Errorf("%s: [%d] inverse * m = %v", step, j, m)
+			}
+		}
+	}
+
+	check("initial")
+	c.SetPosition(&glm.Vec3{-4, 0, 1})
+	check("SetPosition")
+	q := glm.QuatRotate(1, &glm.Vec3{0, 1, 0})
+	c.SetOrientation(&q)
+	check("SetOrientation")
+	c.SetViewport(0, 0, 640, 480)
+	check("SetViewport")
+	c.SetOrtho(-2, 2, -1, 1, 0.1, 10)
+	check("SetOrtho")
+	c.SetClipSpace(glm.ClipVulkan)
+	check("SetClipSpace")
+
+	if want := glm.ClipVulkan.Ortho(-2, 2, -1, 1, 0.1, 10); c.Projection() != want {
+		t.Errorf("Projection() = %v, want %v", c.Projection(), want)
+	}
+}
+
+func TestCamera_ScreenRay(t *testing.T) {
+	t.Parallel()
+	cams := []*Camera{
+		NewPerspective(math.Pi/3, 1, 0.1, 100),
+		NewPerspective(math.Pi/3, 1, 0.1, glm.InfPos),
+		NewOrtho(-4, 4, -3, 3, 0.1, 100),
+	}
+	clips := []glm.ClipSpace{
+		glm.ClipOpenGL,
+		glm.ClipVulkan,
+		glm.ClipDirect3D,
+		{ZeroToOne: true, ReverseZ: true},
+	}
+	for i, c := range cams {
+		for j, cs := range clips {
+			c.SetClipSpace(cs)
+			c.SetViewport(0, 0, 800, 600)
+			c.LookAt(&glm.Vec3{3, 4, 5}, &glm.Vec3{0, 1, 0}, &glm.Vec3{0, 1, 0})
+
+			// The center of the screen looks forward.
+			ray := c.ScreenRay(400, 300)
+			if f := c.Forward(); !vecNear(&ray.Direction, &f, 1e-4) {
+				t.Errorf("[%d,%d] center dir = %v, want %v", i, j, ray.Direction, f)
+			}
+
+			// Points along the ray project back to the same pixel.
+			ray = c.ScreenRay(123, 456)
+			if math.Abs(ray.Direction.Len()-1) > 1e-5 {
+				t.Errorf("[%d,%d] |dir| = %f", i, j, ray.Direction.Len())
+			}
+			for _, dist := range []float32{0, 1, 10} {
+				p := ray.At(dist)
+				win := c.Project(&p)
+				if math.Abs(win[0]-123) > 0.05 || math.Abs(win[1]-456) > 0.05 {
+					t.Errorf("[%d,%d] Project(%v) = %v, want 123, 456", i, j, p, win)
+				}
+			}
+		}
+	}
+}
+
+func matNear(a, b *glm.Mat4, tol float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func vecNear(a, b *glm.Vec3, tol float32) bool {
+	d := a.Sub(b)
+	return d.Len() <= tol
+}
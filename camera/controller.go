@@ -0,0 +1,180 @@
+package camera
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// DefaultPitchLimit is the pitch limit used by the controllers when theirs is
+// 0. It stays just under straight up or down to avoid the singularity.
+const DefaultPitchLimit = math.Pi/2 - 0.001
+
+// yawPitch returns the rotation of yaw radians around the world Y axis
+// followed by pitch radians around the local X axis.
+func yawPitch(yaw, pitch float32) glm.Quat {
+	y := glm.QuatRotate(yaw, &glm.Vec3{0, 1, 0})
+	p := glm.QuatRotate(pitch, &glm.Vec3{1, 0, 0})
+	return y.Mul(&p)
+}
+
+// clampPitch clamps pitch to [-limit, limit], using DefaultPitchLimit when
+// limit is 0.
+func clampPitch(pitch, limit float32) float32 {
+	if limit == 0 {
+		limit = DefaultPitchLimit
+	}
+	return math.Clamp(pitch, -limit, limit)
+}
+
+// FirstPerson is a first person controller. The camera turns around the world
+// Y axis (yaw) and tilts up and down (pitch) without ever rolling.
+type FirstPerson struct {
+	Position glm.Vec3
+
+	// Yaw is the angle around the world Y axis, 0 looks down -Z and positive
+	// values turn left.
+	Yaw float32
+
+	// Pitch is the angle above the horizon, positive values look up.
+	Pitch float32
+
+	// PitchLimit is the maximum absolute pitch, 0 means DefaultPitchLimit.
+	PitchLimit float32
+}
+
+// Look turns the controller by dyaw and dpitch radians, clamping the pitch.
+func (f *FirstPerson) Look(dyaw, dpitch float32) {
+	f.Yaw = math.Mod(f.Yaw+dyaw, 2*math.Pi)
+	f.Pitch = clampPitch(f.Pitch+dpitch, f.PitchLimit)
+}
+
+// Move moves the controller forward and right on the horizontal plane,
+// ignoring the pitch, and up along the world Y axis.
+func (f *FirstPerson) Move(forward, right, up float32) {
+	s, c := math.Sincos(f.Yaw)
+	f.Position[0] += -s*forward + c*right
+	f.Position[1] += up
+	f.Position[2] += -c*forward - s*right
+}
+
+// Orientation returns the rotation from camera space to world space.
+func (f *FirstPerson) Orientation() glm.Quat {
+	return yawPitch(f.Yaw, clampPitch(f.Pitch, f.PitchLimit))
+}
+
+// Apply sets the position and orientation of c.
+func (f *FirstPerson) Apply(c *Camera) {
+	q := f.Orientation()
+	c.SetPosition(&f.Position)
+	c.SetOrientation(&q)
+}
+
+// Orbit is a controller turning around a target, as used by model viewers and
+// editors.
+type Orbit struct {
+	Target glm.Vec3
+
+	// Distance is the distance between the camera and the target.
+	Distance float32
+
+	// MinDistance and MaxDistance clamp Distance when zooming, 0 means no
+	// limit.
+	MinDistance, MaxDistance float32
+
+	// Yaw is the angle around the world Y axis, 0 puts the camera on the +Z
+	// side of the target.
+	Yaw float32
+
+	// Pitch is the angle of the camera above the target, positive values
+	// look down on it.
+	Pitch float32
+
+	// PitchLimit is the maximum absolute pitch, 0 means DefaultPitchLimit.
+	PitchLimit float32
+}
+
+// Rotate turns the camera around the target by dyaw and dpitch radians.
+func (o *Orbit) Rotate(dyaw, dpitch float32) {
+	o.Yaw = math.Mod(o.Yaw+dyaw, 2*math.Pi)
+	o.Pitch = clampPitch(o.Pitch+dpitch, o.PitchLimit)
+}
+
+// Zoom multiplies the distance to the target by factor, values under 1 get
+// closer.
+func (o *Orbit) Zoom(factor float32) {
+	o.Distance *= factor
+	if o.MinDistance != 0 && o.Distance < o.MinDistance {
+		o.Distance = o.MinDistance
+	}
+	if o.MaxDistance != 0 && o.Distance > o.MaxDistance {
+		o.Distance = o.MaxDistance
+	}
+}
+
+// Pan moves the target right and up in the plane of the screen.
+func (o *Orbit) Pan(right, up float32) {
+	q := o.Orientation()
+	r := q.Rotate(&glm.Vec3{1, 0, 0})
+	u := q.Rotate(&glm.Vec3{0, 1, 0})
+	o.Target.AddScaledVec(right, &r)
+	o.Target.AddScaledVec(up, &u)
+}
+
+// Orientation returns the rotation from camera space to world space.
+func (o *Orbit) Orientation() glm.Quat {
+	return yawPitch(o.Yaw, -clampPitch(o.Pitch, o.PitchLimit))
+}
+
+// Position returns the position of the camera in world space.
+func (o *Orbit) Position() glm.Vec3 {
+	q := o.Orientation()
+	p := q.Rotate(&glm.Vec3{0, 0, o.Distance})
+	p.AddWith(&o.Target)
+	return p
+}
+
+// Apply sets the position and orientation of c.
+func (o *Orbit) Apply(c *Camera) {
+	q, p := o.Orientation(), o.Position()
+	c.SetPosition(&p)
+	c.SetOrientation(&q)
+}
+
+// FreeFly is a controller with 6 degrees of freedom, like a spaceship. All
+// the rotations and movements are relative to the current orientation.
+type FreeFly struct {
+	Position glm.Vec3
+
+	// Orientation is the rotation from camera space to world space.
+	Orientation glm.Quat
+}
+
+// NewFreeFly returns a FreeFly at position looking down -Z.
+func NewFreeFly(position *glm.Vec3) FreeFly {
+	return FreeFly{Position: *position, Orientation: glm.QuatIdent()}
+}
+
+// Rotate turns the controller by yaw radians around its up axis (positive
+// turns left), pitch radians around its right axis (positive looks up) and
+// roll radians around its forward axis (positive rolls right).
+func (f *FreeFly) Rotate(yaw, pitch, roll float32) {
+	y := glm.QuatRotate(yaw, &glm.Vec3{0, 1, 0})
+	p := glm.QuatRotate(pitch, &glm.Vec3{1, 0, 0})
+	r := glm.QuatRotate(roll, &glm.Vec3{0, 0, -1})
+	f.Orientation.MulWith(&y)
+	f.Orientation.MulWith(&p)
+	f.Orientation.MulWith(&r)
+	f.Orientation.Normalize()
+}
+
+// Move moves the controller along its own forward, right and up axes.
+func (f *FreeFly) Move(forward, right, up float32) {
+	d := f.Orientation.Rotate(&glm.Vec3{right, up, -forward})
+	f.Position.AddWith(&d)
+}
+
+// Apply sets the position and orientation of c.
+func (f *FreeFly) Apply(c *Camera) {
+	c.SetPosition(&f.Position)
+	c.SetOrientation(&f.Orientation)
+}
@@ -0,0 +1,115 @@
+package camera
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestFirstPerson(t *testing.T) {
+	t.Parallel()
+	var f FirstPerson
+	c := NewPerspective(math.Pi/3, 1, 0.1, 100)
+
+	f.Look(math.Pi/2, 0)
+	f.Apply(c)
+	if fw, want := c.Forward(), (glm.Vec3{-1, 0, 0}); !vecNear(&fw, &want, 1e-5) {
+		t.Errorf("yaw 90: Forward() = %v, want %v", fw, want)
+	}
+	f.Move(2, 1, 0.5)
+	if want := (glm.Vec3{-2, 0.5, -1}); !vecNear(&f.Position, &want, 1e-5) {
+		t.Errorf("Move: Position = %v, want %v", f.Position, want)
+	}
+
+	// Pitch is clamped and never flips the camera over.
+	f.Look(0, 10)
+	if f.Pitch != DefaultPitchLimit {
+		t.Errorf("Pitch = %f, want %f", f.Pitch, DefaultPitchLimit)
+	}
+	f.PitchLimit = 0.5
+	f.Look(0, -10)
+	if f.Pitch != -0.5 {
+		t.Errorf("Pitch = %f, want -0.5", f.Pitch)
+	}
+	f.Apply(c)
+	if up := c.Up(); up[1] <= 0 {
+		t.Errorf("Up() = %v, camera rolled over", up)
+	}
+	if r := c.Right(); math.Abs(r[1]) > 1e-5 {
+		t.Errorf("Right() = %v, camera rolled", r)
+	}
+}
+
+func TestOrbit(t *testing.T) {
+	t.Parallel()
+	o := Orbit{
+		Target:      glm.Vec3{1, 2, 3},
+		Distance:    5,
+		MinDistance: 1,
+		MaxDistance: 10,
+	}
+	c := NewPerspective(math.Pi/3, 1, 0.1, 100)
+	for _, r := range [][2]float32{{0, 0}, {1, 0.3}, {2, -0.5}, {-4, 4}} {
+		o.Rotate(r[0], r[1])
+		o.Apply(c)
+		p := c.Position()
+		if d := p.Sub(&o.Target); math.Abs(d.Len()-o.Distance) > 1e-4 {
+			t.Errorf("%v: distance = %f, want %f", r, d.Len(), o.Distance)
+		}
+		// The camera always looks at the target.
+		dir := o.Target.Sub(&p)
+		dir.Normalize()
+		if f := c.Forward(); !vecNear(&f, &dir, 1e-4) {
+			t.Errorf("%v: Forward() = %v, want %v", r, f, dir)
+		}
+	}
+
+	o.Zoom(0.1)
+	if o.Distance != 1 {
+		t.Errorf("Distance = %f, want 1", o.Distance)
+	}
+	o.Zoom(100)
+	if o.Distance != 10 {
+		t.Errorf("Distance = %f, want 10", o.Distance)
+	}
+
+	// Panning moves the target in the screen plane.
+	o.Apply(c)
+	before := c.Project(&o.Target)
+	target := o.Target
+	o.Pan(1, 1)
+	o.Apply(c)
+	if after := c.Project(&o.Target); !vecNear(&after, &before, 1e-3) {
+		t.Errorf("target moved on screen from %v to %v", before, after)
+	}
+	if d := o.Target.Sub(&target); math.Abs(d.Len()-math.Sqrt2) > 1e-4 {
+		t.Errorf("Pan moved the target by %v", d)
+	}
+}
+
+func TestFreeFly(t *testing.T) {
+	t.Parallel()
+	f := NewFreeFly(&glm.Vec3{0, 0, 0})
+	c := NewPerspective(math.Pi/3, 1, 0.1, 100)
+
+	f.Rotate(0, 0, math.Pi/2)
+	f.Apply(c)
+	if fw, want := c.Forward(), (glm.Vec3{0, 0, -1}); !vecNear(&fw, &want, 1e-5) {
+		t.Errorf("roll: Forward() = %v, want %v", fw, want)
+	}
+	if r, want := c.Right(), (glm.Vec3{0, -1, 0}); !vecNear(&r, &want, 1e-5) {
+		t.Errorf("roll: Right() = %v, want %v", r, want)
+	}
+
+	// Yaw is around the rolled up axis, which is now world +X.
+	f.Rotate(math.Pi/2, 0, 0)
+	f.Apply(c)
+	if fw, want := c.Forward(), (glm.Vec3{0, 1, 0}); !vecNear(&fw, &want, 1e-5) {
+		t.Errorf("yaw: Forward() = %v, want %v", fw, want)
+	}
+
+	f.Move(2, 0, 0)
+	if want := (glm.Vec3{0, 2, 0}); !vecNear(&f.Position, &want, 1e-5) {
+		t.Errorf("Move: Position = %v, want %v", f.Position, want)
+	}
+}
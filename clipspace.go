@@ -0,0 +1,182 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// ClipSpace describes the conventions a graphics API uses for view and clip
+// space. The zero value is the OpenGL convention used by Perspective, Frustum
+// and Ortho: right-handed view space looking down -Z, Y up and depth in
+// [-1, 1].
+type ClipSpace struct {
+	// ZeroToOne maps depth to [0, 1] instead of [-1, 1], like Vulkan,
+	// Direct3D and Metal.
+	ZeroToOne bool
+
+	// ReverseZ maps the near plane to the maximum depth and the far plane to
+	// the minimum depth. Combined with ZeroToOne and a floating point depth
+	// buffer this distributes precision almost evenly over the whole range.
+	ReverseZ bool
+
+	// LeftHanded makes the camera look down +Z in view space instead of -Z.
+	LeftHanded bool
+
+	// FlipY makes Y point down in clip space, like Vulkan.
+	FlipY bool
+}
+
+var (
+	// ClipOpenGL is the OpenGL convention, the same as the zero value.
+	ClipOpenGL = ClipSpace{}
+
+	// ClipVulkan is the Vulkan convention with a right-handed view space.
+	ClipVulkan = ClipSpace{ZeroToOne: true, FlipY: true}
+
+	// ClipDirect3D is the Direct3D convention with a left-handed view space.
+	ClipDirect3D = ClipSpace{ZeroToOne: true, LeftHanded: true}
+
+	// ClipMetal is the Metal convention with a right-handed view space.
+	ClipMetal = ClipSpace{ZeroToOne: true}
+)
+
+// depthRange returns the depth that the near and far planes map to.
+func (cs *ClipSpace) depthRange() (near, far float32) {
+	near, far = -1, 1
+	if cs.ZeroToOne {
+		near = 0
+	}
+	if cs.ReverseZ {
+		near, far = far, near
+	}
+	return
+}
+
+// handedness returns the sign of the view space Z axis pointing forward.
+func (cs *ClipSpace) handedness() float32 {
+	if cs.LeftHanded {
+		return 1
+	}
+	return -1
+}
+
+// yScale returns the sign applied to Y in clip space.
+func (cs *ClipSpace) yScale() float32 {
+	if cs.FlipY {
+		return -1
+	}
+	return 1
+}
+
+// Perspective returns a Mat4 representing a perspective projection of the
+// given arguments in the cs convention. far may be InfPos for an infinite far
+// plane.
+func (cs *ClipSpace) Perspective(fovy, aspect, near, far float32) Mat4 {
+	top := near * math.Tan(fovy/2)
+	right := top * aspect
+	return cs.Frustum(-right, right, -top, top, near, far)
+}
+
+// Frustum returns a Mat4 representing a frustum transform (squared pyramid
+// with the top cut off) in the cs convention. far may be InfPos for an
+// infinite far plane.
+func (cs *ClipSpace) Frustum(left, right, bottom, top, near, far float32) Mat4 {
+	rml, tmb := 1/(right-left), 1/(top-bottom)
+	s, y := cs.handedness(), cs.yScale()
+	dn, df := cs.depthRange()
+
+	// With d the distance in front of the camera, depth = p + q/d maps near
+	// to dn and far to df.
+	var p, q float32
+	if math.IsInf(far, 1) {
+		p, q = df, (dn-df)*near
+	} else {
+		fmn := 1 / (far - near)
+		p, q = (df*far-dn*near)*fmn, (dn-df)*near*far*fmn
+	}
+
+	return Mat4{
+		2 * near * rml, 0, 0, 0,
+		0, 2 * near * tmb * y, 0, 0,
+		-s * (right + left) * rml, -s * (top + bottom) * tmb * y, s * p, s,
+		0, 0, q, 0,
+	}
+}
+
+// Ortho returns a Mat4 that represents a orthographic projection from the
+// given arguments in the cs convention. The far plane must be finite.
+func (cs *ClipSpace) Ortho(left, right, bottom, top, near, far float32) Mat4 {
+	rml, tmb, fmn := 1/(right-left), 1/(top-bottom), 1/(far-near)
+	s, y := cs.handedness(), cs.yScale()
+	dn, df := cs.depthRange()
+
+	// With d the distance in front of the camera, depth = p + q*d maps near
+	// to dn and far to df.
+	q := (df - dn) * fmn
+	p := dn - q*near
+
+	return Mat4{
+		2 * rml, 0, 0, 0,
+		0, 2 * tmb * y, 0, 0,
+		0, 0, s * q, 0,
+		-(right + left) * rml, -(top + bottom) * tmb * y, p, 1,
+	}
+}
+
+// Ortho2D is equivalent to Ortho with the near and far planes being -1 and 1,
+// respectively.
+func (cs *ClipSpace) Ortho2D(left, right, bottom, top float32) Mat4 {
+	return cs.Ortho(left, right, bottom, top, -1, 1)
+}
+
+// Project transforms a set of coordinates from object space (in obj) to window
+// coordinates (with depth in [0, 1]) for a projection in the cs convention.
+//
+// Window coordinates are continuous, not discrete, so you won't get exact pixel
+// locations without rounding.
+func (cs *ClipSpace) Project(obj *Vec3, modelview, projection *Mat4, initialX, initialY, width, height int) Vec3 {
+	obj4 := obj.Vec4(1)
+
+	pm := projection.Mul4(modelview)
+	vpp := pm.Mul4x1(&obj4)
+	over := 1 / vpp[3]
+	win := Vec3{
+		float32(initialX) + (float32(width)*(vpp[0]*over+1))*0.5,
+		float32(initialY) + (float32(height)*(vpp[1]*over+1))*0.5,
+		vpp[2] * over,
+	}
+	if !cs.ZeroToOne {
+		win[2] = (win[2] + 1) * 0.5
+	}
+	return win
+}
+
+// UnProject transforms a set of window coordinates to object space for a
+// projection in the cs convention. If your MVP matrix is not invertible this
+// will return garbage. With an infinite far plane the window depth of the far
+// plane is at infinity and can't be unprojected.
+//
+// Note that the projection may not be perfect if you use strict pixel locations
+// rather than the exact values given by Project.
+func (cs *ClipSpace) UnProject(win *Vec3, modelview, projection *Mat4, initialX, initialY, width, height int) Vec3 {
+	pm := projection.Mul4(modelview)
+	inv := pm.Inverse()
+
+	z := win[2]
+	if !cs.ZeroToOne {
+		z = 2*z - 1
+	}
+	obj4 := inv.Mul4x1(&Vec4{
+		(2 * (win[0] - float32(initialX)) / float32(width)) - 1,
+		(2 * (win[1] - float32(initialY)) / float32(height)) - 1,
+		z,
+		1.0,
+	})
+	obj := obj4.Vec3()
+
+	over := 1 / obj4[3]
+	obj[0] *= over
+	obj[1] *= over
+	obj[2] *= over
+
+	return obj
+}
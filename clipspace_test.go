@@ -0,0 +1,97 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+func TestClipSpace_OpenGL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		got, want Mat4
+	}{
+		{ClipOpenGL.Perspective(math.Pi/3, 1.5, 0.1, 100), Perspective(math.Pi/3, 1.5, 0.1, 100)},
+		{ClipOpenGL.Frustum(-1, 2, -3, 0.5, 1, 10), Frustum(-1, 2, -3, 0.5, 1, 10)},
+		{ClipOpenGL.Ortho(-1, 2, -3, 0.5, 1, 10), Ortho(-1, 2, -3, 0.5, 1, 10)},
+		{ClipOpenGL.Ortho2D(0, 800, 0, 600), Ortho2D(0, 800, 0, 600)},
+	}
+	for i, test := range tests {
+		if !test.got.EqualThreshold(&test.want, 1e-5) {
+			t.Errorf("[%d] got %v, want %v", i, test.got, test.want)
+		}
+	}
+}
+
+func TestClipSpace_Depth(t *testing.T) {
+	t.Parallel()
+	const near, far = 0.5, 50
+	spaces := []ClipSpace{
+		ClipOpenGL,
+		ClipVulkan,
+		ClipDirect3D,
+		ClipMetal,
+		{ZeroToOne: true, ReverseZ: true},
+		{ReverseZ: true, LeftHanded: true, FlipY: true},
+	}
+	view := Ident4()
+	for i, cs := range spaces {
+		forward := cs.handedness()
+		wantNear, wantFar := float32(0), float32(1)
+		if cs.ReverseZ {
+			wantNear, wantFar = 1, 0
+		}
+		projs := []Mat4{
+			cs.Perspective(math.Pi/2, 4.0/3, near, far),
+			cs.Frustum(-0.3, 0.5, -0.2, 0.4, near, far),
+			cs.Ortho(-4, 6, -3, 5, near, far),
+		}
+		for j, proj := range projs {
+			for _, d := range []struct{ dist, depth float32 }{{near, wantNear}, {far, wantFar}} {
+				obj := Vec3{0.1, 0.2, forward * d.dist}
+				win := cs.Project(&obj, &view, &proj, 0, 0, 800, 600)
+				if math.Abs(win[2]-d.depth) > 1e-4 {
+					t.Errorf("[%d,%d] depth of %v = %f, want %f", i, j, obj, win[2], d.depth)
+				}
+				back := cs.UnProject(&win, &view, &proj, 0, 0, 800, 600)
+				if !back.EqualThreshold(&obj, 1e-3) {
+					t.Errorf("[%d,%d] UnProject(Project(%v)) = %v", i, j, obj, back)
+				}
+			}
+
+			up := Vec3{0, 2, forward * near * 2}
+			win := cs.Project(&up, &view, &proj, 0, 0, 800, 600)
+			if cs.FlipY != (win[1] < 300) {
+				t.Errorf("[%d,%d] y of %v = %f, FlipY = %t", i, j, up, win[1], cs.FlipY)
+			}
+		}
+	}
+}
+
+func TestClipSpace_InfiniteFar(t *testing.T) {
+	t.Parallel()
+	spaces := []ClipSpace{
+		ClipOpenGL,
+		ClipVulkan,
+		{ZeroToOne: true, ReverseZ: true},
+	}
+	view := Ident4()
+	for i, cs := range spaces {
+		proj := cs.Perspective(math.Pi/2, 1, 0.1, InfPos)
+		wantNear, wantFar := float32(0), float32(1)
+		if cs.ReverseZ {
+			wantNear, wantFar = 1, 0
+		}
+		for _, d := range []struct{ dist, depth float32 }{{0.1, wantNear}, {1e6, wantFar}} {
+			obj := Vec3{0, 0, -d.dist}
+			win := cs.Project(&obj, &view, &proj, 0, 0, 100, 100)
+			if math.Abs(win[2]-d.depth) > 1e-4 {
+				t.Errorf("[%d] depth at %f = %f, want %f", i, d.dist, win[2], d.depth)
+			}
+		}
+		obj := Vec3{1, -2, -30}
+		win := cs.Project(&obj, &view, &proj, 0, 0, 100, 100)
+		if back := cs.UnProject(&win, &view, &proj, 0, 0, 100, 100); !back.EqualThreshold(&obj, 1e-3) {
+			t.Errorf("[%d] UnProject(Project(%v)) = %v", i, obj, back)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package curve
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/math"
+)
+
+// The 5 points Gauss-Legendre quadrature on [-1, 1], exact for polynomials up
+// to degree 9.
+var (
+	gaussNodes   = [5]float32{-0.9061798459386640, -0.5384693101056831, 0, 0.5384693101056831, 0.9061798459386640}
+	gaussWeights = [5]float32{0.2369268850561891, 0.4786286704993665, 0.5688888888888889, 0.4786286704993665, 0.2369268850561891}
+)
+
+// ArcLength maps the parameter of a path to the distance travelled along it,
+// and back. Evaluating a path at the parameters of evenly spaced distances
+// moves along it at constant speed, which evaluating it at evenly spaced
+// parameters doesn't.
+type ArcLength struct {
+	speed func(t float32) float32
+	// The distance at each parameter of the table.
+	params, distances []float32
+}
+
+// NewArcLength returns the arc-length table of p, with samples intervals per
+// segment. The distances are integrated with Gauss-Legendre quadrature on
+// each interval, a few intervals per segment are enough unless the curve has
+// sharp turns.
+func NewArcLength(p *Path, samples int) *ArcLength {
+	return newArcLength(func(t float32) float32 {
+		d := p.Derivative(t)
+		return d.Len()
+	}, len(p.Segments), p.Knots, samples)
+}
+
+// NewArcLength2D returns the arc-length table of p, see NewArcLength.
+func NewArcLength2D(p *Path2D, samples int) *ArcLength {
+	return newArcLength(func(t float32) float32 {
+		d := p.Derivative(t)
+		return d.Len()
+	}, len(p.Segments), p.Knots, samples)
+}
+
+// newArcLength returns the arc-length table of the path of n segments and the
+// given knots with the speed function.
+func newArcLength(speed func(t float32) float32, n int, knots []float32, samples int) *ArcLength {
+	if samples < 1 {
+		samples = 1
+	}
+	a := &ArcLength{
+		speed:     speed,
+		params:    make([]float32, 0, n*samples+1),
+		distances: make([]float32, 0, n*samples+1),
+	}
+	a.params = append(a.params, param(knots, 0, 0))
+	a.distances = append(a.distances, 0)
+	for i := 0; i < n; i++ {
+		// The intervals end at the segments ends, where the speed can
+		// change abruptly.
+		for j := 1; j <= samples; j++ {
+			t0, t1 := a.params[len(a.params)-1], param(knots, i, float32(j)/float32(samples))
+			a.params = append(a.params, t1)
+			a.distances = append(a.distances, a.distances[len(a.distances)-1]+a.integrate(t0, t1))
+		}
+	}
+	return a
+}
+
+// integrate returns the distance travelled from t0 to t1.
+func (a *ArcLength) integrate(t0, t1 float32) float32 {
+	h, m := (t1-t0)/2, (t1+t0)/2
+	var s float32
+	for i, x := range gaussNodes {
+		s += gaussWeights[i] * a.speed(m+h*x)
+	}
+	return s * h
+}
+
+// Length returns the length of the path.
+func (a *ArcLength) Length() float32 {
+	return a.distances[len(a.distances)-1]
+}
+
+// Distance returns the distance travelled along the path from its start to
+// t.
+func (a *ArcLength) Distance(t float32) float32 {
+	last := len(a.params) - 1
+	t = math.Clamp(t, a.params[0], a.params[last])
+	i := sort.Search(last, func(j int) bool { return a.params[j+1] > t })
+	if i == last {
+		return a.distances[last]
+	}
+	return a.distances[i] + a.integrate(a.params[i], t)
+}
+
+// Param returns the parameter of the path at the distance s from its start,
+// s is clamped to the length of the path. It uses Newton's method, falling
+// back to bisection, within the interval of the table containing s.
+func (a *ArcLength) Param(s float32) float32 {
+	last := len(a.params) - 1
+	if s <= 0 {
+		return a.params[0]
+	}
+	if s >= a.distances[last] {
+		return a.params[last]
+	}
+	i := sort.Search(last, func(j int) bool { return a.distances[j+1] > s })
+	lo, hi := a.params[i], a.params[i+1]
+	s0, s1 := a.distances[i], a.distances[i+1]
+	t := lo + (hi-lo)*(s-s0)/(s1-s0)
+	tolerance := 1e-6 * math.Max(a.distances[last], 1)
+	for iter := 0; iter < 16; iter++ {
+		f := s0 + a.integrate(a.params[i], t) - s
+		if math.Abs(f) <= tolerance {
+			break
+		}
+		if f > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		next := (lo + hi) / 2
+		if d := a.speed(t); d > 0 {
+			if n := t - f/d; n > lo && n < hi {
+				next = n
+			}
+		}
+		t = next
+	}
+	return t
+}
+
+// EvenlySpaced returns the parameters of n+1 points evenly spaced along the
+// path, from its start to its end.
+func (a *ArcLength) EvenlySpaced(n int) []float32 {
+	params := make([]float32, n+1)
+	l := a.Length()
+	for i := range params {
+		params[i] = a.Param(l * float32(i) / float32(n))
+	}
+	return params
+}
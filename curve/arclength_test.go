@@ -0,0 +1,65 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestArcLength(t *testing.T) {
+	t.Parallel()
+	// A straight line with very uneven speed.
+	line := Path{Segments: []Bezier{{{0, 0, 0}, {0.3, 0.4, 0}, {0.6, 0.8, 0}, {3, 4, 0}}}}
+	a := NewArcLength(&line, 4)
+	if l := a.Length(); math.Abs(l-5) > 1e-4 {
+		t.Errorf("line Length() = %f, want 5", l)
+	}
+	for _, s := range []float32{0, 0.5, 1, 2.5, 4.9, 5} {
+		p := line.Point(a.Param(s))
+		want := glm.Vec3{0.6 * s, 0.8 * s, 0}
+		if !vecNear(&p, &want, 1e-4) {
+			t.Errorf("line Point(Param(%f)) = %v, want %v", s, p, want)
+		}
+	}
+
+	// A circle of radius 2 with 4 Bezier quarters.
+	const k = 0.5522847
+	var circle Path2D
+	for i := 0; i < 4; i++ {
+		b := Bezier2D{{2, 0}, {2, 2 * k}, {2 * k, 2}, {0, 2}}
+		for j := range b {
+			for r := 0; r < i; r++ {
+				b[j] = b[j].Perp()
+			}
+		}
+		circle.Segments = append(circle.Segments, b)
+	}
+	a = NewArcLength2D(&circle, 2)
+	if l := a.Length(); math.Abs(l-4*math.Pi) > 2e-3 {
+		t.Errorf("circle Length() = %f, want %f", l, 4*math.Pi)
+	}
+	for _, s := range []float32{0.1, 1, 3, 6.28, 10} {
+		tt := a.Param(s)
+		if d := a.Distance(tt); math.Abs(d-s) > 1e-4 {
+			t.Errorf("circle Distance(Param(%f)) = %f", s, d)
+		}
+	}
+
+	// Evenly spaced points on a spline have the same spacing.
+	p := CatmullRom(testPoints, Centripetal, false)
+	a = NewArcLength(&p, 8)
+	params := a.EvenlySpaced(200)
+	step := a.Length() / 200
+	for i := 1; i < len(params); i++ {
+		if params[i] <= params[i-1] {
+			t.Errorf("params[%d] = %f after %f", i, params[i], params[i-1])
+		}
+		if d := a.Distance(params[i]) - a.Distance(params[i-1]); math.Abs(d-step) > 1e-3*step {
+			t.Errorf("spacing %d = %f, want %f", i, d, step)
+		}
+		p0, p1 := p.Point(params[i-1]), p.Point(params[i])
+		if d := p1.Sub(&p0); d.Len() > step*1.001 {
+			t.Errorf("chord %d = %f, longer than %f", i, d.Len(), step)
+		}
+	}
+}
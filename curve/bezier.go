@@ -0,0 +1,271 @@
+// Package curve implements parametric curves over glm.Vec3 and glm.Vec2:
+// cubic Bezier segments, and Hermite, Catmull-Rom and B-splines built from
+// them.
+//
+// Every spline is converted to a Path of cubic Bezier segments, so they all
+// share the same evaluation, bounds and closest point queries. The 2D types
+// have the same methods as the 3D ones, with bounds in geo2d and a signed
+// curvature.
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+)
+
+// closestSamples is the number of intervals sampled on a segment to find the
+// start of the closest point search.
+const closestSamples = 16
+
+// Bezier is a cubic Bezier curve, going from its first control point at t = 0
+// to its last at t = 1.
+type Bezier [4]glm.Vec3
+
+// Point returns the position on b at t.
+func (b *Bezier) Point(t float32) glm.Vec3 {
+	s := 1 - t
+	w0, w1, w2, w3 := s*s*s, 3*s*s*t, 3*s*t*t, t*t*t
+	var p glm.Vec3
+	for i := range p {
+		p[i] = w0*b[0][i] + w1*b[1][i] + w2*b[2][i] + w3*b[3][i]
+	}
+	return p
+}
+
+// Derivative returns the derivative of b at t, its length is the speed of
+// the curve.
+func (b *Bezier) Derivative(t float32) glm.Vec3 {
+	s := 1 - t
+	w0, w1, w2 := 3*s*s, 6*s*t, 3*t*t
+	var d glm.Vec3
+	for i := range d {
+		d[i] = w0*(b[1][i]-b[0][i]) + w1*(b[2][i]-b[1][i]) + w2*(b[3][i]-b[2][i])
+	}
+	return d
+}
+
+// SecondDerivative returns the second derivative of b at t.
+func (b *Bezier) SecondDerivative(t float32) glm.Vec3 {
+	s := 1 - t
+	var d glm.Vec3
+	for i := range d {
+		d[i] = 6*s*(b[2][i]-2*b[1][i]+b[0][i]) + 6*t*(b[3][i]-2*b[2][i]+b[1][i])
+	}
+	return d
+}
+
+// Tangent returns the unit tangent of b at t, or 0 where the curve stops.
+func (b *Bezier) Tangent(t float32) glm.Vec3 {
+	d := b.Derivative(t)
+	if l := d.Len(); l > 0 {
+		d.MulWith(1 / l)
+	}
+	return d
+}
+
+// Curvature returns the curvature of b at t, the inverse of the radius of the
+// osculating circle, or 0 where the curve stops.
+func (b *Bezier) Curvature(t float32) float32 {
+	d1, d2 := b.Derivative(t), b.SecondDerivative(t)
+	l := d1.Len()
+	if l == 0 {
+		return 0
+	}
+	c := d1.Cross(&d2)
+	return c.Len() / (l * l * l)
+}
+
+// Split returns the two halves of b on each side of t, with de Casteljau's
+// algorithm. Together they trace exactly the same curve.
+func (b *Bezier) Split(t float32) (Bezier, Bezier) {
+	lerp := func(p, q *glm.Vec3) glm.Vec3 {
+		r := p.Mul(1 - t)
+		r.AddScaledVec(t, q)
+		return r
+	}
+	p01, p12, p23 := lerp(&b[0], &b[1]), lerp(&b[1], &b[2]), lerp(&b[2], &b[3])
+	p012, p123 := lerp(&p01, &p12), lerp(&p12, &p23)
+	p := lerp(&p012, &p123)
+	return Bezier{b[0], p01, p012, p}, Bezier{p, p123, p23, b[3]}
+}
+
+// Bounds returns the smallest AABB containing b, which is usually much
+// smaller than the bounds of its control points.
+func (b *Bezier) Bounds() geo.AABB {
+	min, max := b.extents()
+	c, h := min.Add(&max), max.Sub(&min)
+	return geo.AABB{Center: c.Mul(0.5), HalfExtend: h.Mul(0.5)}
+}
+
+// extents returns the minimum and maximum coordinates of b, reached at its
+// ends or where a component of the derivative is 0.
+func (b *Bezier) extents() (min, max glm.Vec3) {
+	for i := 0; i < 3; i++ {
+		min[i], max[i] = math.Min(b[0][i], b[3][i]), math.Max(b[0][i], b[3][i])
+		// The derivative divided by 3 is a t² + bt + c.
+		p0, p1, p2, p3 := b[0][i], b[1][i], b[2][i], b[3][i]
+		r0, r1, n := quadraticRoots(-p0+3*p1-3*p2+p3, 2*(p0-2*p1+p2), p1-p0)
+		roots := [2]float32{r0, r1}
+		for _, t := range roots[:n] {
+			if t > 0 && t < 1 {
+				s := 1 - t
+				v := s*s*s*p0 + 3*s*s*t*p1 + 3*s*t*t*p2 + t*t*t*p3
+				min[i], max[i] = math.Min(min[i], v), math.Max(max[i], v)
+			}
+		}
+	}
+	return
+}
+
+// quadraticRoots returns the n real roots of a x² + b x + c.
+func quadraticRoots(a, b, c float32) (r0, r1 float32, n int) {
+	if math.Abs(a) < 1e-7*(math.Abs(b)+math.Abs(c)) || a == 0 {
+		if b == 0 {
+			return 0, 0, 0
+		}
+		return -c / b, 0, 1
+	}
+	d := b*b - 4*a*c
+	if d < 0 {
+		return 0, 0, 0
+	}
+	// Avoid the cancellation of -b + sqrt(d).
+	q := -0.5 * (b + math.Copysign(math.Sqrt(d), b))
+	if q == 0 {
+		return 0, 0, 1
+	}
+	return q / a, c / q, 2
+}
+
+// ClosestPoint returns the point q on b closest to p and its parameter t.
+func (b *Bezier) ClosestPoint(p *glm.Vec3) (t float32, q glm.Vec3) {
+	// Sample the curve then refine the ends and every sample closer than its
+	// neighbours with Newton's method on the derivative of the squared
+	// distance, the best sample may be near a worse local minimum.
+	var dist [closestSamples + 1]float32
+	for i := range dist {
+		pu := b.Point(float32(i) / closestSamples)
+		d := pu.Sub(p)
+		dist[i] = d.Len2()
+	}
+	var best float32 = math.MaxFloat32
+	for i := range dist {
+		if i > 0 && i < closestSamples && (dist[i] > dist[i-1] || dist[i] > dist[i+1]) {
+			continue
+		}
+		u, pu, d := b.refine(p, float32(i)/closestSamples)
+		if d < best {
+			t, q, best = u, pu, d
+		}
+	}
+	return
+}
+
+// refine returns the parameter, position and squared distance to p of the
+// local minimum of the distance near t.
+func (b *Bezier) refine(p *glm.Vec3, t float32) (float32, glm.Vec3, float32) {
+	q := b.Point(t)
+	d := q.Sub(p)
+	best := d.Len2()
+	for i := 0; i < 8; i++ {
+		d1, d2 := b.Derivative(t), b.SecondDerivative(t)
+		qp := q.Sub(p)
+		df := d1.Len2() + qp.Dot(&d2)
+		if df <= 0 {
+			break
+		}
+		u := math.Clamp(t-qp.Dot(&d1)/df, 0, 1)
+		pu := b.Point(u)
+		d := pu.Sub(p)
+		if d.Len2() >= best {
+			break
+		}
+		t, q, best = u, pu, d.Len2()
+	}
+	return t, q, best
+}
+
+// Bezier2D is a cubic Bezier curve in 2D, going from its first control point
+// at t = 0 to its last at t = 1.
+type Bezier2D [4]glm.Vec2
+
+// bezier returns b in the z = 0 plane.
+func (b *Bezier2D) bezier() Bezier {
+	return Bezier{b[0].Vec3(0), b[1].Vec3(0), b[2].Vec3(0), b[3].Vec3(0)}
+}
+
+// Point returns the position on b at t.
+func (b *Bezier2D) Point(t float32) glm.Vec2 {
+	b3 := b.bezier()
+	p := b3.Point(t)
+	return p.Vec2()
+}
+
+// Derivative returns the derivative of b at t, its length is the speed of
+// the curve.
+func (b *Bezier2D) Derivative(t float32) glm.Vec2 {
+	b3 := b.bezier()
+	d := b3.Derivative(t)
+	return d.Vec2()
+}
+
+// SecondDerivative returns the second derivative of b at t.
+func (b *Bezier2D) SecondDerivative(t float32) glm.Vec2 {
+	b3 := b.bezier()
+	d := b3.SecondDerivative(t)
+	return d.Vec2()
+}
+
+// Tangent returns the unit tangent of b at t, or 0 where the curve stops.
+func (b *Bezier2D) Tangent(t float32) glm.Vec2 {
+	b3 := b.bezier()
+	d := b3.Tangent(t)
+	return d.Vec2()
+}
+
+// Curvature returns the signed curvature of b at t, positive where the curve
+// turns left, or 0 where the curve stops.
+func (b *Bezier2D) Curvature(t float32) float32 {
+	d1, d2 := b.Derivative(t), b.SecondDerivative(t)
+	l := d1.Len()
+	if l == 0 {
+		return 0
+	}
+	return d1.Cross(&d2) / (l * l * l)
+}
+
+// Split returns the two halves of b on each side of t, with de Casteljau's
+// algorithm. Together they trace exactly the same curve.
+func (b *Bezier2D) Split(t float32) (Bezier2D, Bezier2D) {
+	b3 := b.bezier()
+	l, r := b3.Split(t)
+	return bezier2D(&l), bezier2D(&r)
+}
+
+// bezier2D returns b projected on the z = 0 plane.
+func bezier2D(b *Bezier) Bezier2D {
+	return Bezier2D{b[0].Vec2(), b[1].Vec2(), b[2].Vec2(), b[3].Vec2()}
+}
+
+// Bounds returns the smallest AABB containing b, which is usually much
+// smaller than the bounds of its control points.
+func (b *Bezier2D) Bounds() geo2d.AABB {
+	b3 := b.bezier()
+	min, max := b3.extents()
+	return bounds2D(&min, &max)
+}
+
+// bounds2D returns the 2D AABB going from min to max.
+func bounds2D(min, max *glm.Vec3) geo2d.AABB {
+	c, h := min.Add(max), max.Sub(min)
+	return geo2d.AABB{Center: glm.Vec2{c[0] * 0.5, c[1] * 0.5}, HalfExtend: glm.Vec2{h[0] * 0.5, h[1] * 0.5}}
+}
+
+// ClosestPoint returns the point q on b closest to p and its parameter t.
+func (b *Bezier2D) ClosestPoint(p *glm.Vec2) (t float32, q glm.Vec2) {
+	b3, p3 := b.bezier(), p.Vec3(0)
+	t, q3 := b3.ClosestPoint(&p3)
+	return t, q3.Vec2()
+}
@@ -0,0 +1,152 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func vecNear(a, b *glm.Vec3, tol float32) bool {
+	return math.Abs(a[0]-b[0]) <= tol && math.Abs(a[1]-b[1]) <= tol && math.Abs(a[2]-b[2]) <= tol
+}
+
+func vec2Near(a, b *glm.Vec2, tol float32) bool {
+	return math.Abs(a[0]-b[0]) <= tol && math.Abs(a[1]-b[1]) <= tol
+}
+
+var testBeziers = []Bezier{
+	{{0, 0, 0}, {1, 2, 0}, {3, 2, 1}, {4, 0, -1}},
+	{{0, 0, 0}, {4, 3, 2}, {-1, 3, 0}, {3, 0, 1}},
+	{{1, 1, 1}, {1, 1, 1}, {2, 2, 2}, {2, 2, 2}},
+}
+
+func TestBezier_Derivatives(t *testing.T) {
+	t.Parallel()
+	const h = 1e-2
+	for i, b := range testBeziers {
+		if p := b.Point(0); p != b[0] {
+			t.Errorf("[%d] Point(0) = %v, want %v", i, p, b[0])
+		}
+		if p := b.Point(1); !vecNear(&p, &b[3], 1e-6) {
+			t.Errorf("[%d] Point(1) = %v, want %v", i, p, b[3])
+		}
+		for _, u := range []float32{0.1, 0.3, 0.5, 0.9} {
+			p0, p1 := b.Point(u-h), b.Point(u+h)
+			fd := p1.Sub(&p0)
+			fd.MulWith(1 / (2 * h))
+			if d := b.Derivative(u); !vecNear(&d, &fd, 1e-2) {
+				t.Errorf("[%d] Derivative(%f) = %v, want %v", i, u, d, fd)
+			}
+			d0, d1 := b.Derivative(u-h), b.Derivative(u+h)
+			fd = d1.Sub(&d0)
+			fd.MulWith(1 / (2 * h))
+			if d := b.SecondDerivative(u); !vecNear(&d, &fd, 1e-2) {
+				t.Errorf("[%d] SecondDerivative(%f) = %v, want %v", i, u, d, fd)
+			}
+		}
+	}
+}
+
+func TestBezier_Curvature(t *testing.T) {
+	t.Parallel()
+	// The usual approximation of a quarter of a circle of radius 2.
+	const k = 0.5522847
+	arc := Bezier2D{{2, 0}, {2, 2 * k}, {2 * k, 2}, {0, 2}}
+	for _, u := range []float32{0, 0.25, 0.5, 0.75, 1} {
+		if c := arc.Curvature(u); math.Abs(c-0.5) > 0.02 {
+			t.Errorf("Curvature(%f) = %f, want 0.5", u, c)
+		}
+		arc3 := arc.bezier()
+		if c := arc3.Curvature(u); math.Abs(c-0.5) > 0.02 {
+			t.Errorf("3D Curvature(%f) = %f, want 0.5", u, c)
+		}
+	}
+	// Clockwise, the 2D curvature is negative.
+	cw := Bezier2D{arc[3], arc[2], arc[1], arc[0]}
+	if c := cw.Curvature(0.5); math.Abs(c+0.5) > 0.01 {
+		t.Errorf("clockwise Curvature(0.5) = %f, want -0.5", c)
+	}
+	line := Bezier{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}, {3, 3, 3}}
+	if c := line.Curvature(0.3); c != 0 {
+		t.Errorf("line Curvature(0.3) = %f, want 0", c)
+	}
+	if tan := line.Tangent(0.3); math.Abs(tan.Len()-1) > 1e-6 {
+		t.Errorf("line Tangent(0.3) = %v, want unit", tan)
+	}
+}
+
+func TestBezier_Split(t *testing.T) {
+	t.Parallel()
+	for i, b := range testBeziers {
+		const s = 0.3
+		l, r := b.Split(s)
+		for _, u := range []float32{0, 0.2, 0.5, 1} {
+			want, got := b.Point(u*s), l.Point(u)
+			if !vecNear(&got, &want, 1e-5) {
+				t.Errorf("[%d] left Point(%f) = %v, want %v", i, u, got, want)
+			}
+			want, got = b.Point(s+u*(1-s)), r.Point(u)
+			if !vecNear(&got, &want, 1e-5) {
+				t.Errorf("[%d] right Point(%f) = %v, want %v", i, u, got, want)
+			}
+		}
+	}
+}
+
+func TestBezier_Bounds(t *testing.T) {
+	t.Parallel()
+	for i, b := range testBeziers {
+		bounds := b.Bounds()
+		min, max := bounds.Center.Sub(&bounds.HalfExtend), bounds.Center.Add(&bounds.HalfExtend)
+		smin, smax := b[0], b[0]
+		for j := 0; j <= 1000; j++ {
+			p := b.Point(float32(j) / 1000)
+			for k := range p {
+				if p[k] < min[k]-1e-5 || p[k] > max[k]+1e-5 {
+					t.Errorf("[%d] %v outside of bounds %v %v", i, p, min, max)
+				}
+				smin[k], smax[k] = math.Min(smin[k], p[k]), math.Max(smax[k], p[k])
+			}
+		}
+		if !vecNear(&smin, &min, 1e-4) || !vecNear(&smax, &max, 1e-4) {
+			t.Errorf("[%d] bounds %v %v, want %v %v", i, min, max, smin, smax)
+		}
+	}
+	b2 := Bezier2D{{0, 0}, {1, 2}, {3, 2}, {4, 0}}
+	if bounds := b2.Bounds(); !vec2Near(&bounds.Center, &glm.Vec2{2, 0.75}, 1e-5) || !vec2Near(&bounds.HalfExtend, &glm.Vec2{2, 0.75}, 1e-5) {
+		t.Errorf("2D Bounds() = %v", bounds)
+	}
+}
+
+func TestBezier_ClosestPoint(t *testing.T) {
+	t.Parallel()
+	points := []glm.Vec3{{2, 3, 0}, {0, -1, 0}, {5, 0, 0}, {1.5, 1, 0.5}, {2, 2, 2}}
+	tests := []struct {
+		b      Bezier
+		points []glm.Vec3
+	}{
+		{b: testBeziers[0], points: points},
+		{b: testBeziers[1], points: points},
+		// A hairpin, the points are between its 2 lobes and the closest
+		// sample is on the wrong one.
+		{b: Bezier{{0, 0, 0}, {20, 0, 0}, {20, 2, 0}, {0, 2, 0}}, points: []glm.Vec3{{14.5, 0.6, 0}, {14.5, 1.3, 0}, {14.5, 1.4, 0}}},
+	}
+	for i, test := range tests {
+		b := test.b
+		for _, p := range test.points {
+			var want float32 = math.MaxFloat32
+			for j := 0; j <= 10000; j++ {
+				q := b.Point(float32(j) / 10000)
+				d := q.Sub(&p)
+				want = math.Min(want, d.Len2())
+			}
+			u, q := b.ClosestPoint(&p)
+			if pu := b.Point(u); !vecNear(&pu, &q, 1e-5) {
+				t.Errorf("[%d] ClosestPoint(%v) = %f, %v, Point(%f) = %v", i, p, u, q, u, pu)
+			}
+			if d := q.Sub(&p); d.Len2() > want+1e-4 {
+				t.Errorf("[%d] ClosestPoint(%v) distance² = %f, want %f", i, p, d.Len2(), want)
+			}
+		}
+	}
+}
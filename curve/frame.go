@@ -0,0 +1,109 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Frame is an orthonormal frame moving along a curve, Tangent × Normal =
+// Binormal.
+type Frame struct {
+	Tangent, Normal, Binormal glm.Vec3
+}
+
+// Mat3 returns the rotation matrix of f, mapping X to the tangent, Y to the
+// normal and Z to the binormal.
+func (f *Frame) Mat3() glm.Mat3 {
+	return glm.Mat3FromCols(&f.Tangent, &f.Normal, &f.Binormal)
+}
+
+// Quat returns the rotation of f, mapping X to the tangent, Y to the normal
+// and Z to the binormal.
+func (f *Frame) Quat() glm.Quat {
+	m := f.Mat3()
+	return glm.Mat3ToQuat(&m)
+}
+
+// perpendicular returns a unit vector perpendicular to the unit vector v.
+func perpendicular(v *glm.Vec3) glm.Vec3 {
+	axis := glm.Vec3{1, 0, 0}
+	if math.Abs(v[0]) > 0.5 {
+		axis = glm.Vec3{0, 1, 0}
+	}
+	p := v.Cross(&axis)
+	p.Normalize()
+	return p
+}
+
+// FrenetFrame returns the Frenet frame of p at t, whose normal points to the
+// center of curvature. ok is false where the curvature is 0, the normal is
+// then an arbitrary one. The Frenet frame flips at inflection points and spins
+// around the tangent along twisting curves, RotationMinimizingFrames doesn't.
+func (p *Path) FrenetFrame(t float32) (f Frame, ok bool) {
+	d1, d2 := p.Derivative(t), p.SecondDerivative(t)
+	f.Tangent = d1.Normalized()
+	f.Binormal = d1.Cross(&d2)
+	if l := f.Binormal.Len(); l > 1e-6*d1.Len2() {
+		f.Binormal.MulWith(1 / l)
+		ok = true
+	} else {
+		f.Binormal = perpendicular(&f.Tangent)
+	}
+	f.Normal = f.Binormal.Cross(&f.Tangent)
+	return
+}
+
+// RotationMinimizingFrames returns the frames of p at the increasing
+// parameters params, which twist as little as possible around the tangent.
+// The first normal is normal made perpendicular to the tangent, or an
+// arbitrary one if normal is nil or parallel to it. The parameters must be
+// close enough for the path to be nearly straight between them, the evenly
+// spaced parameters of ArcLength work well.
+//
+// It uses the double reflection method of Wang et al. "Computation of
+// Rotation Minimizing Frames".
+func (p *Path) RotationMinimizingFrames(params []float32, normal *glm.Vec3) []Frame {
+	frames := make([]Frame, len(params))
+	if len(params) == 0 {
+		return frames
+	}
+	first := &frames[0]
+	first.Tangent = p.Tangent(params[0])
+	if normal != nil {
+		first.Normal = *normal
+		first.Normal.AddScaledVec(-normal.Dot(&first.Tangent), &first.Tangent)
+	}
+	if l := first.Normal.Len(); l > 1e-6 {
+		first.Normal.MulWith(1 / l)
+	} else {
+		first.Normal = perpendicular(&first.Tangent)
+	}
+	first.Binormal = first.Tangent.Cross(&first.Normal)
+
+	x := p.Point(params[0])
+	for i := 1; i < len(params); i++ {
+		prev, f := &frames[i-1], &frames[i]
+		next := p.Point(params[i])
+		f.Tangent = p.Tangent(params[i])
+
+		// Reflect the previous frame in the plane bisecting the two
+		// points, then in the one bisecting the reflected tangent and the
+		// new one.
+		r, tan := prev.Normal, prev.Tangent
+		if v1 := next.Sub(&x); v1.Len2() > 0 {
+			c1 := v1.Len2()
+			r.AddScaledVec(-2*v1.Dot(&r)/c1, &v1)
+			tan.AddScaledVec(-2*v1.Dot(&tan)/c1, &v1)
+		}
+		if v2 := f.Tangent.Sub(&tan); v2.Len2() > 0 {
+			r.AddScaledVec(-2*v2.Dot(&r)/v2.Len2(), &v2)
+		}
+		// Remove the rounding errors.
+		r.AddScaledVec(-r.Dot(&f.Tangent), &f.Tangent)
+		r.Normalize()
+		f.Normal = r
+		f.Binormal = f.Tangent.Cross(&r)
+		x = next
+	}
+	return frames
+}
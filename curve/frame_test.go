@@ -0,0 +1,97 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// checkFrame checks that f is orthonormal and right-handed.
+func checkFrame(t *testing.T, name string, f *Frame) {
+	for _, v := range []*glm.Vec3{&f.Tangent, &f.Normal, &f.Binormal} {
+		if math.Abs(v.Len()-1) > 1e-4 {
+			t.Errorf("%s: %v isn't a unit vector", name, *v)
+		}
+	}
+	if b := f.Tangent.Cross(&f.Normal); !vecNear(&b, &f.Binormal, 1e-4) {
+		t.Errorf("%s: Tangent × Normal = %v, want Binormal %v", name, b, f.Binormal)
+	}
+	q := f.Quat()
+	m := f.Mat3()
+	for i, axis := range []glm.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}} {
+		want := m.Mul3x1(&axis)
+		if got := q.Rotate(&axis); !vecNear(&got, &want, 1e-4) {
+			t.Errorf("%s: Quat() rotates axis %d to %v, want %v", name, i, got, want)
+		}
+	}
+}
+
+// helix returns a path along a helix of radius 1 around Z.
+func helix() Path {
+	var points []glm.Vec3
+	for i := 0; i < 24; i++ {
+		s, c := math.Sincos(float32(i) * math.Pi / 6)
+		points = append(points, glm.Vec3{c, s, float32(i) * 0.2})
+	}
+	return CatmullRom(points, Centripetal, false)
+}
+
+func TestFrenetFrame(t *testing.T) {
+	t.Parallel()
+	p := helix()
+	for _, u := range []float32{2, 5.5, 10.25, 20} {
+		f, ok := p.FrenetFrame(u)
+		if !ok {
+			t.Errorf("FrenetFrame(%f) not ok", u)
+		}
+		checkFrame(t, "helix", &f)
+		// The normal points to the axis.
+		x := p.Point(u)
+		toAxis := glm.Vec3{-x[0], -x[1], 0}
+		toAxis.Normalize()
+		if d := f.Normal.Dot(&toAxis); d < 0.95 {
+			t.Errorf("FrenetFrame(%f).Normal = %v, want towards %v", u, f.Normal, toAxis)
+		}
+	}
+
+	line := Path{Segments: []Bezier{{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}, {3, 3, 3}}}}
+	f, ok := line.FrenetFrame(0.5)
+	if ok {
+		t.Errorf("line FrenetFrame ok")
+	}
+	checkFrame(t, "line", &f)
+}
+
+func TestRotationMinimizingFrames(t *testing.T) {
+	t.Parallel()
+	p := helix()
+	a := NewArcLength(&p, 4)
+	params := a.EvenlySpaced(500)
+	frames := p.RotationMinimizingFrames(params, &glm.Vec3{0, 0, 1})
+	if up := frames[0].Normal; up[2] < 0.9 {
+		t.Errorf("first Normal = %v, want close to Z", up)
+	}
+	for i := range frames {
+		checkFrame(t, "helix", &frames[i])
+		if tan := p.Tangent(params[i]); !vecNear(&tan, &frames[i].Tangent, 1e-5) {
+			t.Errorf("[%d] Tangent = %v, want %v", i, frames[i].Tangent, tan)
+		}
+		// The normal doesn't turn around the tangent.
+		if i > 0 {
+			d := frames[i].Normal.Sub(&frames[i-1].Normal)
+			if twist := d.Dot(&frames[i-1].Binormal); math.Abs(twist) > 1e-3 {
+				t.Errorf("[%d] twist = %f", i, twist)
+			}
+		}
+	}
+
+	// A planar curve keeps the normal of its plane.
+	flat := CatmullRom([]glm.Vec3{{0, 0, 0}, {1, 2, 0}, {3, -1, 0}, {4, 0, 0}}, Centripetal, false)
+	a = NewArcLength(&flat, 4)
+	params = a.EvenlySpaced(100)
+	for i, f := range flat.RotationMinimizingFrames(params, &glm.Vec3{0, 0, 1}) {
+		if !vecNear(&f.Normal, &glm.Vec3{0, 0, 1}, 1e-4) {
+			t.Errorf("flat [%d] Normal = %v", i, f.Normal)
+		}
+	}
+}
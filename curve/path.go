@@ -0,0 +1,204 @@
+package curve
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+)
+
+// Path is a curve made of cubic Bezier segments. Without Knots, segment i
+// covers t from i to i+1. Otherwise Knots, increasing and one longer than
+// Segments, gives the range [Knots[i], Knots[i+1]] of segment i. t is clamped
+// to the range of the path, which must have at least one segment.
+type Path struct {
+	Segments []Bezier
+	Knots    []float32
+}
+
+// locate returns the segment of a path of n segments at t, the parameter u in
+// that segment and the derivative of u with respect to t.
+func locate(n int, knots []float32, t float32) (i int, u, du float32) {
+	if knots == nil {
+		t = math.Clamp(t, 0, float32(n))
+		i = int(t)
+		if i == n {
+			i--
+		}
+		return i, t - float32(i), 1
+	}
+	t = math.Clamp(t, knots[0], knots[n])
+	i = sort.Search(n, func(j int) bool { return knots[j+1] > t })
+	if i == n {
+		i--
+	}
+	span := knots[i+1] - knots[i]
+	return i, (t - knots[i]) / span, 1 / span
+}
+
+// param returns the parameter of the path at u in segment i.
+func param(knots []float32, i int, u float32) float32 {
+	if knots == nil {
+		return float32(i) + u
+	}
+	return knots[i] + u*(knots[i+1]-knots[i])
+}
+
+// Range returns the range of t covered by p.
+func (p *Path) Range() (min, max float32) {
+	if p.Knots == nil {
+		return 0, float32(len(p.Segments))
+	}
+	return p.Knots[0], p.Knots[len(p.Segments)]
+}
+
+// Point returns the position on p at t.
+func (p *Path) Point(t float32) glm.Vec3 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Point(u)
+}
+
+// Derivative returns the derivative of p at t.
+func (p *Path) Derivative(t float32) glm.Vec3 {
+	i, u, du := locate(len(p.Segments), p.Knots, t)
+	d := p.Segments[i].Derivative(u)
+	return d.Mul(du)
+}
+
+// SecondDerivative returns the second derivative of p at t.
+func (p *Path) SecondDerivative(t float32) glm.Vec3 {
+	i, u, du := locate(len(p.Segments), p.Knots, t)
+	d := p.Segments[i].SecondDerivative(u)
+	return d.Mul(du * du)
+}
+
+// Tangent returns the unit tangent of p at t, or 0 where the curve stops.
+func (p *Path) Tangent(t float32) glm.Vec3 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Tangent(u)
+}
+
+// Curvature returns the curvature of p at t.
+func (p *Path) Curvature(t float32) float32 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Curvature(u)
+}
+
+// Bounds returns the smallest AABB containing p.
+func (p *Path) Bounds() geo.AABB {
+	min, max := p.extents()
+	c, h := min.Add(&max), max.Sub(&min)
+	return geo.AABB{Center: c.Mul(0.5), HalfExtend: h.Mul(0.5)}
+}
+
+// extents returns the minimum and maximum coordinates of p.
+func (p *Path) extents() (min, max glm.Vec3) {
+	min, max = p.Segments[0].extents()
+	for i := 1; i < len(p.Segments); i++ {
+		smin, smax := p.Segments[i].extents()
+		for j := range min {
+			min[j], max[j] = math.Min(min[j], smin[j]), math.Max(max[j], smax[j])
+		}
+	}
+	return
+}
+
+// ClosestPoint returns the point q on p closest to point and its parameter t.
+func (p *Path) ClosestPoint(point *glm.Vec3) (t float32, q glm.Vec3) {
+	var best float32 = math.MaxFloat32
+	for i := range p.Segments {
+		// Skip the segments whose bounds are further than the best point.
+		if i > 0 {
+			b := p.Segments[i].Bounds()
+			if geo.SqDistAABBPoint(&b, point) >= best {
+				continue
+			}
+		}
+		u, qi := p.Segments[i].ClosestPoint(point)
+		if d := qi.Sub(point); d.Len2() < best {
+			t, q, best = param(p.Knots, i, u), qi, d.Len2()
+		}
+	}
+	return
+}
+
+// Path2D is a 2D curve made of cubic Bezier segments, see Path.
+type Path2D struct {
+	Segments []Bezier2D
+	Knots    []float32
+}
+
+// path returns p in the z = 0 plane.
+func (p *Path2D) path() Path {
+	segments := make([]Bezier, len(p.Segments))
+	for i := range p.Segments {
+		segments[i] = p.Segments[i].bezier()
+	}
+	return Path{Segments: segments, Knots: p.Knots}
+}
+
+// path2D returns p projected on the z = 0 plane.
+func path2D(p *Path) Path2D {
+	segments := make([]Bezier2D, len(p.Segments))
+	for i := range p.Segments {
+		segments[i] = bezier2D(&p.Segments[i])
+	}
+	return Path2D{Segments: segments, Knots: p.Knots}
+}
+
+// Range returns the range of t covered by p.
+func (p *Path2D) Range() (min, max float32) {
+	if p.Knots == nil {
+		return 0, float32(len(p.Segments))
+	}
+	return p.Knots[0], p.Knots[len(p.Segments)]
+}
+
+// Point returns the position on p at t.
+func (p *Path2D) Point(t float32) glm.Vec2 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Point(u)
+}
+
+// Derivative returns the derivative of p at t.
+func (p *Path2D) Derivative(t float32) glm.Vec2 {
+	i, u, du := locate(len(p.Segments), p.Knots, t)
+	d := p.Segments[i].Derivative(u)
+	return d.Mul(du)
+}
+
+// SecondDerivative returns the second derivative of p at t.
+func (p *Path2D) SecondDerivative(t float32) glm.Vec2 {
+	i, u, du := locate(len(p.Segments), p.Knots, t)
+	d := p.Segments[i].SecondDerivative(u)
+	return d.Mul(du * du)
+}
+
+// Tangent returns the unit tangent of p at t, or 0 where the curve stops.
+func (p *Path2D) Tangent(t float32) glm.Vec2 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Tangent(u)
+}
+
+// Curvature returns the signed curvature of p at t, positive where the curve
+// turns left.
+func (p *Path2D) Curvature(t float32) float32 {
+	i, u, _ := locate(len(p.Segments), p.Knots, t)
+	return p.Segments[i].Curvature(u)
+}
+
+// Bounds returns the smallest AABB containing p.
+func (p *Path2D) Bounds() geo2d.AABB {
+	p3 := p.path()
+	min, max := p3.extents()
+	return bounds2D(&min, &max)
+}
+
+// ClosestPoint returns the point q on p closest to point and its parameter t.
+func (p *Path2D) ClosestPoint(point *glm.Vec2) (t float32, q glm.Vec2) {
+	p3, point3 := p.path(), point.Vec3(0)
+	t, q3 := p3.ClosestPoint(&point3)
+	return t, q3.Vec2()
+}
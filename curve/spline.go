@@ -0,0 +1,219 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// The alpha of the common Catmull-Rom parameterizations. Centripetal never
+// forms cusps or loops within a segment, chordal follows the points the
+// tightest.
+const (
+	Uniform     = 0
+	Centripetal = 0.5
+	Chordal     = 1
+)
+
+// Hermite returns the Bezier segment going from p0 with derivative m0 to p1
+// with derivative m1.
+func Hermite(p0, m0, p1, m1 *glm.Vec3) Bezier {
+	b1, b2 := *p0, *p1
+	b1.AddScaledVec(1.0/3, m0)
+	b2.AddScaledVec(-1.0/3, m1)
+	return Bezier{*p0, b1, b2, *p1}
+}
+
+// HermitePath returns the path going through the points with the given
+// derivatives, one segment between each pair of consecutive points.
+func HermitePath(points, derivatives []glm.Vec3) Path {
+	segments := make([]Bezier, len(points)-1)
+	for i := range segments {
+		segments[i] = Hermite(&points[i], &derivatives[i], &points[i+1], &derivatives[i+1])
+	}
+	return Path{Segments: segments}
+}
+
+// CatmullRom returns the Catmull-Rom spline going through the points, with
+// one segment between each pair of consecutive points. alpha sets the
+// parameterization, see Uniform, Centripetal and Chordal. A closed spline
+// also joins the last point to the first, an open one extends its ends by
+// mirroring the neighbouring points. It needs at least 2 points.
+func CatmullRom(points []glm.Vec3, alpha float32, closed bool) Path {
+	n := len(points)
+	at := func(i int) glm.Vec3 {
+		switch {
+		case closed:
+			return points[(i+n)%n]
+		case i < 0:
+			return mirror(&points[0], &points[1])
+		case i >= n:
+			return mirror(&points[n-1], &points[n-2])
+		}
+		return points[i]
+	}
+	count := n - 1
+	if closed {
+		count = n
+	}
+	segments := make([]Bezier, count)
+	for i := range segments {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		segments[i] = catmullRom(&p0, &p1, &p2, &p3, alpha)
+	}
+	return Path{Segments: segments}
+}
+
+// mirror returns p mirrored through the point center.
+func mirror(center, p *glm.Vec3) glm.Vec3 {
+	m := center.Mul(2)
+	return m.Sub(p)
+}
+
+// catmullRom returns the Bezier segment between p1 and p2 of the Catmull-Rom
+// spline through p0, p1, p2 and p3 with the given alpha.
+func catmullRom(p0, p1, p2, p3 *glm.Vec3, alpha float32) Bezier {
+	// The knot intervals are the distances between the points to the power
+	// alpha, the tangents are the derivatives of the Barry-Goldman pyramid.
+	span := func(a, b *glm.Vec3) float32 {
+		d := b.Sub(a)
+		return math.Pow(d.Len2(), alpha/2)
+	}
+	d01, d12, d23 := span(p0, p1), span(p1, p2), span(p2, p3)
+	if d12 == 0 {
+		return Bezier{*p1, *p1, *p2, *p2}
+	}
+	if d01 == 0 {
+		d01 = d12
+	}
+	if d23 == 0 {
+		d23 = d12
+	}
+
+	p10, p20, p21 := p1.Sub(p0), p2.Sub(p0), p2.Sub(p1)
+	m1 := p10.Mul(1 / d01)
+	m1.AddScaledVec(-1/(d01+d12), &p20)
+	m1.AddScaledVec(1/d12, &p21)
+
+	p31, p32 := p3.Sub(p1), p3.Sub(p2)
+	m2 := p21.Mul(1 / d12)
+	m2.AddScaledVec(-1/(d12+d23), &p31)
+	m2.AddScaledVec(1/d23, &p32)
+
+	m1.MulWith(d12)
+	m2.MulWith(d12)
+	return Hermite(p1, &m1, p2, &m2)
+}
+
+// BSpline returns the uniform cubic B-spline of the control points. It
+// doesn't go through them, an open spline has len(points)-3 segments and
+// needs at least 4 points, a closed one has len(points) segments.
+func BSpline(points []glm.Vec3, closed bool) Path {
+	n := len(points)
+	count := n - 3
+	if closed {
+		count = n
+	}
+	segments := make([]Bezier, count)
+	for i := range segments {
+		p0, p1, p2, p3 := &points[i], &points[(i+1)%n], &points[(i+2)%n], &points[(i+3)%n]
+		var b Bezier
+		for j := range b[0] {
+			b[0][j] = (p0[j] + 4*p1[j] + p2[j]) / 6
+			b[1][j] = (2*p1[j] + p2[j]) / 3
+			b[2][j] = (p1[j] + 2*p2[j]) / 3
+			b[3][j] = (p1[j] + 4*p2[j] + p3[j]) / 6
+		}
+		segments[i] = b
+	}
+	return Path{Segments: segments}
+}
+
+// NonUniformBSpline returns the cubic B-spline of the control points with the
+// given non-decreasing knot vector, which must have len(points)+4 knots. The
+// path is parameterized by the knots, from knots[3] to knots[len(points)].
+// Repeating the first and last knots 4 times makes it go through the first
+// and last points.
+func NonUniformBSpline(points []glm.Vec3, knots []float32) Path {
+	n := len(points)
+	if n < 4 || len(knots) != n+4 {
+		panic("curve: a cubic B-spline needs at least 4 points and len(points)+4 knots")
+	}
+	var p Path
+	for k := 3; k < n; k++ {
+		a, b := knots[k], knots[k+1]
+		if a >= b {
+			continue
+		}
+		// The Bezier control points of a span are the blossoms of its ends.
+		p.Segments = append(p.Segments, Bezier{
+			blossom(points, knots, k, a, a, a),
+			blossom(points, knots, k, a, a, b),
+			blossom(points, knots, k, a, b, b),
+			blossom(points, knots, k, b, b, b),
+		})
+		if len(p.Knots) == 0 {
+			p.Knots = append(p.Knots, a)
+		}
+		p.Knots = append(p.Knots, b)
+	}
+	return p
+}
+
+// blossom returns the blossom of the cubic B-spline at x0, x1, x2 in the span
+// [knots[k], knots[k+1]), with de Boor's algorithm using a different
+// parameter at each level.
+func blossom(points []glm.Vec3, knots []float32, k int, x0, x1, x2 float32) glm.Vec3 {
+	var d [4]glm.Vec3
+	copy(d[:], points[k-3:k+1])
+	for r, x := range [3]float32{x0, x1, x2} {
+		for j := 3; j > r; j-- {
+			i := k - 3 + j
+			alpha := (x - knots[i]) / (knots[i+3-r] - knots[i])
+			d[j].MulWith(alpha)
+			d[j].AddScaledVec(1-alpha, &d[j-1])
+		}
+	}
+	return d[3]
+}
+
+// lift returns the 2D points in the z = 0 plane.
+func lift(points []glm.Vec2) []glm.Vec3 {
+	lifted := make([]glm.Vec3, len(points))
+	for i := range points {
+		lifted[i] = points[i].Vec3(0)
+	}
+	return lifted
+}
+
+// Hermite2D returns the Bezier segment going from p0 with derivative m0 to p1
+// with derivative m1.
+func Hermite2D(p0, m0, p1, m1 *glm.Vec2) Bezier2D {
+	b1, b2 := *p0, *p1
+	b1.AddScaledVec(1.0/3, m0)
+	b2.AddScaledVec(-1.0/3, m1)
+	return Bezier2D{*p0, b1, b2, *p1}
+}
+
+// HermitePath2D is the 2D version of HermitePath.
+func HermitePath2D(points, derivatives []glm.Vec2) Path2D {
+	p := HermitePath(lift(points), lift(derivatives))
+	return path2D(&p)
+}
+
+// CatmullRom2D is the 2D version of CatmullRom.
+func CatmullRom2D(points []glm.Vec2, alpha float32, closed bool) Path2D {
+	p := CatmullRom(lift(points), alpha, closed)
+	return path2D(&p)
+}
+
+// BSpline2D is the 2D version of BSpline.
+func BSpline2D(points []glm.Vec2, closed bool) Path2D {
+	p := BSpline(lift(points), closed)
+	return path2D(&p)
+}
+
+// NonUniformBSpline2D is the 2D version of NonUniformBSpline.
+func NonUniformBSpline2D(points []glm.Vec2, knots []float32) Path2D {
+	p := NonUniformBSpline(lift(points), knots)
+	return path2D(&p)
+}
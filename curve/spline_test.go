@@ -0,0 +1,145 @@
+package curve
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+var testPoints = []glm.Vec3{{0, 0, 0}, {1, 2, 0}, {1.5, 2, 1}, {4, 0, 0}, {4.1, 0.1, 0}, {6, 3, -1}}
+
+func TestCatmullRom(t *testing.T) {
+	t.Parallel()
+	for _, alpha := range []float32{Uniform, Centripetal, Chordal} {
+		for _, closed := range []bool{false, true} {
+			p := CatmullRom(testPoints, alpha, closed)
+			if _, max := p.Range(); closed && max != float32(len(testPoints)) || !closed && max != float32(len(testPoints)-1) {
+				t.Errorf("alpha %f closed %t: Range() max = %f", alpha, closed, max)
+			}
+			for i := range p.Segments {
+				// The spline goes through the points with a continuous
+				// tangent.
+				want := testPoints[i]
+				if got := p.Point(float32(i)); !vecNear(&got, &want, 1e-5) {
+					t.Errorf("alpha %f closed %t: Point(%d) = %v, want %v", alpha, closed, i, got, want)
+				}
+				if i == 0 {
+					continue
+				}
+				before, after := p.Segments[i-1].Tangent(1), p.Segments[i].Tangent(0)
+				if !vecNear(&before, &after, 1e-4) {
+					t.Errorf("alpha %f closed %t: tangent at %d %v, then %v", alpha, closed, i, before, after)
+				}
+			}
+		}
+	}
+
+	// Equally spaced collinear points give a straight line at constant speed.
+	line := CatmullRom([]glm.Vec3{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {3, 0, 0}}, Centripetal, false)
+	for _, u := range []float32{0.3, 1.5, 2.9} {
+		if p := line.Point(u); !vecNear(&p, &glm.Vec3{u, 0, 0}, 1e-5) {
+			t.Errorf("line Point(%f) = %v", u, p)
+		}
+	}
+
+	square := CatmullRom2D([]glm.Vec2{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}, Centripetal, true)
+	for _, u := range []float32{0, 0.5, 1.7, 3.2} {
+		if c := square.Curvature(u); c <= 0 {
+			t.Errorf("counter-clockwise Curvature(%f) = %f", u, c)
+		}
+		if p := square.Point(u); math.Abs(p.Len()-1) > 0.15 {
+			t.Errorf("circle Point(%f) = %v", u, p)
+		}
+	}
+	if b := square.Bounds(); !vec2Near(&b.HalfExtend, &glm.Vec2{1, 1}, 0.1) {
+		t.Errorf("circle Bounds() = %v", b)
+	}
+}
+
+func TestHermite(t *testing.T) {
+	t.Parallel()
+	points, derivatives := testPoints[:3], []glm.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, -2}}
+	p := HermitePath(points, derivatives)
+	for i := range points {
+		if got := p.Point(float32(i)); !vecNear(&got, &points[i], 1e-5) {
+			t.Errorf("Point(%d) = %v, want %v", i, got, points[i])
+		}
+		if got := p.Derivative(float32(i)); !vecNear(&got, &derivatives[i], 1e-5) {
+			t.Errorf("Derivative(%d) = %v, want %v", i, got, derivatives[i])
+		}
+	}
+}
+
+func TestBSpline(t *testing.T) {
+	t.Parallel()
+	// Uniform knots give the uniform B-spline.
+	knots := make([]float32, len(testPoints)+4)
+	for i := range knots {
+		knots[i] = float32(i)
+	}
+	uniform, nonUniform := BSpline(testPoints, false), NonUniformBSpline(testPoints, knots)
+	if len(uniform.Segments) != len(testPoints)-3 || len(nonUniform.Segments) != len(uniform.Segments) {
+		t.Fatalf("%d and %d segments, want %d", len(uniform.Segments), len(nonUniform.Segments), len(testPoints)-3)
+	}
+	for _, u := range []float32{0, 0.4, 1, 2.5, 3} {
+		want, got := uniform.Point(u), nonUniform.Point(u+3)
+		if !vecNear(&got, &want, 1e-5) {
+			t.Errorf("Point(%f) = %v, want %v", u+3, got, want)
+		}
+		wantD, gotD := uniform.Derivative(u), nonUniform.Derivative(u+3)
+		if !vecNear(&gotD, &wantD, 1e-4) {
+			t.Errorf("Derivative(%f) = %v, want %v", u+3, gotD, wantD)
+		}
+	}
+
+	// Clamped knots with a single span give the Bezier curve.
+	b := Bezier{testPoints[0], testPoints[1], testPoints[2], testPoints[3]}
+	clamped := NonUniformBSpline(b[:], []float32{0, 0, 0, 0, 2, 2, 2, 2})
+	for _, u := range []float32{0, 0.5, 1.2, 2} {
+		want, got := b.Point(u/2), clamped.Point(u)
+		if !vecNear(&got, &want, 1e-5) {
+			t.Errorf("clamped Point(%f) = %v, want %v", u, got, want)
+		}
+	}
+
+	// A repeated interior knot drops a segment and makes the curve go
+	// closer to the control points.
+	clamped = NonUniformBSpline(testPoints, []float32{0, 0, 0, 0, 1, 1, 2, 2, 2, 2})
+	if len(clamped.Segments) != 2 || len(clamped.Knots) != 3 {
+		t.Errorf("%d segments, knots %v", len(clamped.Segments), clamped.Knots)
+	}
+	if p := clamped.Point(2); !vecNear(&p, &testPoints[5], 1e-5) {
+		t.Errorf("clamped Point(2) = %v, want %v", p, testPoints[5])
+	}
+
+	closed := BSpline2D([]glm.Vec2{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}, true)
+	first, last := closed.Point(0), closed.Point(4)
+	if !vec2Near(&first, &last, 1e-5) {
+		t.Errorf("closed spline ends at %v and %v", first, last)
+	}
+}
+
+func TestPath_ClosestPoint(t *testing.T) {
+	t.Parallel()
+	p := CatmullRom(testPoints, Centripetal, false)
+	for _, point := range []glm.Vec3{{3, 3, 0}, {-1, 0, 0}, {4, 0.5, 0}, {7, 3, -1}} {
+		var want float32 = math.MaxFloat32
+		for j := 0; j <= 50000; j++ {
+			q := p.Point(float32(j) / 10000)
+			d := q.Sub(&point)
+			want = math.Min(want, d.Len2())
+		}
+		u, q := p.ClosestPoint(&point)
+		if pu := p.Point(u); !vecNear(&pu, &q, 1e-5) {
+			t.Errorf("ClosestPoint(%v) = %f, %v, Point(%f) = %v", point, u, q, u, pu)
+		}
+		if d := q.Sub(&point); d.Len2() > want+1e-4 {
+			t.Errorf("ClosestPoint(%v) distance² = %f, want %f", point, d.Len2(), want)
+		}
+	}
+
+	p2 := CatmullRom2D([]glm.Vec2{{0, 0}, {1, 1}, {2, 0}}, Uniform, false)
+	if u, q := p2.ClosestPoint(&glm.Vec2{1, 3}); math.Abs(u-1) > 1e-3 || !vec2Near(&q, &glm.Vec2{1, 1}, 1e-4) {
+		t.Errorf("2D ClosestPoint = %f, %v", u, q)
+	}
+}
@@ -0,0 +1,106 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// PolarDecompose splits m into an orthogonal matrix u and a symmetric positive
+// semi-definite matrix p such that m = u * p. u is the rotation closest to m
+// (with a reflection if m has a negative determinant) and p is the stretch,
+// including any shear. ok is false if m is singular.
+//
+// It uses Higham's scaled Newton iteration, u = (γu + (γu)⁻ᵀ) / 2, which
+// converges quadratically.
+func PolarDecompose(m *Mat3) (u, p Mat3, ok bool) {
+	const (
+		maxIterations = 20
+		tolerance     = 1e-6
+	)
+
+	u = *m
+	if d := u.Det(); math.Abs(d) < MinNormal {
+		return Ident3(), Mat3{}, false
+	}
+
+	for i := 0; i < maxIterations; i++ {
+		inv := u.Inverse()
+		inv.Transpose()
+
+		// Scaling the iterate by the ratio of the norms speeds up
+		// convergence when m has very different singular values.
+		g := math.Sqrt(math.Sqrt(mat3FrobeniusNorm2(&inv) / mat3FrobeniusNorm2(&u)))
+
+		var next Mat3
+		next.MulOf(&u, 0.5*g)
+		inv.MulWith(0.5 / g)
+		next.AddWith(&inv)
+
+		diff := next.Sub(&u)
+		u = next
+		if mat3FrobeniusNorm2(&diff) < tolerance*tolerance {
+			break
+		}
+	}
+
+	ut := u.Transposed()
+	p = ut.Mul3(m)
+	// p is symmetric by construction, remove the rounding errors.
+	for _, ij := range [3][2]int{{1, 3}, {2, 6}, {5, 7}} {
+		s := (p[ij[0]] + p[ij[1]]) * 0.5
+		p[ij[0]], p[ij[1]] = s, s
+	}
+	return u, p, true
+}
+
+// DecomposeTRS splits the affine transform m into a translation t, a rotation r
+// and a scale s such that m = ComposeTRS(&t, &r, &s). Reflections are
+// represented as a negative scale on the X axis. If m contains shear it is
+// discarded and r is the rotation closest to m. ok is false if m is singular or
+// projective, t is then still the translation of m, r is the identity and s is
+// {1, 1, 1}.
+func DecomposeTRS(m *Mat4) (t Vec3, r Quat, s Vec3, ok bool) {
+	t = Vec3{m[12], m[13], m[14]}
+	if m[3] != 0 || m[7] != 0 || m[11] != 0 || m[15] != 1 {
+		return t, QuatIdent(), Vec3{1, 1, 1}, false
+	}
+
+	m3 := m.Mat3()
+	u, p, ok := PolarDecompose(&m3)
+	if !ok {
+		return t, QuatIdent(), Vec3{1, 1, 1}, false
+	}
+	s = p.Diag()
+
+	if u.Det() < 0 {
+		// Attribute the reflection to the X axis: u*p = (u*F) * (F*p)
+		// with F = diag(-1, 1, 1).
+		u[0], u[1], u[2] = -u[0], -u[1], -u[2]
+		s[0] = -s[0]
+	}
+	r = Mat3ToQuat(&u)
+	r.Normalize()
+	return t, r, s, true
+}
+
+// ComposeTRS returns the affine transform that scales by s, then rotates by r
+// and then translates by t. This is the inverse of DecomposeTRS.
+func ComposeTRS(t *Vec3, r *Quat, s *Vec3) Mat4 {
+	m := r.Mat4()
+	for i := 0; i < 3; i++ {
+		m[i*4+0] *= s[i]
+		m[i*4+1] *= s[i]
+		m[i*4+2] *= s[i]
+	}
+	m[12], m[13], m[14] = t[0], t[1], t[2]
+	return m
+}
+
+// mat3FrobeniusNorm2 returns the square of the Frobenius norm of m, the sum of
+// the squares of all its elements.
+func mat3FrobeniusNorm2(m *Mat3) float32 {
+	var n float32
+	for _, e := range m {
+		n += e * e
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+package glm
+
+import (
+	"testing"
+)
+
+func TestPolarDecompose(t *testing.T) {
+	t.Parallel()
+	rot := QuatRotate(0.7, &Vec3{0, 0.6, 0.8})
+	r := rot.Mat3()
+	tests := []Mat3{
+		Ident3(),
+		r,
+		{2, 0, 0, 0, 3, 0, 0, 0, 0.5},
+		r.Mul3(&Mat3{2, 0.5, 0, 0.5, 1, 0.3, 0, 0.3, 4}),
+		r.Mul3(&Mat3{-1, 0, 0, 0, 1, 0, 0, 0, 1}),
+		{1, 2, 0, 0, 1, 0, 0.5, 0, 3},
+	}
+
+	for i, m := range tests {
+		u, p, ok := PolarDecompose(&m)
+		if !ok {
+			t.Errorf("[%d] PolarDecompose(%v) failed", i, m)
+			continue
+		}
+
+		ut := u.Transposed()
+		if utu := ut.Mul3(&u); !utu.EqualThreshold(&Mat3{1, 0, 0, 0, 1, 0, 0, 0, 1}, 1e-3) {
+			t.Errorf("[%d] u is not orthogonal: %v", i, u)
+		}
+		if pt := p.Transposed(); pt != p {
+			t.Errorf("[%d] p is not symmetric: %v", i, p)
+		}
+		if up := u.Mul3(&p); !up.EqualThreshold(&m, 1e-3) {
+			t.Errorf("[%d] u*p = %v, want %v", i, up, m)
+		}
+		if d := p.Det(); d < 0 {
+			t.Errorf("[%d] p has a negative determinant %v", i, d)
+		}
+	}
+
+	var singular Mat3
+	if _, _, ok := PolarDecompose(&singular); ok {
+		t.Errorf("PolarDecompose of a singular matrix should fail")
+	}
+}
+
+func TestDecomposeTRS(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		t Vec3
+		r Quat
+		s Vec3
+	}{
+		{Vec3{0, 0, 0}, QuatIdent(), Vec3{1, 1, 1}},
+		{Vec3{1, 2, 3}, QuatRotate(1, &Vec3{1, 0, 0}), Vec3{1, 1, 1}},
+		{Vec3{-4, 0, 5}, QuatRotate(2.5, &Vec3{0, 0.6, 0.8}), Vec3{2, 3, 0.5}},
+		{Vec3{1, 1, 1}, QuatRotate(-0.3, &Vec3{0, 1, 0}), Vec3{-2, 1, 1}},
+		{Vec3{0, 7, 0}, QuatRotate(0.3, &Vec3{0.8, 0.6, 0}), Vec3{-1, -1, -1}},
+	}
+
+	for i, test := range tests {
+		m := ComposeTRS(&test.t, &test.r, &test.s)
+		tr, r, s, ok := DecomposeTRS(&m)
+		if !ok {
+			t.Errorf("[%d] DecomposeTRS failed", i)
+			continue
+		}
+		if !tr.EqualThreshold(&test.t, 1e-4) {
+			t.Errorf("[%d] translation = %v, want %v", i, tr, test.t)
+		}
+		// Reflections are not unique, recompose and compare matrices.
+		if c := ComposeTRS(&tr, &r, &s); !c.EqualThreshold(&m, 1e-3) {
+			t.Errorf("[%d] recomposed\n%vwant\n%v", i, c.String(), m.String())
+		}
+		if test.s[0] > 0 && test.s[1] > 0 && test.s[2] > 0 {
+			if !r.OrientationEqualThreshold(&test.r, 1e-4) || !s.EqualThreshold(&test.s, 1e-3) {
+				t.Errorf("[%d] r, s = %v, %v, want %v, %v", i, r, s, test.r, test.s)
+			}
+		}
+		if s[1] < 0 || s[2] < 0 {
+			t.Errorf("[%d] reflections should be on the X axis, got %v", i, s)
+		}
+	}
+
+	// Failures give the identity rotation and a unit scale.
+	singular := Translate3D(1, 2, 3)
+	singular.Mul4With(&Mat4{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1})
+	for i, m := range []Mat4{Perspective(1, 1, 0.1, 10), singular} {
+		_, r, s, ok := DecomposeTRS(&m)
+		if ok {
+			t.Errorf("[%d] DecomposeTRS of a projective or singular matrix should fail", i)
+		}
+		if r != QuatIdent() || s != (Vec3{1, 1, 1}) {
+			t.Errorf("[%d] DecomposeTRS failed with r = %v, s = %v", i, r, s)
+		}
+	}
+}
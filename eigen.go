@@ -0,0 +1,139 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// SymEigen computes the eigenvalues and eigenvectors of the symmetric matrix
+// m1. The eigenvalues are sorted in decreasing order and the corresponding
+// unit eigenvectors are the columns of vectors, such that
+// m1 = vectors * Diag2(values) * vectors^T.
+//
+// Only the lower triangle of m1 is read, the matrix is assumed symmetric.
+func (m1 *Mat2) SymEigen() (values Vec2, vectors Mat2) {
+	a := *m1
+	a[2] = a[1]
+	jacobiEigen(a[:], vectors[:], 2)
+	values = a.Diag()
+	sortEigen(values[:], vectors[:], 2)
+	return
+}
+
+// SymEigen computes the eigenvalues and eigenvectors of the symmetric matrix
+// m1. The eigenvalues are sorted in decreasing order and the corresponding
+// unit eigenvectors are the columns of vectors, such that
+// m1 = vectors * Diag3(values) * vectors^T.
+//
+// Only the lower triangle of m1 is read, the matrix is assumed symmetric.
+func (m1 *Mat3) SymEigen() (values Vec3, vectors Mat3) {
+	a := *m1
+	a[3], a[6], a[7] = a[1], a[2], a[5]
+	jacobiEigen(a[:], vectors[:], 3)
+	values = a.Diag()
+	sortEigen(values[:], vectors[:], 3)
+	return
+}
+
+// SymEigen computes the eigenvalues and eigenvectors of the symmetric matrix
+// m1. The eigenvalues are sorted in decreasing order and the corresponding
+// unit eigenvectors are the columns of vectors, such that
+// m1 = vectors * Diag4(values) * vectors^T.
+//
+// Only the lower triangle of m1 is read, the matrix is assumed symmetric.
+func (m1 *Mat4) SymEigen() (values Vec4, vectors Mat4) {
+	a := *m1
+	a[4], a[8], a[12] = a[1], a[2], a[3]
+	a[9], a[13], a[14] = a[6], a[7], a[11]
+	jacobiEigen(a[:], vectors[:], 4)
+	values = a.Diag()
+	sortEigen(values[:], vectors[:], 4)
+	return
+}
+
+// jacobiEigen diagonalizes the symmetric column major n by n matrix a using
+// cyclic Jacobi rotations, a = J^T * a * J, and accumulates the rotations in
+// v. On exit the diagonal of a holds the eigenvalues and the columns of v the
+// eigenvectors.
+//
+// See Golub, Van Loan, Matrix Computations, 3rd ed, p428
+func jacobiEigen(a, v []float32, n int) {
+	const maxSweeps = 32
+
+	for i := range v {
+		v[i] = 0
+	}
+	var norm float32
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+		for j := 0; j < n; j++ {
+			norm += a[j*n+i] * a[j*n+i]
+		}
+	}
+	if norm == 0 {
+		return
+	}
+
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var off float32
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a[q*n+p] * a[q*n+p]
+			}
+		}
+		// Stop when the off-diagonal is negligible compared to the whole
+		// matrix, float32 doesn't allow much more.
+		if off <= 1e-14*norm {
+			return
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a[q*n+p]
+				if apq == 0 {
+					continue
+				}
+				tau := (a[q*n+q] - a[p*n+p]) / (2 * apq)
+				var t float32
+				if tau >= 0 {
+					t = 1 / (tau + math.Sqrt(1+tau*tau))
+				} else {
+					t = -1 / (-tau + math.Sqrt(1+tau*tau))
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := t * c
+
+				// a = a * J
+				for k := 0; k < n; k++ {
+					akp, akq := a[p*n+k], a[q*n+k]
+					a[p*n+k] = c*akp - s*akq
+					a[q*n+k] = s*akp + c*akq
+				}
+				// a = J^T * a
+				for k := 0; k < n; k++ {
+					apk, aqk := a[k*n+p], a[k*n+q]
+					a[k*n+p] = c*apk - s*aqk
+					a[k*n+q] = s*apk + c*aqk
+				}
+				// v = v * J
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[p*n+k], v[q*n+k]
+					v[p*n+k] = c*vkp - s*vkq
+					v[q*n+k] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+}
+
+// sortEigen sorts the values in decreasing order, swapping the columns of the
+// column major n by n matrix v accordingly.
+func sortEigen(values, v []float32, n int) {
+	for i := 1; i < n; i++ {
+		for j := i; j > 0 && values[j] > values[j-1]; j-- {
+			values[j], values[j-1] = values[j-1], values[j]
+			for k := 0; k < n; k++ {
+				v[j*n+k], v[(j-1)*n+k] = v[(j-1)*n+k], v[j*n+k]
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package glm
+
+import (
+	"testing"
+)
+
+func TestMat2_SymEigen(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		m      Mat2
+		values Vec2
+	}{
+		{Ident2(), Vec2{1, 1}},
+		{Mat2{2, 0, 0, 5}, Vec2{5, 2}},
+		{Mat2{2, 1, 1, 2}, Vec2{3, 1}},
+	}
+
+	for i, test := range tests {
+		values, vectors := test.m.SymEigen()
+		if !values.EqualThreshold(&test.values, 1e-4) {
+			t.Errorf("[%d] eigenvalues = %v, want %v", i, values, test.values)
+		}
+		d := Diag2(&values)
+		vt := vectors.Transposed()
+		r := vectors.Mul2(&d)
+		r = r.Mul2(&vt)
+		diff := r.Sub(&test.m)
+		d0, d1 := diff.Cols()
+		if d0.Len()+d1.Len() > 1e-4 {
+			t.Errorf("[%d] v*d*vt = %v, want %v", i, r, test.m)
+		}
+	}
+}
+
+func TestMat3_SymEigen(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		m      Mat3
+		values Vec3
+	}{
+		{Ident3(), Vec3{1, 1, 1}},
+		{Mat3{1, 0, 0, 0, 3, 0, 0, 0, 2}, Vec3{3, 2, 1}},
+		{Mat3{2, -1, 0, -1, 2, -1, 0, -1, 2}, Vec3{3.4142135, 2, 0.5857864}},
+		{Mat3{4, 1, 2, 1, -3, 0, 2, 0, 1}, Vec3{5.0992560, 0.0620339, -3.1612892}},
+	}
+
+	for i, test := range tests {
+		values, vectors := test.m.SymEigen()
+		if !values.EqualThreshold(&test.values, 1e-4) {
+			t.Errorf("[%d] eigenvalues = %v, want %v", i, values, test.values)
+		}
+		for c := 0; c < 3; c++ {
+			v := vectors.Col(c)
+			mv := test.m.Mul3x1(&v)
+			lv := v.Mul(values[c])
+			if d := mv.Sub(&lv); d.Len() > 1e-4 {
+				t.Errorf("[%d] m*v%d = %v, want %v", i, c, mv, lv)
+			}
+		}
+	}
+}
+
+func TestMat4_SymEigen(t *testing.T) {
+	t.Parallel()
+	m := Mat4{
+		4, 1, 0, 2,
+		1, 3, 1, 0,
+		0, 1, 2, 1,
+		2, 0, 1, 5,
+	}
+	values, vectors := m.SymEigen()
+	for c := 0; c < 4; c++ {
+		if c > 0 && values[c] > values[c-1] {
+			t.Errorf("eigenvalues are not sorted: %v", values)
+		}
+		v := vectors.Col(c)
+		mv := m.Mul4x1(&v)
+		lv := v.Mul(values[c])
+		if d := mv.Sub(&lv); d.Len() > 1e-4 {
+			t.Errorf("m*v%d = %v, want %v", c, mv, lv)
+		}
+	}
+	if tr := values[0] + values[1] + values[2] + values[3]; !FloatEqualThreshold(tr, m.Trace(), 1e-4) {
+		t.Errorf("sum of eigenvalues %v != trace %v", tr, m.Trace())
+	}
+}
@@ -15,9 +15,9 @@ func TestBarycentric(t *testing.T) {
 			b: glm.Vec3{0, 1, 0},
 			c: glm.Vec3{1, 0, 0},
 			p: glm.Vec3{0, 0.5, 0},
-			u: 0.41666663,
-			v: 0.16666667,
-			w: 0.4166667,
+			u: 0.25,
+			v: 0.5,
+			w: 0.25,
 		},
 		{
 			a: glm.Vec3{-1, 0, 0},
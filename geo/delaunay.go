@@ -0,0 +1,250 @@
+package geo
+
+import (
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// Tetrahedralize returns the Delaunay tetrahedralization of the points, no
+// point is inside the circumsphere of any tetrahedron and together they fill
+// the convex hull of the points. It returns the indices of the vertices of the
+// tetrahedra, 4 per tetrahedron, ordered so that
+// (b - a).Cross(c - a).Dot(d - a) is positive. Duplicate points are not used
+// by any tetrahedron and it's empty if the points are all coplanar.
+//
+// The points are inserted one by one with the Bowyer-Watson algorithm. The
+// faces of the convex hull are closed by tetrahedra with a vertex at infinity,
+// so points outside the current hull are inserted exactly like the others.
+//
+// It doesn't use the hull code of Quickhull. The tetrahedralization is the
+// lower hull of the points lifted onto a paraboloid in 4 dimensions, which
+// that 3 dimensional code can't build, and the lifted points can't be stored
+// exactly in a Vec3. InSphere is the orientation test of the lifted points,
+// evaluated exactly without storing them.
+func Tetrahedralize(points []glm.Vec3) []int {
+	n := len(points)
+	if n < 4 {
+		return nil
+	}
+
+	// The first tetrahedron is made of 4 points that aren't coplanar.
+	first := [4]int{0, -1, -1, -1}
+	for i := 1; i < n && first[3] < 0; i++ {
+		p := &points[i]
+		switch {
+		case first[1] < 0:
+			if *p != points[0] {
+				first[1] = i
+			}
+		case first[2] < 0:
+			if robust.CrossDot(&points[0], &points[first[1]], p, p) > 0 {
+				first[2] = i
+			}
+		default:
+			if robust.Orient3D(&points[0], &points[first[1]], &points[first[2]], p) != 0 {
+				first[3] = i
+			}
+		}
+	}
+	if first[3] < 0 {
+		return nil
+	}
+	if robust.Orient3D(&points[first[0]], &points[first[1]], &points[first[2]], &points[first[3]]) < 0 {
+		first[2], first[3] = first[3], first[2]
+	}
+
+	m := tetMesh{points: points, ghost: n}
+	m.add(first, [4]int{1, 2, 3, 4})
+	for f := 0; f < 4; f++ {
+		// The ghost tetrahedron on the face f, with 2 vertices swapped to
+		// keep the orientation when the ghost is a point beyond the face.
+		v := first
+		v[f] = n
+		j, k := (f+1)%4, (f+2)%4
+		v[j], v[k] = v[k], v[j]
+		// The face opposite the ghost is shared with the first tetrahedron
+		// and the others with the ghost tetrahedra of the other faces.
+		var adj [4]int
+		for l := range adj {
+			if l != f {
+				adj[l] = 1 + vertexIndex(&first, v[l])
+			}
+		}
+		m.add(v, adj)
+	}
+
+	for i := 0; i < n; i++ {
+		m.insert(i)
+	}
+
+	var tets []int
+	for t, v := range m.v {
+		if !m.dead[t] && !m.isGhost(t) {
+			tets = append(tets, v[0], v[1], v[2], v[3])
+		}
+	}
+	return tets
+}
+
+// vertexIndex returns the index of the vertex a in v or -1.
+func vertexIndex(v *[4]int, a int) int {
+	for i := range v {
+		if v[i] == a {
+			return i
+		}
+	}
+	return -1
+}
+
+// tetMesh is the mutable tetrahedralization used by Tetrahedralize. adj[t][i]
+// is the tetrahedron sharing the face of t opposite to v[t][i]. The vertex
+// ghost is the point at infinity closing the convex hull.
+type tetMesh struct {
+	points []glm.Vec3
+	ghost  int
+	v, adj [][4]int
+	dead   []bool
+	mark   []int
+	last   int
+}
+
+// add adds a tetrahedron and returns its index.
+func (m *tetMesh) add(v, adj [4]int) int {
+	m.v = append(m.v, v)
+	m.adj = append(m.adj, adj)
+	m.dead = append(m.dead, false)
+	m.mark = append(m.mark, -1)
+	m.last = len(m.v) - 1
+	return m.last
+}
+
+// isGhost returns true if t has the ghost vertex.
+func (m *tetMesh) isGhost(t int) bool {
+	return vertexIndex(&m.v[t], m.ghost) >= 0
+}
+
+// orient returns the orientation of t with its vertex i replaced by p.
+func (m *tetMesh) orient(t, i int, p *glm.Vec3) float64 {
+	var w [4]*glm.Vec3
+	for k := range w {
+		if k == i {
+			w[k] = p
+		} else {
+			w[k] = &m.points[m.v[t][k]]
+		}
+	}
+	return robust.Orient3D(w[0], w[1], w[2], w[3])
+}
+
+// locate returns the tetrahedron containing p, or a ghost tetrahedron whose
+// face p is beyond if it's outside the convex hull, walking from the last
+// created one.
+func (m *tetMesh) locate(p *glm.Vec3) int {
+	t, r := m.last, 0
+	if g := vertexIndex(&m.v[t], m.ghost); g >= 0 {
+		t = m.adj[t][g]
+	}
+	for steps := 0; steps < 4*len(m.v); steps++ {
+		moved := false
+		for k := 0; k < 4; k++ {
+			// Rotating the first face tested avoids walking in circles.
+			i := (k + r) % 4
+			if m.orient(t, i, p) < 0 {
+				t, moved = m.adj[t][i], true
+				break
+			}
+		}
+		if !moved || m.isGhost(t) {
+			return t
+		}
+		r++
+	}
+	return t
+}
+
+// conflict returns true if p is strictly inside the circumsphere of t. The
+// circumsphere of a ghost tetrahedron is the half space beyond its face, with
+// the circumcircle of the face on the boundary plane.
+func (m *tetMesh) conflict(t int, p *glm.Vec3) bool {
+	v := &m.v[t]
+	if g := vertexIndex(v, m.ghost); g >= 0 {
+		if o := m.orient(t, g, p); o != 0 {
+			return o > 0
+		}
+		// On the plane of the face, the circumsphere of the tetrahedron on
+		// the other side cuts it along the circumcircle of the face.
+		return m.conflict(m.adj[t][g], p)
+	}
+	return robust.InSphere(&m.points[v[0]], &m.points[v[1]], &m.points[v[2]], &m.points[v[3]], p) > 0
+}
+
+// insert adds the point i to the tetrahedralization.
+func (m *tetMesh) insert(i int) {
+	p := &m.points[i]
+	t := m.locate(p)
+	for _, v := range m.v[t] {
+		if v != m.ghost && m.points[v] == *p {
+			return
+		}
+	}
+
+	// The cavity is made of the tetrahedra whose circumsphere contains p.
+	cavity := []int{t}
+	m.mark[t] = i
+	for k := 0; k < len(cavity); k++ {
+		for _, n := range m.adj[cavity[k]] {
+			if m.mark[n] != i && m.conflict(n, p) {
+				m.mark[n] = i
+				cavity = append(cavity, n)
+			}
+		}
+	}
+
+	// Connect p to the faces of the cavity boundary. The new tetrahedra are
+	// glued together along the edges of the boundary.
+	type half struct{ t, face int }
+	edges := make(map[[2]int]half)
+	for _, c := range cavity {
+		for f, n := range m.adj[c] {
+			if m.mark[n] == i {
+				continue
+			}
+			v := m.v[c]
+			v[f] = i
+			nt := m.add(v, [4]int{-1, -1, -1, -1})
+			m.adj[nt][f] = n
+			for k := range m.adj[n] {
+				if m.adj[n][k] == c {
+					m.adj[n][k] = nt
+				}
+			}
+			for k := 0; k < 4; k++ {
+				if k == f {
+					continue
+				}
+				// Face k contains p and the 2 vertices other than v[k].
+				var key [2]int
+				j := 0
+				for l := 0; l < 4; l++ {
+					if l != k && l != f {
+						key[j] = v[l]
+						j++
+					}
+				}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				if h, ok := edges[key]; ok {
+					m.adj[nt][k] = h.t
+					m.adj[h.t][h.face] = nt
+					delete(edges, key)
+				} else {
+					edges[key] = half{nt, k}
+				}
+			}
+		}
+	}
+	for _, c := range cavity {
+		m.dead[c] = true
+	}
+}
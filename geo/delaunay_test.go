@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+	"testing"
+)
+
+func TestTetrahedralize(t *testing.T) {
+	t.Parallel()
+	seed := uint32(1)
+	rnd := func() float32 {
+		seed = seed*1664525 + 1013904223
+		return float32(seed>>8) / (1 << 24)
+	}
+	cloud := func(n int, scale glm.Vec3) []glm.Vec3 {
+		points := make([]glm.Vec3, n)
+		for i := range points {
+			points[i] = glm.Vec3{rnd() * scale[0], rnd() * scale[1], rnd() * scale[2]}
+		}
+		return append(points, points[n/2])
+	}
+	// A 3x3x3 lattice in a scrambled order, full of cospherical points.
+	var lattice []glm.Vec3
+	for i := 0; i < 27; i++ {
+		j := i * 10 % 27
+		lattice = append(lattice, glm.Vec3{float32(j % 3), float32(j / 3 % 3), float32(j / 9)})
+	}
+
+	tests := []struct {
+		name   string
+		points []glm.Vec3
+		volume float32
+	}{
+		{name: "cube", points: cloud(100, glm.Vec3{1, 1, 1})},
+		{name: "cube 2", points: cloud(200, glm.Vec3{1, 1, 1})},
+		{name: "flat", points: cloud(100, glm.Vec3{1, 1, 0.01})},
+		{name: "very flat", points: cloud(100, glm.Vec3{1, 1, 1e-4})},
+		{name: "needle", points: cloud(100, glm.Vec3{1, 0.01, 0.01})},
+		{name: "lattice", points: lattice, volume: 8},
+	}
+	for _, test := range tests {
+		points := test.points
+		tets := Tetrahedralize(points)
+		if len(tets) == 0 {
+			t.Errorf("%s: no tetrahedra", test.name)
+			continue
+		}
+		var volume float32
+		faces := make(map[[3]int]int)
+		for i := 0; i < len(tets); i += 4 {
+			v := tets[i : i+4]
+			a, b, c, d := &points[v[0]], &points[v[1]], &points[v[2]], &points[v[3]]
+			o := robust.Orient3D(a, b, c, d)
+			if o <= 0 {
+				t.Errorf("%s: tetrahedron %v has orientation %g", test.name, v, o)
+			}
+			volume += float32(o / 6)
+			for j := range points {
+				if robust.InSphere(a, b, c, d, &points[j]) > 0 {
+					t.Errorf("%s: point %d is inside the circumsphere of %v", test.name, j, v)
+				}
+			}
+			for f := 0; f < 4; f++ {
+				var key [3]int
+				for k, l := 0, 0; k < 4; k++ {
+					if k != f {
+						key[l] = v[k]
+						l++
+					}
+				}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				if key[1] > key[2] {
+					key[1], key[2] = key[2], key[1]
+				}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				faces[key]++
+			}
+		}
+		if test.volume != 0 && math.Abs(volume-test.volume) > 1e-4 {
+			t.Errorf("%s: volume = %f, want %f", test.name, volume, test.volume)
+		}
+		for f, n := range faces {
+			if n > 2 {
+				t.Errorf("%s: face %v is shared by %d tetrahedra", test.name, f, n)
+			}
+			if n != 1 {
+				continue
+			}
+			// A face used once is on the convex hull, all the points are on
+			// one side of it.
+			a, b, c := &points[f[0]], &points[f[1]], &points[f[2]]
+			var above, below bool
+			for j := range points {
+				o := robust.Orient3D(a, b, c, &points[j])
+				above, below = above || o > 0, below || o < 0
+			}
+			if above && below {
+				t.Errorf("%s: face %v is used once but isn't on the convex hull", test.name, f)
+			}
+		}
+	}
+
+	if tets := Tetrahedralize([]glm.Vec3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}, {2, 3, 0}}); len(tets) != 0 {
+		t.Errorf("Tetrahedralize(coplanar) = %v", tets)
+	}
+}
@@ -1,15 +1,11 @@
 package qhull
 
 import (
-	"fmt"
 	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
 	"github.com/EngoEngine/math"
 )
 
-const (
-	epsilonbase = 0.0001 // arbitrary value that will require testing
-)
-
 type (
 	// Edge is a quickhull utility struct for edges
 	Edge struct {
@@ -20,7 +16,6 @@ type (
 	// Face is a quickhull utility struct for faces
 	Face struct {
 		Edges     [3]*Edge
-		Faces     [3]*Face
 		Vertices  [3]int
 		Conflicts []Conflict
 
@@ -37,9 +32,22 @@ type (
 	}
 )
 
-func (f *Face) canSee(point *glm.Vec3) bool {
-	ap := point.Sub(&f.Point)
-	return ap.Dot(&f.Normal) > 0
+// canSee returns true if point is strictly in front of f, the side its normal
+// points to. The test is exact, nearly coplanar points are never misclassified.
+func (f *Face) canSee(points []glm.Vec3, point *glm.Vec3) bool {
+	// The normal is (v2 - v0).Cross(v1 - v0), opposite to Orient3D.
+	return robust.Orient3D(&points[f.Vertices[0]], &points[f.Vertices[1]], &points[f.Vertices[2]], point) < 0
+}
+
+// AddConflict adds the point i to the conflicts of f if f can see it and
+// returns true if it did.
+func (f *Face) AddConflict(points []glm.Vec3, i int) bool {
+	if !f.canSee(points, &points[i]) {
+		return false
+	}
+	ap := points[i].Sub(&f.Point)
+	f.Conflicts = append(f.Conflicts, Conflict{Distance: ap.Dot(&f.Normal) / f.Normal.Len(), Index: i})
+	return true
 }
 
 // CleanVisited clears the visited field of all the faces of the convex hull.
@@ -49,48 +57,35 @@ func CleanVisited(faces []*Face) {
 	}
 }
 
-// FindHorizon finds the horizon of the conflict
-func FindHorizon(face *Face, point *glm.Vec3) []*Edge {
-	return findHorizon(face, nil, point)
-}
-
-func findHorizon(face *Face, edge *Edge, point *glm.Vec3) []*Edge {
-	fmt.Println("face, edge", fmt.Sprintf("%p", face), fmt.Sprintf("%p", edge), " visited ", face.Visited)
-	if edge == nil {
-		e := face.Edges[0]
-		fmt.Println("nil 1")
-		edges := findHorizon(e.Twin.Face, e.Twin, point)
-		e = e.Next
-		fmt.Println("nil 2")
-		edges = append(edges, findHorizon(e.Twin.Face, e.Twin, point)...)
+// FindHorizon finds the horizon of the conflict point, points are the vertices
+// of the hull. It returns the edges of the faces that can see the point
+// bordering a face that can't and marks the faces that can see it as visited.
+func FindHorizon(face *Face, points []glm.Vec3, point *glm.Vec3) []*Edge {
+	face.Visited = true
+	var edges []*Edge
+	e := face.Edges[0]
+	for n := 0; n < 3; n++ {
+		edges = append(edges, findHorizon(e.Twin, points, point)...)
 		e = e.Next
-		fmt.Println("nil 3")
-		edges = append(edges, findHorizon(e.Twin.Face, e.Twin, point)...)
-		return edges
 	}
+	return edges
+}
+
+// findHorizon returns the horizon beyond edge, an edge of the face entered.
+func findHorizon(edge *Edge, points []glm.Vec3, point *glm.Vec3) []*Edge {
+	face := edge.Face
 	if face.Visited {
-		fmt.Println("visited")
 		return nil
 	}
-
-	if !face.canSee(point) {
-		fmt.Printf("add %p\n", edge.Twin)
+	if !face.canSee(points, point) {
 		return []*Edge{edge.Twin}
 	}
-
-	fmt.Println("setting visited")
 	face.Visited = true
 
 	e := edge.Next
-	fmt.Print("non-nil 1 ")
-	edges := findHorizon(e.Twin.Face, e.Twin, point)
-	fmt.Println(edges)
+	edges := findHorizon(e.Twin, points, point)
 	e = e.Next
-	fmt.Print("non-nil 2 ")
-	edges = append(edges, findHorizon(e.Twin.Face, e.Twin, point)...)
-	fmt.Println(edges)
-
-	return edges
+	return append(edges, findHorizon(e.Twin, points, point)...)
 }
 
 // NextConflict returns the index of the face and conflict of the conflict with
@@ -102,7 +97,7 @@ func NextConflict(faces []*Face) (int, int) {
 
 	for n, face := range faces {
 		for m := range face.Conflicts {
-			if face.Conflicts[m].Distance > maxDist {
+			if iface == -1 || face.Conflicts[m].Distance > maxDist {
 				maxDist = face.Conflicts[m].Distance
 				iface = n
 				iconflict = m
@@ -152,16 +147,6 @@ func FindExtremums(points []glm.Vec3) (extremumIndices [6]int, extremums [6]glm.
 	return
 }
 
-// CalculateEpsilon calculates the epsilon the algorithm should use given the
-// extremums of the point cloud [minx, miny, minz, maxx, maxy, maxz]
-func CalculateEpsilon(extremums [6]glm.Vec3) float32 {
-	var maxima float32
-	for n := 0; n < 3; n++ {
-		maxima += math.Max(math.Abs(extremums[n][n]), math.Abs(extremums[3+n][n]))
-	}
-	return epsilonbase * maxima * 3
-}
-
 // BuildInitialTetrahedron builds the initial tetrahedron from the given 4 indices
 func BuildInitialTetrahedron(a, b, c, d int, points []glm.Vec3) []*Face {
 	ab := points[b].Sub(&points[a])
@@ -178,11 +163,6 @@ func BuildInitialTetrahedron(a, b, c, d int, points []glm.Vec3) []*Face {
 	f2 := &Face{Vertices: [3]int{b, a, d}, Normal: bd.Cross(&ba), Point: points[b]}
 	f3 := &Face{Vertices: [3]int{d, c, b}, Normal: db.Cross(&dc), Point: points[d]}
 
-	f0.Faces = [3]*Face{f2, f3, f1}
-	f1.Faces = [3]*Face{f3, f2, f0}
-	f2.Faces = [3]*Face{f0, f1, f3}
-	f3.Faces = [3]*Face{f1, f0, f2}
-
 	// edges of f0
 	e00 := &Edge{Tail: a, Face: f0}
 	e01 := &Edge{Tail: b, Face: f0}
@@ -240,3 +220,37 @@ func BuildInitialTetrahedron(a, b, c, d int, points []glm.Vec3) []*Face {
 
 	return []*Face{f0, f1, f2, f3}
 }
+
+// BuildCone builds the faces joining the apex to the horizon edges and links
+// them to the faces on the other side of the horizon.
+func BuildCone(apex int, horizon []*Edge, points []glm.Vec3) []*Face {
+	faces := make([]*Face, len(horizon))
+	byTail := make(map[int]*Face, len(horizon))
+	for n, edge := range horizon {
+		a, b := edge.Tail, edge.Next.Tail
+		ab := points[b].Sub(&points[a])
+		ac := points[apex].Sub(&points[a])
+		face := &Face{Vertices: [3]int{a, b, apex}, Normal: ac.Cross(&ab), Point: points[a]}
+
+		e0 := &Edge{Tail: a, Face: face, Twin: edge.Twin}
+		e1 := &Edge{Tail: b, Face: face}
+		e2 := &Edge{Tail: apex, Face: face}
+		edge.Twin.Twin = e0
+		face.Edges = [3]*Edge{e0, e1, e2}
+
+		e0.Next, e0.Prev = e1, e2
+		e1.Next, e1.Prev = e2, e0
+		e2.Next, e2.Prev = e0, e1
+
+		faces[n] = face
+		byTail[a] = face
+	}
+
+	// The horizon is a loop, the edge from the head of each horizon edge to
+	// the apex is the twin of the edge from the apex in the next face.
+	for _, face := range faces {
+		next := byTail[face.Vertices[1]]
+		face.Edges[1].Twin, next.Edges[2].Twin = next.Edges[2], face.Edges[1]
+	}
+	return faces
+}
@@ -21,10 +21,10 @@ func TestPlane_PlaneFromPoints(t *testing.T) {
 
 	for i, test := range tests {
 		got := PlaneFromPoints(&test.points[0], &test.points[1], &test.points[2])
-		if !got.P.ApproxEqualThreshold(&test.plane.P, 1e-4) {
+		if !got.P.EqualThreshold(&test.plane.P, 1e-4) {
 			t.Errorf("[%d] P = %v, want %v", i, got.P, test.plane.P)
 		}
-		if !got.N.ApproxEqualThreshold(&test.plane.N, 1e-4) {
+		if !got.N.EqualThreshold(&test.plane.N, 1e-4) {
 			t.Errorf("[%d] N = %v, want %v", i, got.N, test.plane.N)
 		}
 	}
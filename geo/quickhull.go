@@ -1,192 +1,147 @@
 package geo
 
 import (
-	"fmt"
 	"github.com/engoengine/glm"
 	"github.com/engoengine/glm/geo/internal/qhull"
-	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm/geo/robust"
 )
 
-// Quickhull returns the convex hull of the given points.
-func Quickhull(points []glm.Vec3) {
-	// TODO(hydroflame): finish implementation
-
-	// 1 Find Initial tetrahedron
-	// 1.1 Find Initial Triangle
-	extremumIndices, extremums := qhull.FindExtremums(points)
-
-	// 0.2 calculate the epsilon
-	epsilon := qhull.CalculateEpsilon(extremums)
-
-	// 1.2 Find the 3 most extreme points
-	var triangleIndices [3]int
-	var maxArea float32
-	for i := 0; i < len(extremums); i++ {
-		for j := i + 1; j < len(extremums); j++ {
-			for k := j + 1; k < len(extremums); k++ {
-				l1, l2, l3 := extremums[i].Sub(&extremums[j]), extremums[i].Sub(&extremums[k]), extremums[j].Sub(&extremums[k])
-				area := TriangleAreaFromLengths(l1.Len(), l2.Len(), l3.Len())
-				if area > maxArea {
-					maxArea = area
-					triangleIndices = [3]int{i, j, k}
-				}
-			}
-		}
-	}
-
-	// 1.3 Finish the tetrahedron
-
-	// find the last point
-	l1, l2 := extremums[triangleIndices[1]].Sub(&extremums[triangleIndices[0]]), extremums[triangleIndices[2]].Sub(&extremums[triangleIndices[0]])
-	dir := l1.Cross(&l2)
-
-	imin, imax := ExtremePointsAlongDirection(&dir, points)
-	vmin, vmax := points[imin].Sub(&extremums[triangleIndices[0]]), points[imax].Sub(&extremums[triangleIndices[0]])
-	p1, p2 := math.Abs(vmin.Dot(&dir)), math.Abs(vmax.Dot(&dir))
-
-	var tetraIndex int
-	if p1 > p2 {
-		tetraIndex = imin
-	} else {
-		tetraIndex = imax
-	}
-
-	faces := qhull.BuildInitialTetrahedron(tetraIndex, extremumIndices[triangleIndices[0]], extremumIndices[triangleIndices[1]], extremumIndices[triangleIndices[2]], points)
-
-	for _, face := range faces {
-		for n := range points {
-			if dist := DistToTriangle(&points[n], &points[face.Vertices[0]], &points[face.Vertices[1]], &points[face.Vertices[2]]); dist > epsilon {
-				face.Conflicts = append(face.Conflicts, qhull.Conflict{Distance: dist, Index: n})
-			}
-		}
+// Quickhull returns the convex hull of the given points. It returns the
+// indices of the vertices of the triangles, 3 per triangle, ordered so that
+// (b - a).Cross(c - a) points out of the hull. It's empty if the points are all
+// coplanar.
+//
+// Whether a point is outside a face is decided exactly, points nearly coplanar
+// with a face are never misclassified. Coplanar faces aren't merged and some
+// points on the faces of the hull may be vertices of it.
+func Quickhull(points []glm.Vec3) []int {
+	first, ok := hullTetrahedron(points)
+	if !ok {
+		return nil
 	}
 
-	{ // debug
-		fmt.Println("debugging initial tetrahedron")
+	faces := qhull.BuildInitialTetrahedron(first[0], first[1], first[2], first[3], points)
+	for n := range points {
 		for _, face := range faces {
-			fmt.Println("face:      ", fmt.Sprintf("%p", face), points[face.Vertices[0]], points[face.Vertices[1]], points[face.Vertices[2]])
-			fmt.Println("edges:     ", face.Edges)
-			fmt.Println("conflicts: ", face.Conflicts)
-			fmt.Println()
+			if face.AddConflict(points, n) {
+				break
+			}
 		}
 	}
 
-	var cnt int
 	for iface, iconflict := qhull.NextConflict(faces); iface != -1; iface, iconflict = qhull.NextConflict(faces) {
-		fmt.Println("conflict", points[faces[iface].Conflicts[iconflict].Index])
+		apex := faces[iface].Conflicts[iconflict].Index
 
 		qhull.CleanVisited(faces)
-		for _, face := range faces {
-			fmt.Printf("%p %t\n", face, face.Visited)
-		}
-
-		horizon := qhull.FindHorizon(faces[iface], &points[faces[iface].Conflicts[iconflict].Index])
-		fmt.Println("horizon: ", horizon)
-		newfaces := make([]qhull.Face, len(horizon), len(horizon))
-
-		for n, edge := range horizon {
-			newfaces[n].Vertices = [3]int{faces[iface].Conflicts[iconflict].Index, edge.Tail, edge.Twin.Tail}
-			newfaces[n].Edges = [3]*qhull.Edge{
-				{Tail: newfaces[n].Vertices[1], Face: &newfaces[n]},
-				{Tail: newfaces[n].Vertices[0], Face: &newfaces[n], Twin: edge},
-				{Tail: newfaces[n].Vertices[2], Face: &newfaces[n]},
-			}
-			edge.Twin = newfaces[n].Edges[1]
+		horizon := qhull.FindHorizon(faces[iface], points, &points[apex])
+		cone := qhull.BuildCone(apex, horizon, points)
 
-			newfaces[n].Edges[0].Next = newfaces[n].Edges[1]
-			newfaces[n].Edges[1].Next = newfaces[n].Edges[2]
-			newfaces[n].Edges[2].Next = newfaces[n].Edges[0]
-
-			newfaces[n].Edges[2].Prev = newfaces[n].Edges[1]
-			newfaces[n].Edges[0].Prev = newfaces[n].Edges[2]
-			newfaces[n].Edges[1].Prev = newfaces[n].Edges[0]
-
-			newfaces[n].Faces = [3]*qhull.Face{nil, edge.Twin.Face, nil}
-			l1, l2 := points[newfaces[n].Vertices[1]].Sub(&points[newfaces[n].Vertices[0]]), points[newfaces[n].Vertices[2]].Sub(&points[newfaces[n].Vertices[0]])
-			newfaces[n].Normal = l2.Cross(&l1)
-			newfaces[n].Point = points[newfaces[n].Vertices[0]]
-
-			for _, c := range faces[iface].Conflicts {
-				if dist := DistToTriangle(&points[c.Index], &points[newfaces[n].Vertices[0]], &points[newfaces[n].Vertices[1]], &points[newfaces[n].Vertices[2]]); dist > epsilon {
-					newfaces[n].Conflicts = append(newfaces[n].Conflicts, qhull.Conflict{Distance: dist, Index: c.Index})
-				}
+		// The faces that can see the apex are replaced by the cone, their
+		// conflicts move to the new faces that can see them.
+		var kept int
+		for _, face := range faces {
+			if !face.Visited {
+				faces[kept] = face
+				kept++
+				continue
 			}
-
-			for _, c := range faces[iface].Conflicts {
-				if dist := DistToTriangle(&points[c.Index], &points[newfaces[n].Vertices[0]], &points[newfaces[n].Vertices[1]], &points[newfaces[n].Vertices[2]]); dist > epsilon {
-					newfaces[n].Conflicts = append(newfaces[n].Conflicts, qhull.Conflict{Distance: dist, Index: c.Index})
+			for _, c := range face.Conflicts {
+				if c.Index == apex {
+					continue
 				}
-			}
-
-			// check surrounding faces in case we suddently see something new
-			for _, face := range faces[iface].Faces {
-				for _, c := range face.Conflicts {
-					if dist := DistToTriangle(&points[c.Index], &points[newfaces[n].Vertices[0]], &points[newfaces[n].Vertices[1]], &points[newfaces[n].Vertices[2]]); dist > epsilon {
-						newfaces[n].Conflicts = append(newfaces[n].Conflicts, qhull.Conflict{Distance: dist, Index: c.Index})
+				for _, newface := range cone {
+					if newface.AddConflict(points, c.Index) {
+						break
 					}
 				}
 			}
-
 		}
+		faces = append(faces[:kept], cone...)
+	}
 
-		for n := range newfaces {
-			newfaces[n].Edges[0].Twin = newfaces[(n-1+len(newfaces))%len(newfaces)].Edges[2]
-			newfaces[n].Edges[2].Twin = newfaces[(n+1)%len(newfaces)].Edges[1]
-			newfaces[n].Faces[0] = &newfaces[(n-1+len(newfaces))%len(newfaces)]
-			newfaces[n].Faces[2] = &newfaces[(n+1)%len(newfaces)]
-
-			faces = append(faces, &newfaces[n])
-		}
+	// The faces are clockwise seen from outside.
+	triangles := make([]int, 0, 3*len(faces))
+	for _, face := range faces {
+		triangles = append(triangles, face.Vertices[0], face.Vertices[2], face.Vertices[1])
+	}
+	return triangles
+}
 
-		//clean old faces
-		var oldfaces []*qhull.Face
-		for _, e := range horizon {
-			var found bool
-			for _, face := range oldfaces {
-				if e.Face == face {
-					found = true
-					break
-				}
-			}
+// hullTetrahedron returns 4 extreme points that aren't coplanar, ordered so
+// that the last one is on the side (b - a).Cross(c - a) points to, or false if
+// there are none.
+func hullTetrahedron(points []glm.Vec3) ([4]int, bool) {
+	var first [4]int
+	if len(points) < 4 {
+		return first, false
+	}
 
-			if !found {
-				oldfaces = append(oldfaces, e.Face)
+	// The 2 extremums furthest apart.
+	extremumIndices, extremums := qhull.FindExtremums(points)
+	var maxDist float32
+	for i := range extremums {
+		for j := i + 1; j < len(extremums); j++ {
+			if d := extremums[i].Sub(&extremums[j]); d.Len2() > maxDist {
+				maxDist = d.Len2()
+				first[0], first[1] = extremumIndices[i], extremumIndices[j]
 			}
 		}
+	}
+	a, b := &points[first[0]], &points[first[1]]
+	if *a == *b {
+		return first, false
+	}
 
-		for _, face := range oldfaces {
-			for n := 0; n < len(faces); n++ {
-				if face == faces[n] {
-					faces = faces[:n+copy(faces[n:], faces[n+1:])]
-					n--
-				}
+	// The point furthest from the line through them.
+	ab := b.Sub(a)
+	maxDist = -1
+	for n := range points {
+		ap := points[n].Sub(a)
+		if c := ab.Cross(&ap); c.Len2() > maxDist {
+			maxDist = c.Len2()
+			first[2] = n
+		}
+	}
+	if p := &points[first[2]]; robust.CrossDot(a, b, p, p) <= 0 {
+		// Rounding hid the only points off the line.
+		first[2] = -1
+		for n := range points {
+			if p := &points[n]; robust.CrossDot(a, b, p, p) > 0 {
+				first[2] = n
+				break
 			}
 		}
+		if first[2] < 0 {
+			return first, false
+		}
+	}
+	c := &points[first[2]]
 
-		{ // debug
-			for _, face := range faces {
-				fmt.Println("face:      ", fmt.Sprintf("%p", face), points[face.Vertices[0]], points[face.Vertices[1]], points[face.Vertices[2]])
-				fmt.Println("edges:     ", face.Edges)
-				fmt.Println("conflicts: ", face.Conflicts)
-				fmt.Println()
+	// The point furthest from the plane through them.
+	ac := c.Sub(a)
+	dir := ab.Cross(&ac)
+	imin, imax := ExtremePointsAlongDirection(&dir, points)
+	vmin, vmax := points[imin].Sub(a), points[imax].Sub(a)
+	if -vmin.Dot(&dir) > vmax.Dot(&dir) {
+		first[3] = imin
+	} else {
+		first[3] = imax
+	}
+	if robust.Orient3D(a, b, c, &points[first[3]]) == 0 {
+		first[3] = -1
+		for n := range points {
+			if robust.Orient3D(a, b, c, &points[n]) != 0 {
+				first[3] = n
+				break
 			}
 		}
-
-		if cnt >= 1 {
-			break
+		if first[3] < 0 {
+			return first, false
 		}
-		cnt++
-
 	}
 
-	/*for _, face := range faces {
-		fmt.Printf("v %f %f %f\n", points[face.Vertices[0]][0], points[face.Vertices[0]][1], points[face.Vertices[0]][2])
-		fmt.Printf("v %f %f %f\n", points[face.Vertices[1]][0], points[face.Vertices[1]][1], points[face.Vertices[1]][2])
-		fmt.Printf("v %f %f %f\n", points[face.Vertices[2]][0], points[face.Vertices[2]][1], points[face.Vertices[2]][2])
+	if robust.Orient3D(a, b, c, &points[first[3]]) < 0 {
+		first[1], first[2] = first[2], first[1]
 	}
-
-	for n := range faces {
-		fmt.Printf("f %d %d %d\n", (n)*3+3, (n)*3+2, (n)*3+1)
-	}*/
-
+	return first, true
 }
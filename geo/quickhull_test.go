@@ -1,15 +1,98 @@
 package geo
 
 import (
+	"github.com/EngoEngine/math"
 	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
 	"testing"
 )
 
 func TestQuickhull(t *testing.T) {
-	t.Skip("implementation unfinished")
-	points := []glm.Vec3{{0, 0, 0}, {1, 1, 1},
-		{2, 0, 0}, {0, 2, 0}, {0, 0, 2},
-		{-1, 0, 0}, {0, -1, 0}, {0, 0, -1}, {0.1, 0.1, 0.1},
-		{0, 1.9, 1.9}} // that last one should see 2 faces :\
-	Quickhull(points)
+	t.Parallel()
+	seed := uint32(1)
+	rnd := func() float32 {
+		seed = seed*1664525 + 1013904223
+		return float32(seed>>8) / (1 << 24)
+	}
+	cloud := func(n int, scale glm.Vec3) []glm.Vec3 {
+		points := make([]glm.Vec3, n)
+		for i := range points {
+			points[i] = glm.Vec3{rnd() * scale[0], rnd() * scale[1], rnd() * scale[2]}
+		}
+		return append(points, points[n/2])
+	}
+	// Points on a sphere, all of them are nearly coplanar with their neighbours.
+	sphere := make([]glm.Vec3, 300)
+	for i := range sphere {
+		p := glm.Vec3{rnd() - 0.5, rnd() - 0.5, rnd() - 0.5}
+		sphere[i] = p.Normalized()
+	}
+	// A 4x4x4 lattice, full of coplanar and collinear points.
+	var lattice []glm.Vec3
+	for i := 0; i < 64; i++ {
+		j := i * 7 % 64
+		lattice = append(lattice, glm.Vec3{float32(j % 4), float32(j / 4 % 4), float32(j / 16)})
+	}
+
+	tests := []struct {
+		name      string
+		points    []glm.Vec3
+		volume    float32
+		triangles int
+	}{
+		{name: "points", points: []glm.Vec3{{0, 0, 0}, {1, 1, 1},
+			{2, 0, 0}, {0, 2, 0}, {0, 0, 2},
+			{-1, 0, 0}, {0, -1, 0}, {0, 0, -1}, {0.1, 0.1, 0.1},
+			{0, 1.9, 1.9}}, triangles: 12},
+		{name: "cube", points: cloud(100, glm.Vec3{1, 1, 1})},
+		{name: "cube 2", points: cloud(1000, glm.Vec3{1, 1, 1})},
+		{name: "flat", points: cloud(100, glm.Vec3{1, 1, 1e-4})},
+		{name: "needle", points: cloud(100, glm.Vec3{1, 0.01, 0.01})},
+		{name: "sphere", points: sphere},
+		{name: "lattice", points: lattice, volume: 27},
+	}
+	for _, test := range tests {
+		points := test.points
+		triangles := Quickhull(points)
+		if len(triangles) == 0 {
+			t.Errorf("%s: no triangles", test.name)
+			continue
+		}
+		if test.triangles != 0 && len(triangles) != 3*test.triangles {
+			t.Errorf("%s: %d triangles, want %d", test.name, len(triangles)/3, test.triangles)
+		}
+		var volume float32
+		edges := make(map[[2]int]int)
+		for i := 0; i < len(triangles); i += 3 {
+			v := triangles[i : i+3]
+			a, b, c := &points[v[0]], &points[v[1]], &points[v[2]]
+			if robust.CrossDot(a, b, c, c) <= 0 {
+				t.Errorf("%s: triangle %v is degenerate", test.name, v)
+			}
+			for j := range points {
+				if o := robust.Orient3D(a, b, c, &points[j]); o > 0 {
+					t.Errorf("%s: point %d is outside triangle %v", test.name, j, v)
+				}
+			}
+			bc := b.Cross(c)
+			volume += a.Dot(&bc) / 6
+			for k := range v {
+				edges[[2]int{v[k], v[(k+1)%3]}]++
+			}
+		}
+		// The hull is closed, each edge is used once in each direction.
+		for e, count := range edges {
+			if count != 1 || edges[[2]int{e[1], e[0]}] != 1 {
+				t.Errorf("%s: edge %v is used %d times and %d times reversed", test.name, e, count, edges[[2]int{e[1], e[0]}])
+			}
+		}
+		if test.volume != 0 && math.Abs(volume-test.volume) > 1e-4 {
+			t.Errorf("%s: volume %g, want %g", test.name, volume, test.volume)
+		}
+	}
+
+	// Coplanar points have no hull.
+	if triangles := Quickhull(cloud(100, glm.Vec3{1, 1, 0})); len(triangles) != 0 {
+		t.Errorf("coplanar: %d triangles, want 0", len(triangles)/3)
+	}
 }
@@ -0,0 +1,147 @@
+package robust
+
+import (
+	"math"
+)
+
+// expansion is an exact sum of float64 components which don't overlap, sorted
+// by increasing magnitude and without zeros, so the last one has the sign of
+// the sum. The empty expansion is 0.
+//
+// The arithmetic follows Shewchuk, "Adaptive Precision Floating-Point
+// Arithmetic and Fast Robust Geometric Predicates". The explicit conversions
+// prevent the compiler from fusing operations whose rounding errors must be
+// recovered exactly.
+type expansion []float64
+
+// twoSum returns a + b rounded and the rounding error.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := float64(x - a)
+	av := float64(x - bv)
+	y = float64(a-av) + float64(b-bv)
+	return
+}
+
+// twoProduct returns a * b rounded and the rounding error.
+func twoProduct(a, b float64) (x, y float64) {
+	x = float64(a * b)
+	y = math.FMA(a, b, -x)
+	return
+}
+
+// diff returns the exact expansion of a - b.
+func diff(a, b float64) expansion {
+	x, y := twoSum(a, -b)
+	e := make(expansion, 0, 2)
+	if y != 0 {
+		e = append(e, y)
+	}
+	if x != 0 {
+		e = append(e, x)
+	}
+	return e
+}
+
+// grow returns the exact expansion of e + b.
+func (e expansion) grow(b float64) expansion {
+	h := make(expansion, 0, len(e)+1)
+	q := b
+	for _, c := range e {
+		var hh float64
+		q, hh = twoSum(q, c)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// add returns the exact expansion of e + f.
+func (e expansion) add(f expansion) expansion {
+	for _, c := range f {
+		e = e.grow(c)
+	}
+	return e
+}
+
+// sub returns the exact expansion of e - f.
+func (e expansion) sub(f expansion) expansion {
+	for _, c := range f {
+		e = e.grow(-c)
+	}
+	return e
+}
+
+// scale returns the exact expansion of e * b.
+func (e expansion) scale(b float64) expansion {
+	if len(e) == 0 || b == 0 {
+		return nil
+	}
+	h := make(expansion, 0, 2*len(e))
+	q, hh := twoProduct(e[0], b)
+	if hh != 0 {
+		h = append(h, hh)
+	}
+	for _, c := range e[1:] {
+		p1, p0 := twoProduct(c, b)
+		var sum float64
+		sum, hh = twoSum(q, p0)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+		q, hh = twoSum(p1, sum)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// mul returns the exact expansion of e * f.
+func (e expansion) mul(f expansion) expansion {
+	var h expansion
+	for _, c := range f {
+		h = h.add(e.scale(c))
+	}
+	return h
+}
+
+// estimate returns the sum of the components of e, which has the sign of e.
+func (e expansion) estimate() float64 {
+	var s float64
+	for _, c := range e {
+		s += c
+	}
+	return s
+}
+
+// det3 returns the exact determinant of the rows of m.
+func det3(m *[3][3]expansion) expansion {
+	minor := func(c0, c1 int) expansion {
+		return m[1][c0].mul(m[2][c1]).sub(m[1][c1].mul(m[2][c0]))
+	}
+	return m[0][0].mul(minor(1, 2)).sub(m[0][1].mul(minor(0, 2))).add(m[0][2].mul(minor(0, 1)))
+}
+
+// det3Approx returns the determinant of the rows of m evaluated in float64,
+// with the same operations as det3, and its permanent, the same sum of
+// products with all the terms positive, to bound its error.
+func det3Approx(m *[3][3]float64) (det, permanent float64) {
+	minor := func(c0, c1 int) (float64, float64) {
+		l, r := m[1][c0]*m[2][c1], m[1][c1]*m[2][c0]
+		return l - r, math.Abs(l) + math.Abs(r)
+	}
+	d0, p0 := minor(1, 2)
+	d1, p1 := minor(0, 2)
+	d2, p2 := minor(0, 1)
+	det = m[0][0]*d0 - m[0][1]*d1 + m[0][2]*d2
+	permanent = math.Abs(m[0][0])*p0 + math.Abs(m[0][1])*p1 + math.Abs(m[0][2])*p2
+	return
+}
@@ -0,0 +1,198 @@
+// Package robust implements the geometric predicates that decide on which side
+// of a line, plane, circle or sphere a point is, with an exact sign.
+//
+// A plain floating-point evaluation misclassifies nearly collinear or coplanar
+// points, which makes hulls and triangulations loop or build broken
+// topology. Like Shewchuk's adaptive predicates, the determinants are first
+// evaluated in float64 along with a bound of their rounding error, and only
+// when that can't decide the sign are they evaluated exactly with
+// floating-point expansions. The returned values approximate the
+// determinants, their signs are always right.
+package robust
+
+import (
+	"math"
+
+	"github.com/engoengine/glm"
+)
+
+// epsilon is half the machine epsilon of float64, the largest relative
+// rounding error of an operation.
+const epsilon = 1.0 / (1 << 53)
+
+// The relative error bounds of the float64 evaluations.
+const (
+	orient2dBound = (3 + 16*epsilon) * epsilon
+	orient3dBound = (7 + 56*epsilon) * epsilon
+	incircleBound = (10 + 96*epsilon) * epsilon
+	insphereBound = (16 + 224*epsilon) * epsilon
+	crossDotBound = (16 + 128*epsilon) * epsilon
+)
+
+// Orient2D returns a positive value if a, b, c are in counter-clockwise order,
+// negative if they are clockwise and 0 if they are collinear. The value is
+// approximately twice the signed area of the triangle.
+func Orient2D(a, b, c *glm.Vec2) float64 {
+	ax, ay := float64(a[0]), float64(a[1])
+	bx, by := float64(b[0]), float64(b[1])
+	cx, cy := float64(c[0]), float64(c[1])
+	l, r := (ax-cx)*(by-cy), (ay-cy)*(bx-cx)
+	det := l - r
+	if bound := orient2dBound * (math.Abs(l) + math.Abs(r)); det > bound || -det > bound {
+		return det
+	}
+
+	acx, acy, bcx, bcy := diff(ax, cx), diff(ay, cy), diff(bx, cx), diff(by, cy)
+	return acx.mul(bcy).sub(acy.mul(bcx)).estimate()
+}
+
+// Orient3D returns a positive value if d is on the side of the plane through a,
+// b, c that (b - a).Cross(c - a) points to, negative if it's on the other side
+// and 0 if the points are coplanar. The value is approximately 6 times the
+// signed volume of the tetrahedron.
+func Orient3D(a, b, c, d *glm.Vec3) float64 {
+	var m [3][3]float64
+	for i, p := range [3]*glm.Vec3{b, c, d} {
+		for j := range m[i] {
+			m[i][j] = float64(p[j]) - float64(a[j])
+		}
+	}
+	det, permanent := det3Approx(&m)
+	if bound := orient3dBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	var e [3][3]expansion
+	for i, p := range [3]*glm.Vec3{b, c, d} {
+		for j := range e[i] {
+			e[i][j] = diff(float64(p[j]), float64(a[j]))
+		}
+	}
+	return det3(&e).estimate()
+}
+
+// InCircle returns a positive value if d is inside the circle through a, b, c,
+// which must be in counter-clockwise order, negative if it's outside and 0 if
+// it's on the circle. The signs are swapped if a, b, c are clockwise.
+func InCircle(a, b, c, d *glm.Vec2) float64 {
+	dx, dy := float64(d[0]), float64(d[1])
+	var m [3][3]float64
+	for i, p := range [3]*glm.Vec2{a, b, c} {
+		x, y := float64(p[0])-dx, float64(p[1])-dy
+		m[i] = [3]float64{x, y, x*x + y*y}
+	}
+	det, permanent := det3Approx(&m)
+	if bound := incircleBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	var e [3][3]expansion
+	for i, p := range [3]*glm.Vec2{a, b, c} {
+		x, y := diff(float64(p[0]), dx), diff(float64(p[1]), dy)
+		e[i] = [3]expansion{x, y, x.mul(x).add(y.mul(y))}
+	}
+	return det3(&e).estimate()
+}
+
+// InSphere returns a positive value if e is inside the sphere through a, b, c,
+// d, which must have a positive Orient3D, negative if it's outside and 0 if
+// it's on the sphere. The signs are swapped if Orient3D(a, b, c, d) is
+// negative.
+func InSphere(a, b, c, d, e *glm.Vec3) float64 {
+	// The determinant of the rows p - e, |p - e|² is expanded along the
+	// last column, rows[i] being the 3x3 minor without row i.
+	points := [4]*glm.Vec3{a, b, c, d}
+	var rows [4][3]float64
+	var lift [4]float64
+	for i, p := range points {
+		for j := range rows[i] {
+			rows[i][j] = float64(p[j]) - float64(e[j])
+		}
+		lift[i] = rows[i][0]*rows[i][0] + rows[i][1]*rows[i][1] + rows[i][2]*rows[i][2]
+	}
+	var det, permanent float64
+	for i := range points {
+		var m [3][3]float64
+		for j, k := 0, 0; j < 4; j++ {
+			if j != i {
+				m[k] = rows[j]
+				k++
+			}
+		}
+		d, p := det3Approx(&m)
+		if i%2 == 0 {
+			d = -d
+		}
+		det += lift[i] * d
+		permanent += lift[i] * p
+	}
+	// The determinant is negative inside for a positive Orient3D.
+	det = -det
+	if bound := insphereBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	var erows [4][3]expansion
+	var elift [4]expansion
+	for i, p := range points {
+		for j := range erows[i] {
+			erows[i][j] = diff(float64(p[j]), float64(e[j]))
+		}
+		x, y, z := erows[i][0], erows[i][1], erows[i][2]
+		elift[i] = x.mul(x).add(y.mul(y)).add(z.mul(z))
+	}
+	var exact expansion
+	for i := range points {
+		var m [3][3]expansion
+		for j, k := 0, 0; j < 4; j++ {
+			if j != i {
+				m[k] = erows[j]
+				k++
+			}
+		}
+		term := elift[i].mul(det3(&m))
+		if i%2 == 0 {
+			exact = exact.add(term)
+		} else {
+			exact = exact.sub(term)
+		}
+	}
+	return exact.estimate()
+}
+
+// CrossDot returns a positive value if (b - a).Cross(p - a) and
+// (b - a).Cross(q - a) point the same way, negative if they point in opposite
+// directions and 0 if either is null. For coplanar points it tells whether p
+// and q are on the same side of the line through a and b.
+func CrossDot(a, b, p, q *glm.Vec3) float64 {
+	var u, v, w [3]float64
+	for i := range u {
+		ai := float64(a[i])
+		u[i], v[i], w[i] = float64(b[i])-ai, float64(p[i])-ai, float64(q[i])-ai
+	}
+	var det, permanent float64
+	for i := range u {
+		j, k := (i+1)%3, (i+2)%3
+		vl, vr := u[j]*v[k], u[k]*v[j]
+		wl, wr := u[j]*w[k], u[k]*w[j]
+		det += (vl - vr) * (wl - wr)
+		permanent += (math.Abs(vl) + math.Abs(vr)) * (math.Abs(wl) + math.Abs(wr))
+	}
+	if bound := crossDotBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	var eu, ev, ew [3]expansion
+	for i := range eu {
+		ai := float64(a[i])
+		eu[i], ev[i], ew[i] = diff(float64(b[i]), ai), diff(float64(p[i]), ai), diff(float64(q[i]), ai)
+	}
+	var exact expansion
+	for i := range eu {
+		j, k := (i+1)%3, (i+2)%3
+		cv := eu[j].mul(ev[k]).sub(eu[k].mul(ev[j]))
+		cw := eu[j].mul(ew[k]).sub(eu[k].mul(ew[j]))
+		exact = exact.add(cv.mul(cw))
+	}
+	return exact.estimate()
+}
@@ -0,0 +1,225 @@
+package robust
+
+import (
+	"github.com/engoengine/glm"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func rat(f float32) *big.Rat {
+	return new(big.Rat).SetFloat64(float64(f))
+}
+
+// ratDet3 returns the determinant of the rows of m.
+func ratDet3(m *[3][3]*big.Rat) *big.Rat {
+	mul := func(a, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) }
+	minor := func(c0, c1 int) *big.Rat {
+		return new(big.Rat).Sub(mul(m[1][c0], m[2][c1]), mul(m[1][c1], m[2][c0]))
+	}
+	d := mul(m[0][0], minor(1, 2))
+	d.Sub(d, mul(m[0][1], minor(0, 2)))
+	return d.Add(d, mul(m[0][2], minor(0, 1)))
+}
+
+// ratSub returns the rows p - o of the points.
+func ratSub(o *glm.Vec3, points ...*glm.Vec3) [][3]*big.Rat {
+	rows := make([][3]*big.Rat, len(points))
+	for i, p := range points {
+		for j := range rows[i] {
+			rows[i][j] = new(big.Rat).Sub(rat(p[j]), rat(o[j]))
+		}
+	}
+	return rows
+}
+
+func exactOrient3D(a, b, c, d *glm.Vec3) int {
+	rows := ratSub(a, b, c, d)
+	m := [3][3]*big.Rat{rows[0], rows[1], rows[2]}
+	return ratDet3(&m).Sign()
+}
+
+// exactInSphere returns the sign of InSphere.
+func exactInSphere(a, b, c, d, e *glm.Vec3) int {
+	rows := ratSub(e, a, b, c, d)
+	det := new(big.Rat)
+	for i := range rows {
+		lift := new(big.Rat)
+		for _, x := range rows[i] {
+			lift.Add(lift, new(big.Rat).Mul(x, x))
+		}
+		var m [3][3]*big.Rat
+		for j, k := 0, 0; j < 4; j++ {
+			if j != i {
+				m[k] = rows[j]
+				k++
+			}
+		}
+		term := lift.Mul(lift, ratDet3(&m))
+		if i%2 == 0 {
+			det.Add(det, term)
+		} else {
+			det.Sub(det, term)
+		}
+	}
+	return det.Sign()
+}
+
+func exactCrossDot(a, b, p, q *glm.Vec3) int {
+	rows := ratSub(a, b, p, q)
+	u, v, w := rows[0], rows[1], rows[2]
+	det := new(big.Rat)
+	for i := range u {
+		j, k := (i+1)%3, (i+2)%3
+		cv := new(big.Rat).Sub(new(big.Rat).Mul(u[j], v[k]), new(big.Rat).Mul(u[k], v[j]))
+		cw := new(big.Rat).Sub(new(big.Rat).Mul(u[j], w[k]), new(big.Rat).Mul(u[k], w[j]))
+		det.Add(det, cv.Mul(cv, cw))
+	}
+	return det.Sign()
+}
+
+func vec3(v glm.Vec2) glm.Vec3 {
+	return glm.Vec3{v[0], v[1], 0}
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+// nudge moves f by up to 2 float32 ulps.
+func nudge(r *rand.Rand, f float32) float32 {
+	for i := r.Intn(5) - 2; i != 0; {
+		if i > 0 {
+			f, i = math.Nextafter32(f, float32(math.Inf(1))), i-1
+		} else {
+			f, i = math.Nextafter32(f, float32(math.Inf(-1))), i+1
+		}
+	}
+	return f
+}
+
+func TestOrient(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	random := func() float32 { return r.Float32()*20 - 10 }
+	for i := 0; i < 2000; i++ {
+		a, b := glm.Vec2{random(), random()}, glm.Vec2{random(), random()}
+		// c is nearly on the line ab, often exactly.
+		s := r.Float32()*3 - 1
+		c := glm.Vec2{nudge(r, a[0]+s*(b[0]-a[0])), nudge(r, a[1]+s*(b[1]-a[1]))}
+		a3, b3, c3 := vec3(a), vec3(b), vec3(c)
+		up := glm.Vec3{a[0], a[1], 1}
+		want := -exactOrient3D(&a3, &b3, &up, &c3)
+		if got := sign(Orient2D(&a, &b, &c)); got != want {
+			t.Errorf("Orient2D(%v, %v, %v) sign = %d, want %d", a, b, c, got, want)
+		}
+
+		a3[2], b3[2] = random(), random()
+		d3 := glm.Vec3{random(), random(), random()}
+		u, v := r.Float32()*3-1, r.Float32()*3-1
+		var p glm.Vec3
+		for j := range p {
+			p[j] = nudge(r, a3[j]+u*(b3[j]-a3[j])+v*(d3[j]-a3[j]))
+		}
+		want = exactOrient3D(&a3, &b3, &d3, &p)
+		if got := sign(Orient3D(&a3, &b3, &d3, &p)); got != want {
+			t.Errorf("Orient3D(%v, %v, %v, %v) sign = %d, want %d", a3, b3, d3, p, got, want)
+		}
+
+		want = exactCrossDot(&a3, &b3, &p, &c3)
+		if got := sign(CrossDot(&a3, &b3, &p, &c3)); got != want {
+			t.Errorf("CrossDot(%v, %v, %v, %v) sign = %d, want %d", a3, b3, p, c3, got, want)
+		}
+	}
+
+	// The classic failure of the float evaluation, points near the diagonal
+	// of a grid of ulps.
+	b, c := glm.Vec2{12, 12}, glm.Vec2{24, 24}
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			a := glm.Vec2{0.5, 0.5}
+			for i := 0; i < x; i++ {
+				a[0] = math.Nextafter32(a[0], 1)
+			}
+			for i := 0; i < y; i++ {
+				a[1] = math.Nextafter32(a[1], 1)
+			}
+			want := sign(float64(y - x))
+			if got := sign(Orient2D(&a, &b, &c)); got != want {
+				t.Errorf("Orient2D(%v, %v, %v) sign = %d, want %d", a, b, c, got, want)
+			}
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(2))
+	onCircle := func(center glm.Vec2, radius float32) glm.Vec2 {
+		s, c := math.Sincos(r.Float64() * 2 * math.Pi)
+		return glm.Vec2{nudge(r, center[0]+radius*float32(c)), nudge(r, center[1]+radius*float32(s))}
+	}
+	for i := 0; i < 2000; i++ {
+		center, radius := glm.Vec2{r.Float32() * 100, r.Float32() * 100}, r.Float32()*10+0.1
+		a, b, c, d := onCircle(center, radius), onCircle(center, radius), onCircle(center, radius), onCircle(center, radius)
+		if i%4 == 0 {
+			// Cocircular points on integers.
+			a, b, c, d = glm.Vec2{3, 4}, glm.Vec2{-4, 3}, glm.Vec2{0, -5}, glm.Vec2{nudge(r, 5), 0}
+		}
+		if Orient2D(&a, &b, &c) < 0 {
+			a, b = b, a
+		}
+		// The rows p - d, |p - d|².
+		a3, b3, c3, d3 := vec3(a), vec3(b), vec3(c), vec3(d)
+		rows := ratSub(&d3, &a3, &b3, &c3)
+		for i, row := range rows {
+			rows[i][2] = new(big.Rat).Add(new(big.Rat).Mul(row[0], row[0]), new(big.Rat).Mul(row[1], row[1]))
+		}
+		m := [3][3]*big.Rat{rows[0], rows[1], rows[2]}
+		want := ratDet3(&m).Sign()
+		if got := sign(InCircle(&a, &b, &c, &d)); got != want {
+			t.Errorf("InCircle(%v, %v, %v, %v) sign = %d, want %d", a, b, c, d, got, want)
+		}
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(3))
+	onSphere := func(center glm.Vec3, radius float32) glm.Vec3 {
+		v := glm.Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}
+		v.Normalize()
+		var p glm.Vec3
+		for j := range p {
+			p[j] = nudge(r, center[j]+radius*v[j])
+		}
+		return p
+	}
+	for i := 0; i < 1000; i++ {
+		center, radius := glm.Vec3{r.Float32() * 10, r.Float32() * 10, r.Float32() * 10}, r.Float32()*5+0.1
+		a, b, c, d, e := onSphere(center, radius), onSphere(center, radius), onSphere(center, radius), onSphere(center, radius), onSphere(center, radius)
+		if i%4 == 0 {
+			// Cospherical points on integers.
+			a, b, c, d, e = glm.Vec3{1, 2, 2}, glm.Vec3{-2, 1, 2}, glm.Vec3{2, -2, 1}, glm.Vec3{0, 0, -3}, glm.Vec3{3, 0, nudge(r, 0)}
+		}
+		if Orient3D(&a, &b, &c, &d) < 0 {
+			a, b = b, a
+		}
+		want := exactInSphere(&a, &b, &c, &d, &e)
+		if got := sign(InSphere(&a, &b, &c, &d, &e)); got != want {
+			t.Errorf("InSphere(%v, %v, %v, %v, %v) sign = %d, want %d", a, b, c, d, e, got, want)
+		}
+	}
+
+	a, b, c, d := glm.Vec3{0, 0, 0}, glm.Vec3{1, 0, 0}, glm.Vec3{0, 1, 0}, glm.Vec3{0, 0, 1}
+	if in, out := (glm.Vec3{0.25, 0.25, 0.25}), (glm.Vec3{2, 2, 2}); InSphere(&a, &b, &c, &d, &in) <= 0 || InSphere(&a, &b, &c, &d, &out) >= 0 {
+		t.Errorf("InSphere of the unit tetrahedron has the wrong sign")
+	}
+}
@@ -102,8 +102,8 @@ func TestAABBFromSphere(t *testing.T) {
 
 	for i, test := range tests {
 		aabb := AABBFromSphere(&test.a)
-		if !aabb.Center.ApproxEqualThreshold(&test.b.Center, 1e-4) ||
-			!aabb.HalfExtend.ApproxEqualThreshold(&test.b.HalfExtend, 1e-4) {
+		if !aabb.Center.EqualThreshold(&test.b.Center, 1e-4) ||
+			!aabb.HalfExtend.EqualThreshold(&test.b.HalfExtend, 1e-4) {
 			t.Errorf("[%d] %v.AABB = %v, want %v", i, test.a, aabb, test.b)
 		}
 	}
@@ -3,22 +3,14 @@ package geo
 import (
 	"github.com/engoengine/glm"
 	"github.com/engoengine/glm/flops/32/flops"
+	"github.com/engoengine/glm/geo/robust"
 	"github.com/EngoEngine/math"
 )
 
 // IsConvexQuad returns true if the qualidrateral is convex.
 func IsConvexQuad(a, b, c, d *glm.Vec3) bool {
-	dmb, amb, cmb := d.Sub(b), a.Sub(b), c.Sub(b)
-	bda, bdc := dmb.Cross(&amb), dmb.Cross(&cmb)
-
-	if bda.Dot(&bdc) >= 0 {
-		return false
-	}
-
-	cma, dma, bma := c.Sub(a), d.Sub(a), b.Sub(a)
-	acd := cma.Cross(&dma)
-	acb := cma.Cross(&bma)
-	return acd.Dot(&acb) < 0
+	// The diagonal bd must separate a and c, and ac must separate b and d.
+	return robust.CrossDot(b, d, a, c) < 0 && robust.CrossDot(a, c, d, b) < 0
 }
 
 // ExtremePointsAlongDirection returns indices imin and imax into points of the
@@ -114,6 +106,8 @@ func CovarianceMatrix(cov *glm.Mat3, points []glm.Vec3) {
 // (s, c) that will serve to form a Jacobi rotation matrix.
 //
 // See Golub, Van Loan, Matrix Computations, 3rd ed, p428
+//
+// Deprecated: glm.Mat3.SymEigen does the whole eigen decomposition.
 func SymSchur2(a *glm.Mat3, p, q int) (c, s float32) {
 	if math.Abs(a[3*q+p]) > 0.0001 {
 		r := (a[3*q+q] - a[3*p+p]) / (2.0 * a[3*q+p])
@@ -132,79 +126,16 @@ func SymSchur2(a *glm.Mat3, p, q int) (c, s float32) {
 	return
 }
 
-// Jacobi computes the eigenvectors and eigenvalues of the symmetric matrix A
-// using the classic Jacobi method of iteratively updating A as A = J∧T * A * J,
-// where J = J(p, q, theta) is the Jacobi rotation matrix.
+// Jacobi computes the eigenvectors and eigenvalues of the symmetric matrix A.
 //
 // On exit, v will contain the eigenvectors, and the diagonal elements
 // of a are the corresponding eigenvalues.
 //
-// See Golub, Van Loan, Matrix Computations, 3rd ed, p428
+// Deprecated: use glm.Mat3.SymEigen, this is kept as a thin wrapper around it.
 func Jacobi(a, v *glm.Mat3) {
-	// TODO(hydroflame): find a good value for that
-	const maxIterations = 50
-
-	var i, j, n, p, q int
-	var prevoff, c, s float32
-	// Initialize v to identity matrix
-	v.Ident()
-
-	var J glm.Mat3
-	// Repeat for some maximum number of iterations
-	for n = 0; n < maxIterations; n++ {
-		// Find largest off-diagonal absolute element a[p][q]
-		p, q = 0, 1
-		for i = 0; i < 3; i++ {
-			for j = 0; j < 3; j++ {
-				if i == j {
-					continue
-				}
-				if math.Abs(a[3*j+i]) > math.Abs(a[3*q+p]) {
-					p = i
-					q = j
-				}
-			}
-		}
-		// Compute the Jacobi rotation matrix J(p, q, theta)
-		// (This code can be optimized for the three different cases of rotation)
-		c, s = SymSchur2(a, p, q)
-		for i = 0; i < 3; i++ {
-			J[3*0+i] = 0
-			J[3*1+i] = 0
-			J[3*2+i] = 0
-			J[3*i+i] = 1
-		}
-		J[3*p+p] = c
-		J[3*q+p] = s
-		J[3*p+q] = -s
-		J[3*q+q] = c
-
-		// Cumulate rotations into what will contain the eigenvectors
-		*v = v.Mul3(&J)
-		// Make a more diagonal, until just eigenvalues remain on diagonal
-
-		Jt := J.Transposed()
-		Jta := Jt.Mul3(a)
-		a.Mul3Of(&Jta, &J)
-
-		// Compute norm of off-diagonal elements
-		var off float32
-		for i = 0; i < 3; i++ {
-			for j = 0; j < 3; j++ {
-				if i == j {
-					continue
-				}
-				off += a[3*j+i] * a[3*j+i]
-			}
-		}
-		/* off = sqrt(off); not needed for norm comparison */
-
-		// Stop when norm no longer decreasing
-		if n > 2 && off >= prevoff {
-			return
-		}
-		prevoff = off
-	}
+	values, vectors := a.SymEigen()
+	*a = glm.Diag3(&values)
+	*v = vectors
 }
 
 // MinimumAreaRectangle returns the center point and axis orientation of the
@@ -475,27 +406,14 @@ func ClosestPointTrianglePoint(p, a, b, c *glm.Vec3) glm.Vec3 {
 
 // PointOutsidePlane returns true if p is outside or on triangle abc CCW.
 func PointOutsidePlane(p, a, b, c *glm.Vec3) bool {
-	//return Dot(p-a,Cross(b-a,c-a))>=0.0f; //[APABAC]>=0
-	ap, ab, ac := p.Sub(a), b.Sub(a), c.Sub(a)
-	abac := ab.Cross(&ac)
-	d := ap.Dot(&abac)
-	return d > 0 || flops.Z(d)
+	return robust.Orient3D(a, b, c, p) >= 0
 }
 
 // PointsOnOppositeSideOfPlane returns true if point p is opposite of d, such that it
 // doesn't matter if abc is CW or CCW
 func PointsOnOppositeSideOfPlane(p1, a, b, c, p2 *glm.Vec3) bool {
-	ap := p1.Sub(a)
-	ad := p2.Sub(a)
-	ab := b.Sub(a)
-	ac := c.Sub(a)
-
-	abac := ab.Cross(&ac)
-
-	signp := ap.Dot(&abac)
-	signd := ad.Dot(&abac)
-
-	return signp*signd < 0
+	o1, o2 := robust.Orient3D(a, b, c, p1), robust.Orient3D(a, b, c, p2)
+	return o1 < 0 && o2 > 0 || o1 > 0 && o2 < 0
 }
 
 // ClosestPointTetrahedronPoint returns the closes point in or on tetrahedron abcd.
@@ -737,7 +655,8 @@ func IntersectRaySphere(p, d *glm.Vec3, s *Sphere) (t float32, q glm.Vec3, overl
 	if flops.Ltz(discr) {
 		return // returns false and all zero value
 	}
-	// Ray now found to intersect sphere, compute smallest t value of intersection t = -b - Sqrt(discr);
+	// Ray now found to intersect sphere, compute smallest t value of intersection
+	t = -b - math.Sqrt(discr)
 	// If t is negative, ray started inside sphere so clamp t to zero
 	if t < 0 {
 		t = 0
@@ -796,7 +715,7 @@ func IntersectRayAABB(p, d *glm.Vec3, a *AABB) (t float32, q glm.Vec3, overlap b
 			if t1 > t {
 				t = t1
 			}
-			if t2 > tmax {
+			if t2 < tmax {
 				tmax = t2
 			}
 			// Exit with no collision as soon as slab intersection becomes empty
@@ -983,6 +902,43 @@ func IntersectSegmentTriangle2(p, q, a, b, c *glm.Vec3) (u, v, w, t float32, ove
 	return
 }
 
+// IntersectRayTriangle intersects ray R(t) = p + t*d against triangle abc,
+// from either side. When intersecting, returns the barycentric coordinates
+// (u,v,w) of the intersection point and its distance t along the ray, in world
+// units if |d| = 1.
+func IntersectRayTriangle(p, d, a, b, c *glm.Vec3) (u, v, w, t float32, overlap bool) {
+	// Möller-Trumbore, Fast, Minimum Storage Ray/Triangle Intersection
+	const epsilon = 0.000001
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	pvec := d.Cross(&ac)
+	det := ab.Dot(&pvec)
+	// The ray is parallel to the plane of the triangle. det is the sine of the
+	// angle between them scaled by |ab|*|ac|*|d|, so the test doesn't depend
+	// on the size of the triangle.
+	if math.Abs(det) < epsilon*math.Sqrt(ab.Len2()*ac.Len2()*d.Len2()) || det == 0 {
+		return
+	}
+	ood := 1.0 / det
+	ap := p.Sub(a)
+	v = ap.Dot(&pvec) * ood
+	if v < 0 || v > 1 {
+		return
+	}
+	qvec := ap.Cross(&ab)
+	w = d.Dot(&qvec) * ood
+	if w < 0 || v+w > 1 {
+		return
+	}
+	t = ac.Dot(&qvec) * ood
+	if t < 0 {
+		return
+	}
+	u = 1 - v - w
+	overlap = true
+	return
+}
+
 // IntersectSegmentCylinder intersects segment S(t)=sa+t(sb-sa), 0<=t<=1 against
 // cylinder specified by p, q and r
 func IntersectSegmentCylinder(sa, sb, p, q *glm.Vec3, r float32) (float32, bool) {
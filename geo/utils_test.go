@@ -1,6 +1,7 @@
 package geo
 
 import (
+	"github.com/EngoEngine/math"
 	"github.com/engoengine/glm"
 	"math/rand"
 	"testing"
@@ -158,6 +159,127 @@ func BenchmarkIsConvexQuad(b *testing.B) {
 	}
 }
 
+func TestIntersectRaySphere(t *testing.T) {
+	t.Parallel()
+	s := Sphere{Center: glm.Vec3{0, 0, -10}, Radius: 2, Radius2: 4}
+	tests := []struct {
+		p, d    glm.Vec3
+		t       float32
+		overlap bool
+	}{
+		{glm.Vec3{0, 0, 0}, glm.Vec3{0, 0, -1}, 8, true},
+		{glm.Vec3{0, 1, 0}, glm.Vec3{0, 0, -1}, 10 - math.Sqrt(3), true},
+		{glm.Vec3{0, 0, -10}, glm.Vec3{1, 0, 0}, 0, true},
+		{glm.Vec3{0, 0, 0}, glm.Vec3{0, 0, 1}, 0, false},
+		{glm.Vec3{0, 3, 0}, glm.Vec3{0, 0, -1}, 0, false},
+	}
+	for i, test := range tests {
+		d, q, overlap := IntersectRaySphere(&test.p, &test.d, &s)
+		if overlap != test.overlap || overlap && math.Abs(d-test.t) > 1e-5 {
+			t.Errorf("[%d] IntersectRaySphere = %f, %t, want %f, %t", i, d, overlap, test.t, test.overlap)
+			continue
+		}
+		if want := test.p.Add(&glm.Vec3{test.d[0] * d, test.d[1] * d, test.d[2] * d}); overlap && q != want {
+			t.Errorf("[%d] q = %v, want %v", i, q, want)
+		}
+	}
+}
+
+func TestIntersectRayAABB(t *testing.T) {
+	t.Parallel()
+	a := AABB{Center: glm.Vec3{5, 0, 0}, HalfExtend: glm.Vec3{1, 2, 3}}
+	tests := []struct {
+		p, d    glm.Vec3
+		t       float32
+		overlap bool
+	}{
+		{glm.Vec3{0, 0, 0}, glm.Vec3{1, 0, 0}, 4, true},
+		{glm.Vec3{5, 10, 0}, glm.Vec3{0, -1, 0}, 8, true},
+		{glm.Vec3{5, 0, 0}, glm.Vec3{0, 0, 1}, 0, true},
+		{glm.Vec3{0, 0, 0}, glm.Vec3{-1, 0, 0}, 0, false},
+		{glm.Vec3{0, 0, 0}, glm.Vec3{0.6, 0.8, 0}, 0, false},
+		{glm.Vec3{0, 3, 0}, glm.Vec3{1, 0, 0}, 0, false},
+	}
+	for i, test := range tests {
+		d, _, overlap := IntersectRayAABB(&test.p, &test.d, &a)
+		if overlap != test.overlap || overlap && math.Abs(d-test.t) > 1e-5 {
+			t.Errorf("[%d] IntersectRayAABB = %f, %t, want %f, %t", i, d, overlap, test.t, test.overlap)
+		}
+	}
+}
+
+func TestIntersectRayTriangle(t *testing.T) {
+	t.Parallel()
+	a, b, c := glm.Vec3{0, 0, -5}, glm.Vec3{2, 0, -5}, glm.Vec3{0, 2, -5}
+	tests := []struct {
+		p, d    glm.Vec3
+		t       float32
+		overlap bool
+	}{
+		{glm.Vec3{0.5, 0.5, 0}, glm.Vec3{0, 0, -1}, 5, true},
+		// From the back side.
+		{glm.Vec3{0.5, 0.5, -10}, glm.Vec3{0, 0, 1}, 5, true},
+		{glm.Vec3{1.5, 1.5, 0}, glm.Vec3{0, 0, -1}, 0, false},
+		{glm.Vec3{0.5, 0.5, 0}, glm.Vec3{0, 0, 1}, 0, false},
+		{glm.Vec3{0.5, 0.5, 0}, glm.Vec3{1, 0, 0}, 0, false},
+	}
+	for i, test := range tests {
+		u, v, w, d, overlap := IntersectRayTriangle(&test.p, &test.d, &a, &b, &c)
+		if overlap != test.overlap || overlap && math.Abs(d-test.t) > 1e-5 {
+			t.Errorf("[%d] IntersectRayTriangle = %f, %t, want %f, %t", i, d, overlap, test.t, test.overlap)
+			continue
+		}
+		if !overlap {
+			continue
+		}
+		q := a.Mul(u)
+		q.AddScaledVec(v, &b)
+		q.AddScaledVec(w, &c)
+		want := test.p.Add(&glm.Vec3{0, 0, test.d[2] * d})
+		if diff := q.Sub(&want); diff.Len() > 1e-5 {
+			t.Errorf("[%d] u*a+v*b+w*c = %v, want %v", i, q, want)
+		}
+	}
+
+	// Small triangles are hit as well as big ones.
+	a, b, c = glm.Vec3{0, 0, -5}, glm.Vec3{3e-4, 0, -5}, glm.Vec3{0, 3e-4, -5}
+	p, d := glm.Vec3{1e-4, 1e-4, 0}, glm.Vec3{0, 0, -1}
+	if _, _, _, got, overlap := IntersectRayTriangle(&p, &d, &a, &b, &c); !overlap || math.Abs(got-5) > 1e-5 {
+		t.Errorf("small triangle IntersectRayTriangle = %f, %t, want 5, true", got, overlap)
+	}
+	d = glm.Vec3{1, 0, 0}
+	if _, _, _, _, overlap := IntersectRayTriangle(&p, &d, &a, &b, &c); overlap {
+		t.Errorf("small triangle parallel IntersectRayTriangle = true")
+	}
+}
+
+func TestIntersectUnProjectRay(t *testing.T) {
+	t.Parallel()
+	view := glm.LookAtV(&glm.Vec3{0, 0, 10}, &glm.Vec3{}, &glm.Vec3{0, 1, 0})
+	projs := []glm.Mat4{
+		glm.Perspective(math.Pi/4, 1, 0.1, 100),
+		glm.Ortho(-5, 5, -5, 5, 0.1, 100),
+	}
+	s := Sphere{Radius: 1, Radius2: 1}
+	a := AABB{HalfExtend: glm.Vec3{1, 1, 1}}
+	for i, proj := range projs {
+		// The center of the screen hits the front of the shapes at 9 units
+		// from the camera.
+		r := glm.UnProjectRay(50, 50, &view, &proj, 0, 0, 100, 100)
+		want := 9 - (10 - r.Origin[2])
+		if d, _, ok := IntersectRaySphere(&r.Origin, &r.Direction, &s); !ok || math.Abs(d-want) > 1e-4 {
+			t.Errorf("[%d] sphere hit = %f, %t, want %f", i, d, ok, want)
+		}
+		if d, _, ok := IntersectRayAABB(&r.Origin, &r.Direction, &a); !ok || math.Abs(d-want) > 1e-4 {
+			t.Errorf("[%d] AABB hit = %f, %t, want %f", i, d, ok, want)
+		}
+		r = glm.UnProjectRay(1, 99, &view, &proj, 0, 0, 100, 100)
+		if _, _, ok := IntersectRaySphere(&r.Origin, &r.Direction, &s); ok {
+			t.Errorf("[%d] corner ray %v hits the sphere", i, r)
+		}
+	}
+}
+
 func BenchmarkExtremePointsAlongDirection1000(b *testing.B) {
 	r := rand.New(rand.NewSource(999))
 	dir := glm.Vec3{1, 0, 0}
@@ -0,0 +1,114 @@
+// Package geo2d is the 2D counterpart of geo. It has bounding volumes,
+// overlap tests, closest point queries and raycasts for glm.Vec2 shapes, plus
+// polygon triangulation, boolean operations, convex decomposition and
+// offsetting.
+//
+// Polygons are counter-clockwise unless stated otherwise and the overlap tests
+// between polygons assume they are convex.
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	// Center represents the center of the bounding box.
+	Center glm.Vec2
+
+	// HalfExtend represents the 2 half extends of the bounding box.
+	HalfExtend glm.Vec2
+}
+
+// AABBFromPoints returns the smallest AABB containing all the points.
+func AABBFromPoints(points []glm.Vec2) AABB {
+	if len(points) == 0 {
+		return AABB{}
+	}
+	min, max := points[0], points[0]
+	for _, p := range points[1:] {
+		min[0], max[0] = math.Min(min[0], p[0]), math.Max(max[0], p[0])
+		min[1], max[1] = math.Min(min[1], p[1]), math.Max(max[1], p[1])
+	}
+	return AABB{
+		Center:     glm.Vec2{(min[0] + max[0]) * 0.5, (min[1] + max[1]) * 0.5},
+		HalfExtend: glm.Vec2{(max[0] - min[0]) * 0.5, (max[1] - min[1]) * 0.5},
+	}
+}
+
+// Polygon returns the corners of the AABB as a counter-clockwise polygon.
+func (a *AABB) Polygon() Polygon {
+	x0, y0 := a.Center[0]-a.HalfExtend[0], a.Center[1]-a.HalfExtend[1]
+	x1, y1 := a.Center[0]+a.HalfExtend[0], a.Center[1]+a.HalfExtend[1]
+	return Polygon{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+}
+
+// TestAABBAABB returns true if these AABB overlap.
+func TestAABBAABB(a, b *AABB) bool {
+	return math.Abs(a.Center[0]-b.Center[0]) <= a.HalfExtend[0]+b.HalfExtend[0] &&
+		math.Abs(a.Center[1]-b.Center[1]) <= a.HalfExtend[1]+b.HalfExtend[1]
+}
+
+// ClosestPointAABBPoint returns the point in or on the AABB closest to p.
+func ClosestPointAABBPoint(a *AABB, p *glm.Vec2) glm.Vec2 {
+	return glm.Vec2{
+		math.Clamp(p[0], a.Center[0]-a.HalfExtend[0], a.Center[0]+a.HalfExtend[0]),
+		math.Clamp(p[1], a.Center[1]-a.HalfExtend[1], a.Center[1]+a.HalfExtend[1]),
+	}
+}
+
+// SqDistAABBPoint returns the square distance of p to the AABB.
+func SqDistAABBPoint(a *AABB, p *glm.Vec2) float32 {
+	var sqDist float32
+	for i := 0; i < 2; i++ {
+		// Count any excess distance outside box extents.
+		if d := math.Abs(p[i]-a.Center[i]) - a.HalfExtend[i]; d > 0 {
+			sqDist += d * d
+		}
+	}
+	return sqDist
+}
+
+// IntersectRayAABB intersects the ray r with the AABB a. When intersecting it
+// returns the intersection distance t and the point q of intersection, t is 0
+// if the ray starts inside a.
+func IntersectRayAABB(r *Ray, a *AABB) (t float32, q glm.Vec2, overlap bool) {
+	t, overlap = raySlabs(&r.Origin, &r.Direction, &a.Center, &a.HalfExtend)
+	if overlap {
+		q = r.At(t)
+	}
+	return
+}
+
+// raySlabs intersects the ray p + t*d with the box centered on c with half
+// extends h, returning the entry t.
+func raySlabs(p, d, c, h *glm.Vec2) (t float32, overlap bool) {
+	const epsilon = 0.00001
+	tmax := float32(math.MaxFloat32)
+	for i := 0; i < 2; i++ {
+		if math.Abs(d[i]) < epsilon {
+			// Ray is parallel to slab. No hit if origin not within slab
+			if p[i] < c[i]-h[i] || p[i] > c[i]+h[i] {
+				return 0, false
+			}
+			continue
+		}
+		ood := 1 / d[i]
+		t1 := (c[i] - h[i] - p[i]) * ood
+		t2 := (c[i] + h[i] - p[i]) * ood
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > t {
+			t = t1
+		}
+		if t2 < tmax {
+			tmax = t2
+		}
+		if t > tmax {
+			return 0, false
+		}
+	}
+	return t, true
+}
@@ -0,0 +1,488 @@
+package geo2d
+
+import (
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// Region is a polygon with holes. Outer is counter-clockwise and the holes are
+// clockwise, so the interior is always on the left of the edges.
+type Region struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// Area returns the area of r, the holes excluded.
+func (r *Region) Area() float32 {
+	a := r.Outer.Area()
+	for _, h := range r.Holes {
+		a -= h.Area()
+	}
+	return a
+}
+
+// Contains returns true if p is inside r and not inside one of its holes.
+func (r *Region) Contains(p *glm.Vec2) bool {
+	if !r.Outer.Contains(p) {
+		return false
+	}
+	for _, h := range r.Holes {
+		if h.Contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the regions covered by a or b. a and b must be simple, the
+// windings don't matter.
+func Union(a, b Polygon) []Region {
+	return clip(a, b, true, true)
+}
+
+// Intersection returns the regions covered by both a and b. a and b must be
+// simple, the windings don't matter.
+func Intersection(a, b Polygon) []Region {
+	return clip(a, b, false, false)
+}
+
+// Difference returns the regions covered by a but not by b. a and b must be
+// simple, the windings don't matter.
+func Difference(a, b Polygon) []Region {
+	return clip(a, b, true, false)
+}
+
+// ghVertex is a vertex of the doubly linked lists used by the Greiner-Hormann
+// algorithm. Vertices on the boundary of the other polygon are intersections,
+// inserted ones aren't vertices of the polygon and crossings are the
+// intersections where the boundaries cross.
+type ghVertex struct {
+	p                    glm.Vec2
+	next, prev, neighbor *ghVertex
+	alpha                float64
+	intersect, inserted  bool
+	crossing, entry      bool
+	visited              bool
+}
+
+// ghList returns the circular list of the vertices of p, counter-clockwise.
+func ghList(p Polygon) *ghVertex {
+	ccw := p.SignedArea() > 0
+	var first, last *ghVertex
+	for i := range p {
+		j := i
+		if !ccw {
+			j = len(p) - 1 - i
+		}
+		v := &ghVertex{p: p[j]}
+		if first == nil {
+			first = v
+		} else {
+			last.next, v.prev = v, last
+		}
+		last = v
+	}
+	last.next, first.prev = first, last
+	return first
+}
+
+// nextOriginal returns the first vertex after v that isn't inserted.
+func (v *ghVertex) nextOriginal() *ghVertex {
+	v = v.next
+	for v.inserted {
+		v = v.next
+	}
+	return v
+}
+
+// prevOriginal returns the first vertex before v that isn't inserted.
+func (v *ghVertex) prevOriginal() *ghVertex {
+	v = v.prev
+	for v.inserted {
+		v = v.prev
+	}
+	return v
+}
+
+// insert inserts the intersection v after start, sorted by alpha.
+func (v *ghVertex) insert(start *ghVertex) {
+	cur := start.next
+	for cur.inserted && cur.alpha < v.alpha {
+		cur = cur.next
+	}
+	v.next, v.prev = cur, cur.prev
+	cur.prev.next = v
+	cur.prev = v
+}
+
+// ghSplit inserts a vertex at p in the edge starting at s, alpha along it.
+func ghSplit(s *ghVertex, alpha float64, p glm.Vec2) *ghVertex {
+	v := &ghVertex{p: p, alpha: alpha, inserted: true}
+	v.insert(s)
+	return v
+}
+
+// ghLink makes u and v the same intersection.
+func ghLink(u, v *ghVertex) {
+	u.intersect, v.intersect = true, true
+	u.neighbor, v.neighbor = v, u
+}
+
+// ghParam returns the position of p along ab, 0 at a and 1 at b.
+func ghParam(p, a, b *glm.Vec2) float64 {
+	dx, dy := float64(b[0])-float64(a[0]), float64(b[1])-float64(a[1])
+	px, py := float64(p[0])-float64(a[0]), float64(p[1])-float64(a[1])
+	return (px*dx + py*dy) / (dx*dx + dy*dy)
+}
+
+// ghBetween returns true if p, collinear with a and b, is strictly between
+// them.
+func ghBetween(p, a, b *glm.Vec2) bool {
+	t := ghParam(p, a, b)
+	return t > 0 && t < 1 && *p != *a && *p != *b
+}
+
+// ghIntersect adds the intersection of the edges s s2 and c c2. Vertices on
+// the other edge become intersections, inserted in it unless they're on its
+// vertex, so overlapping edges are split at the same points. Only s and c are
+// tested, s2 and c2 are the start of the next edges.
+func ghIntersect(s, s2, c, c2 *ghVertex) {
+	o1, o2 := robust.Orient2D(&c.p, &c2.p, &s.p), robust.Orient2D(&c.p, &c2.p, &s2.p)
+	if o1 == 0 && o2 == 0 {
+		// Collinear, overlapping if the start of one is on the other.
+		if s.p == c.p {
+			ghLink(s, c)
+			return
+		}
+		if ghBetween(&c.p, &s.p, &s2.p) {
+			ghLink(ghSplit(s, ghParam(&c.p, &s.p, &s2.p), c.p), c)
+		}
+		if ghBetween(&s.p, &c.p, &c2.p) {
+			ghLink(s, ghSplit(c, ghParam(&s.p, &c.p, &c2.p), s.p))
+		}
+		return
+	}
+
+	o3, o4 := robust.Orient2D(&s.p, &s2.p, &c.p), robust.Orient2D(&s.p, &s2.p, &c2.p)
+	if o1 > 0 && o2 > 0 || o1 < 0 && o2 < 0 || o3 > 0 && o4 > 0 || o3 < 0 && o4 < 0 || o2 == 0 || o4 == 0 {
+		// Apart or meeting at s2 or c2.
+		return
+	}
+	switch {
+	case o1 == 0 && o3 == 0:
+		ghLink(s, c)
+	case o1 == 0:
+		ghLink(s, ghSplit(c, o3/(o3-o4), s.p))
+	case o3 == 0:
+		ghLink(ghSplit(s, o1/(o1-o2), c.p), c)
+	default:
+		alpha := o1 / (o1 - o2)
+		p := s.p
+		d := s2.p.Sub(&s.p)
+		p.AddScaledVec(float32(alpha), &d)
+		i1, i2 := ghSplit(s, alpha, p), ghSplit(c, o3/(o3-o4), p)
+		ghLink(i1, i2)
+		i1.crossing, i2.crossing = true, true
+	}
+}
+
+// ghIntersections inserts the intersections of the lists a and b in both
+// lists.
+func ghIntersections(a, b *ghVertex) {
+	for s := a; ; {
+		s2 := s.nextOriginal()
+		for c := b; ; {
+			c2 := c.nextOriginal()
+			ghIntersect(s, s2, c, c2)
+			if c = c2; c == b {
+				break
+			}
+		}
+		if s = s2; s == a {
+			break
+		}
+	}
+}
+
+// ghOverlap returns true if the edge from u to the next vertex v is also an
+// edge of the other polygon.
+func ghOverlap(u, v *ghVertex) bool {
+	return u.intersect && v.intersect && (u.neighbor.next == v.neighbor || u.neighbor.prev == v.neighbor)
+}
+
+// ghLeft returns true if q is on the left of the chain a, b, c.
+func ghLeft(q, a, b, c *glm.Vec2) bool {
+	s1, s2 := robust.Orient2D(a, b, q), robust.Orient2D(b, c, q)
+	if robust.Orient2D(a, b, c) > 0 {
+		return s1 > 0 && s2 > 0
+	}
+	return s1 > 0 || s2 > 0
+}
+
+// ghLabel finds the crossings among the intersections of list that are on a
+// vertex, where the other polygon goes from one side of list to the other.
+// Where the edges overlap, the other polygon is moved a little to its right if
+// grow is true and to its left otherwise, so the overlapping edges are either
+// in the result once or not at all.
+//
+// See Foster, Hormann, Popa "Clipping simple polygons with degenerate
+// intersections".
+func ghLabel(list *ghVertex, grow bool) {
+	for v := list; ; {
+		if v.intersect && !v.crossing {
+			w := v.neighbor
+			prev, next := v.prevOriginal(), v.nextOriginal()
+			left := func(u *ghVertex) bool {
+				return ghLeft(&u.p, &prev.p, &v.p, &next.p)
+			}
+			onPrev, onNext := ghOverlap(v.prev, v), ghOverlap(v, v.next)
+			switch {
+			case !onPrev && !onNext:
+				v.crossing = left(w.prevOriginal()) != left(w.nextOriginal())
+			case onPrev != onNext:
+				// The other polygon leaves the overlap through free, it
+				// crosses if it's on the other side than the moved overlap.
+				var same bool
+				var free *ghVertex
+				if onNext {
+					same = w.next == v.next.neighbor
+					free = w.nextOriginal()
+					if same {
+						free = w.prevOriginal()
+					}
+				} else {
+					same = w.prev == v.prev.neighbor
+					free = w.prevOriginal()
+					if same {
+						free = w.nextOriginal()
+					}
+				}
+				right := grow == same
+				v.crossing = left(free) == right
+			}
+			w.crossing = v.crossing
+		}
+		if v = v.next; v == list {
+			break
+		}
+	}
+}
+
+// ghStatus returns a vertex of list and whether the edge after it is inside
+// other. moved is true if list is the polygon moved by ghLabel.
+func ghStatus(list *ghVertex, other Polygon, grow, moved bool) (*ghVertex, bool) {
+	for v := list; ; {
+		if !v.intersect {
+			return v, other.Contains(&v.p)
+		}
+		if v = v.next; v == list {
+			break
+		}
+	}
+	if ghOverlap(list, list.next) {
+		if !moved {
+			return list, grow
+		}
+		same := list.neighbor.next == list.next.neighbor
+		return list, grow != same
+	}
+	mid := list.p.Add(&list.next.p)
+	mid.MulWith(0.5)
+	return list, other.Contains(&mid)
+}
+
+// ghMark sets the entry flags of the crossings of list, true when going
+// forward enters other. If flip is true the flags are inverted to trace the
+// outside of other instead.
+func ghMark(list *ghVertex, other Polygon, flip, grow, moved bool) {
+	start, inside := ghStatus(list, other, grow, moved)
+	inside = inside != flip
+	for v := start.next; ; v = v.next {
+		if v.crossing {
+			v.entry = !inside
+			inside = !inside
+		}
+		if v == start {
+			break
+		}
+	}
+}
+
+// ghTrace returns the polygons traced by following the crossings of list.
+func ghTrace(list *ghVertex) []Polygon {
+	var rings []Polygon
+	for start := list; ; {
+		if start.crossing && !start.visited {
+			ring := Polygon{start.p}
+			cur := start
+			for {
+				cur.visited, cur.neighbor.visited = true, true
+				forward := cur.entry
+				for {
+					if forward {
+						cur = cur.next
+					} else {
+						cur = cur.prev
+					}
+					if cur.crossing {
+						break
+					}
+					ring = append(ring, cur.p)
+				}
+				if cur == start || cur.neighbor == start {
+					break
+				}
+				ring = append(ring, cur.p)
+				cur = cur.neighbor
+			}
+			if len(ring) >= 3 {
+				rings = append(rings, ring)
+			}
+		}
+		if start = start.next; start == list {
+			break
+		}
+	}
+	return rings
+}
+
+// clip computes a boolean operation between a and b with the Greiner-Hormann
+// algorithm. flipA and flipB select the outside of a and b instead of the
+// inside.
+//
+// See Greiner, Hormann "Efficient Clipping of Arbitrary Polygons". Degenerate
+// cases, where a vertex is on the other polygon's boundary, are handled as
+// by Foster, Hormann and Popa, the vertices of the result are the vertices of
+// a and b and the crossings of their edges.
+func clip(a, b Polygon, flipA, flipB bool) []Region {
+	if len(a) < 3 || len(b) < 3 {
+		// One of the polygons is empty, a is kept by union and difference and
+		// b only by union.
+		var rings []Polygon
+		if len(a) >= 3 && flipA {
+			rings = append(rings, append(Polygon(nil), a...))
+		}
+		if len(b) >= 3 && flipA && flipB {
+			rings = append(rings, append(Polygon(nil), b...))
+		}
+		return regions(rings)
+	}
+
+	// Overlapping edges are in the union and the difference when b grows a
+	// little and in the intersection when it shrinks, so polygons touching
+	// along an edge merge or cut cleanly instead of leaving slivers.
+	grow := flipA
+	la, lb := ghList(a), ghList(b)
+	ghIntersections(la, lb)
+	ghLabel(la, grow)
+
+	crossings := false
+	for v := la; ; {
+		crossings = crossings || v.crossing
+		if v = v.next; v == la {
+			break
+		}
+	}
+	if !crossings {
+		// The polygons are either nested or disjoint.
+		_, aInB := ghStatus(la, b, grow, false)
+		_, bInA := ghStatus(lb, a, grow, true)
+		pa, pb := append(Polygon(nil), a...), append(Polygon(nil), b...)
+		switch {
+		case !flipA && !flipB: // intersection
+			if aInB {
+				return regions([]Polygon{pa})
+			} else if bInA {
+				return regions([]Polygon{pb})
+			}
+			return nil
+		case flipA && flipB: // union
+			if aInB {
+				return regions([]Polygon{pb})
+			} else if bInA {
+				return regions([]Polygon{pa})
+			}
+			return regions([]Polygon{pa, pb})
+		default: // difference
+			if aInB {
+				return nil
+			} else if bInA {
+				return regions([]Polygon{pa, pb})
+			}
+			return regions([]Polygon{pa})
+		}
+	}
+
+	ghMark(la, b, flipA, grow, false)
+	ghMark(lb, a, flipB, grow, true)
+	return regions(ghTrace(la))
+}
+
+// onBoundary returns true if q is exactly on an edge of p.
+func onBoundary(p Polygon, q *glm.Vec2) bool {
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		if *q == p[i] || robust.Orient2D(&p[j], &p[i], q) == 0 && ghBetween(q, &p[j], &p[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ringInside returns true if the ring r, which may touch other but doesn't
+// cross it, is inside other. It's tested at a vertex of r or the middle of an
+// edge that isn't on the boundary of other.
+func ringInside(r, other Polygon) bool {
+	for i := range r {
+		if !onBoundary(other, &r[i]) {
+			return other.Contains(&r[i])
+		}
+	}
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		mid := r[i].Add(&r[j])
+		mid.MulWith(0.5)
+		if !onBoundary(other, &mid) {
+			return other.Contains(&mid)
+		}
+	}
+	return false
+}
+
+// regions sorts the rings into regions. Rings inside an odd number of other
+// rings are holes, they are added to the smallest ring containing them.
+func regions(rings []Polygon) []Region {
+	depth := make([]int, len(rings))
+	for i := range rings {
+		for j := range rings {
+			if i != j && ringInside(rings[i], rings[j]) {
+				depth[i]++
+			}
+		}
+	}
+
+	var out []Region
+	index := make([]int, len(rings))
+	for i, r := range rings {
+		if depth[i]%2 == 0 {
+			if r.SignedArea() < 0 {
+				r.Reverse()
+			}
+			index[i] = len(out)
+			out = append(out, Region{Outer: r})
+		}
+	}
+	for i, r := range rings {
+		if depth[i]%2 == 0 {
+			continue
+		}
+		if r.SignedArea() > 0 {
+			r.Reverse()
+		}
+		for j := range rings {
+			if depth[j] == depth[i]-1 && ringInside(r, rings[j]) {
+				out[index[j]].Holes = append(out[index[j]].Holes, r)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,136 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestBooleans(t *testing.T) {
+	t.Parallel()
+	square := func(x, y, s float32) Polygon {
+		return Polygon{{x, y}, {x + s, y}, {x + s, y + s}, {x, y + s}}
+	}
+	u := Polygon{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}
+	bar := Polygon{{-1, 2}, {4, 2}, {4, 4}, {-1, 4}}
+	tests := []struct {
+		name    string
+		got     []Region
+		regions int
+		holes   int
+		area    float32
+	}{
+		{"union", Union(square(0, 0, 2), square(1, 1, 2)), 1, 0, 7},
+		{"intersection", Intersection(square(0, 0, 2), square(1, 1, 2)), 1, 0, 1},
+		{"difference", Difference(square(0, 0, 2), square(1, 1, 2)), 1, 0, 3},
+		{"difference clockwise", Difference(square(0, 0, 2), Polygon{{1, 1}, {1, 3}, {3, 3}, {3, 1}}), 1, 0, 3},
+		{"nested union", Union(square(0, 0, 4), square(1, 1, 1)), 1, 0, 16},
+		{"nested intersection", Intersection(square(0, 0, 4), square(1, 1, 1)), 1, 0, 1},
+		{"nested difference", Difference(square(0, 0, 4), square(1, 1, 1)), 1, 1, 15},
+		{"inverse nested difference", Difference(square(1, 1, 1), square(0, 0, 4)), 0, 0, 0},
+		{"disjoint union", Union(square(0, 0, 1), square(2, 2, 1)), 2, 0, 2},
+		{"disjoint intersection", Intersection(square(0, 0, 1), square(2, 2, 1)), 0, 0, 0},
+		{"union with hole", Union(u, bar), 1, 1, 15},
+		{"intersection of U", Intersection(u, bar), 2, 0, 2},
+		{"difference of U", Difference(u, bar), 1, 0, 5},
+		{"shared edge", Union(square(0, 0, 2), Polygon{{2, 0}, {3, 0}, {3, 2}, {2, 2}}), 1, 0, 6},
+		{"shared edge intersection", Intersection(square(0, 0, 2), Polygon{{2, 0}, {3, 0}, {3, 2}, {2, 2}}), 0, 0, 0},
+		{"shared edge difference", Difference(square(0, 0, 2), Polygon{{1, 0}, {3, 0}, {3, 2}, {1, 2}}), 1, 0, 2},
+		{"shared vertex", Intersection(square(0, 0, 2), Polygon{{1, 1}, {2, 0}, {3, 1}, {2, 2}}), 1, 0, 1},
+		{"same union", Union(square(0, 0, 2), square(0, 0, 2)), 1, 0, 4},
+		{"same intersection", Intersection(square(0, 0, 2), square(0, 0, 2)), 1, 0, 4},
+		{"same difference", Difference(square(0, 0, 2), square(0, 0, 2)), 0, 0, 0},
+		{"corner union", Union(square(0, 0, 4), square(0, 0, 2)), 1, 0, 16},
+		{"corner intersection", Intersection(square(0, 0, 4), square(0, 0, 2)), 1, 0, 4},
+		{"corner difference", Difference(square(0, 0, 4), square(0, 0, 2)), 1, 0, 12},
+		{"partial edge union", Union(square(0, 0, 2), square(2, 1, 2)), 1, 0, 8},
+		{"partial edge intersection", Intersection(square(0, 0, 2), square(2, 1, 2)), 0, 0, 0},
+		{"partial edge difference", Difference(square(0, 0, 2), square(2, 1, 2)), 1, 0, 4},
+		{"touching union", Union(square(0, 0, 2), Polygon{{2, 1}, {3, 0}, {3, 2}}), 2, 0, 5},
+		{"touching intersection", Intersection(square(0, 0, 2), Polygon{{2, 1}, {3, 0}, {3, 2}}), 0, 0, 0},
+		{"U on edge union", Union(u, Polygon{{-1, 1}, {4, 1}, {4, 4}, {-1, 4}}), 1, 0, 18},
+		{"U on edge intersection", Intersection(u, Polygon{{-1, 1}, {4, 1}, {4, 4}, {-1, 4}}), 2, 0, 4},
+		{"U on edge difference", Difference(u, Polygon{{-1, 1}, {4, 1}, {4, 4}, {-1, 4}}), 1, 0, 3},
+	}
+	for _, test := range tests {
+		if len(test.got) != test.regions {
+			t.Errorf("%s: %d regions, want %d", test.name, len(test.got), test.regions)
+			continue
+		}
+		var area float32
+		holes := 0
+		for _, r := range test.got {
+			if r.Outer.SignedArea() <= 0 {
+				t.Errorf("%s: outer polygon %v isn't counter-clockwise", test.name, r.Outer)
+			}
+			for _, h := range r.Holes {
+				if h.SignedArea() >= 0 {
+					t.Errorf("%s: hole %v isn't clockwise", test.name, h)
+				}
+			}
+			holes += len(r.Holes)
+			area += r.Area()
+		}
+		if holes != test.holes {
+			t.Errorf("%s: %d holes, want %d", test.name, holes, test.holes)
+		}
+		if math.Abs(area-test.area) > 1e-5 {
+			t.Errorf("%s: area = %f, want %f", test.name, area, test.area)
+		}
+	}
+}
+
+func TestBooleans_Degenerate(t *testing.T) {
+	t.Parallel()
+	// Without crossing edges the result is made of the vertices of a and b.
+	a := Polygon{{0, 0}, {0.3, 0}, {0.3, 0.7}, {0, 0.7}}
+	b := Polygon{{0.3, 0.1}, {0.9, 0.1}, {0.9, 0.7}, {0.3, 0.7}, {0.1, 0.4}}
+	for _, op := range []func(a, b Polygon) []Region{Union, Intersection, Difference} {
+		for _, r := range op(a, b) {
+			for _, p := range append([]Polygon{r.Outer}, r.Holes...) {
+				for _, v := range p {
+					found := false
+					for _, w := range append(append(Polygon(nil), a...), b...) {
+						found = found || v == w
+					}
+					if !found {
+						t.Errorf("%v isn't a vertex of a or b", v)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestOffsetPolygon(t *testing.T) {
+	t.Parallel()
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	tests := []struct {
+		name string
+		p    Polygon
+		area float32
+		tol  float32
+	}{
+		{"miter", OffsetPolygon(square, 1, JoinMiter, 2), 16, 1e-4},
+		{"miter limit", OffsetPolygon(square, 1, JoinMiter, 1.2), 16 - 4*0.5, 1e-4},
+		{"bevel", OffsetPolygon(square, 1, JoinBevel, 2), 16 - 4*0.5, 1e-4},
+		{"round", OffsetPolygon(square, 1, JoinRound, 2), 4 + 8 + math.Pi, 0.05},
+		{"inwards", OffsetPolygon(square, -0.5, JoinRound, 2), 1, 1e-4},
+		{"clockwise", OffsetPolygon(Polygon{{0, 0}, {0, 2}, {2, 2}, {2, 0}}, 1, JoinMiter, 2), 16, 1e-4},
+	}
+	for _, test := range tests {
+		if a := test.p.Area(); math.Abs(a-test.area) > test.tol {
+			t.Errorf("%s: area = %f, want %f", test.name, a, test.area)
+		}
+	}
+	if tests[5].p.SignedArea() > 0 {
+		t.Errorf("OffsetPolygon() changed the winding")
+	}
+
+	// A concave corner is joined by the intersection of the offset edges.
+	l := Polygon{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}
+	o := OffsetPolygon(l, 0.5, JoinMiter, 2)
+	if want := (glm.Vec2{1.5, 1.5}); o[3] != want {
+		t.Errorf("OffsetPolygon() concave corner = %v, want %v", o[3], want)
+	}
+}
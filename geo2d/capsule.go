@@ -0,0 +1,72 @@
+package geo2d
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Capsule is a rectangle with round ends, or a swept circle.
+type Capsule struct {
+	A, B   glm.Vec2
+	Radius float32
+}
+
+// TestCapsuleCapsule returns true if these capsules overlap.
+func TestCapsuleCapsule(a, b *Capsule) bool {
+	_, _, u, _, _ := ClosestPointSegmentSegment(&Segment{a.A, a.B}, &Segment{b.A, b.B})
+	r := a.Radius + b.Radius
+	return u <= r*r
+}
+
+// TestCapsuleCircle returns true if the capsule and the circle overlap.
+func TestCapsuleCircle(c *Capsule, s *Circle) bool {
+	r := c.Radius + s.Radius
+	return SqDistSegmentPoint(&Segment{c.A, c.B}, &s.Center) <= r*r
+}
+
+// ClosestPointCapsulePoint returns the point in or on the capsule closest to
+// p.
+func ClosestPointCapsulePoint(c *Capsule, p *glm.Vec2) glm.Vec2 {
+	_, q := ClosestPointSegmentPoint(&Segment{c.A, c.B}, p)
+	return ClosestPointCirclePoint(&Circle{q, c.Radius}, p)
+}
+
+// SqDistCapsulePoint returns the square distance of p to the capsule.
+func SqDistCapsulePoint(c *Capsule, p *glm.Vec2) float32 {
+	_, q := ClosestPointSegmentPoint(&Segment{c.A, c.B}, p)
+	return SqDistCirclePoint(&Circle{q, c.Radius}, p)
+}
+
+// IntersectRayCapsule intersects the ray r with the capsule c. When
+// intersecting it returns the intersection distance t and the point q of
+// intersection, t is 0 if the ray starts inside c.
+func IntersectRayCapsule(r *Ray, c *Capsule) (t float32, q glm.Vec2, overlap bool) {
+	if SqDistSegmentPoint(&Segment{c.A, c.B}, &r.Origin) <= c.Radius*c.Radius {
+		return 0, r.Origin, true
+	}
+
+	// The capsule is the union of the circles at its ends and of the
+	// rectangle between them, the first hit on any of them is the entry.
+	t, overlap = rayCircle(&r.Origin, &r.Direction, &c.A, c.Radius)
+	if t2, ok := rayCircle(&r.Origin, &r.Direction, &c.B, c.Radius); ok && (!overlap || t2 < t) {
+		t, overlap = t2, true
+	}
+
+	ab := c.B.Sub(&c.A)
+	if l := ab.Len(); l > 0 {
+		n := ab.Perp()
+		n.MulWith(c.Radius / l)
+		for _, side := range [2]float32{1, -1} {
+			s := Segment{c.A, c.B}
+			s.A.AddScaledVec(side, &n)
+			s.B.AddScaledVec(side, &n)
+			if ts, _, ok := IntersectRaySegment(r, &s); ok && (!overlap || ts < t) {
+				t, overlap = ts, true
+			}
+		}
+	}
+
+	if overlap {
+		q = r.At(t)
+	}
+	return
+}
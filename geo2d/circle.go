@@ -0,0 +1,142 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Circle is a bounding circle.
+type Circle struct {
+	Center glm.Vec2
+	Radius float32
+}
+
+// MergePoint updates the circle to encompass p if needed.
+func (c *Circle) MergePoint(p *glm.Vec2) {
+	d := p.Sub(&c.Center)
+	dist2 := d.Len2()
+	if dist2 > c.Radius*c.Radius {
+		dist := math.Sqrt(dist2)
+		newRadius := (c.Radius + dist) * 0.5
+		c.Center.AddScaledVec((newRadius-c.Radius)/dist, &d)
+		c.Radius = newRadius
+	}
+}
+
+// CircleFromPoints returns a circle bounding all the points, using Ritter's
+// algorithm. It's not the smallest one but it's usually within 5-20% of it.
+func CircleFromPoints(points []glm.Vec2) Circle {
+	if len(points) == 0 {
+		return Circle{}
+	}
+	// Start with the most separated pair of extreme points along x and y.
+	var minx, maxx, miny, maxy int
+	for i := range points {
+		if points[i][0] < points[minx][0] {
+			minx = i
+		}
+		if points[i][0] > points[maxx][0] {
+			maxx = i
+		}
+		if points[i][1] < points[miny][1] {
+			miny = i
+		}
+		if points[i][1] > points[maxy][1] {
+			maxy = i
+		}
+	}
+	a, b := points[minx], points[maxx]
+	dx, dy := b.Sub(&a), points[maxy].Sub(&points[miny])
+	if dy.Len2() > dx.Len2() {
+		a, b = points[miny], points[maxy]
+	}
+	d := b.Sub(&a)
+	c := Circle{Center: a.Add(&b), Radius: d.Len() * 0.5}
+	c.Center.MulWith(0.5)
+	for i := range points {
+		c.MergePoint(&points[i])
+	}
+	return c
+}
+
+// TestCircleCircle returns true if the circles overlap.
+func TestCircleCircle(a, b *Circle) bool {
+	d := b.Center.Sub(&a.Center)
+	r := a.Radius + b.Radius
+	return d.Len2() <= r*r
+}
+
+// TestCircleAABB returns true if the circle and the AABB overlap.
+func TestCircleAABB(c *Circle, a *AABB) bool {
+	return SqDistAABBPoint(a, &c.Center) <= c.Radius*c.Radius
+}
+
+// TestCircleOBB returns true if the circle and the OBB overlap.
+func TestCircleOBB(c *Circle, o *OBB) bool {
+	return SqDistOBBPoint(o, &c.Center) <= c.Radius*c.Radius
+}
+
+// TestCircleSegment returns true if the circle and the segment overlap.
+func TestCircleSegment(c *Circle, s *Segment) bool {
+	return SqDistSegmentPoint(s, &c.Center) <= c.Radius*c.Radius
+}
+
+// TestCirclePolygon returns true if the circle and the polygon overlap. The
+// polygon doesn't need to be convex.
+func TestCirclePolygon(c *Circle, p Polygon) bool {
+	return SqDistPolygonPoint(p, &c.Center) <= c.Radius*c.Radius
+}
+
+// ClosestPointCirclePoint returns the point in or on the circle closest to p.
+func ClosestPointCirclePoint(c *Circle, p *glm.Vec2) glm.Vec2 {
+	d := p.Sub(&c.Center)
+	l2 := d.Len2()
+	if l2 <= c.Radius*c.Radius {
+		return *p
+	}
+	q := c.Center
+	q.AddScaledVec(c.Radius/math.Sqrt(l2), &d)
+	return q
+}
+
+// SqDistCirclePoint returns the square distance of p to the circle.
+func SqDistCirclePoint(c *Circle, p *glm.Vec2) float32 {
+	d := p.Sub(&c.Center)
+	if dist := d.Len() - c.Radius; dist > 0 {
+		return dist * dist
+	}
+	return 0
+}
+
+// IntersectRayCircle intersects the ray r with the circle c. When intersecting
+// it returns the intersection distance t and the point q of intersection, t is
+// 0 if the ray starts inside c.
+func IntersectRayCircle(r *Ray, c *Circle) (t float32, q glm.Vec2, overlap bool) {
+	t, overlap = rayCircle(&r.Origin, &r.Direction, &c.Center, c.Radius)
+	if overlap {
+		q = r.At(t)
+	}
+	return
+}
+
+// rayCircle intersects the ray p + t*d with the circle of center c and radius
+// r.
+func rayCircle(p, d, c *glm.Vec2, r float32) (t float32, overlap bool) {
+	m := p.Sub(c)
+	a := d.Len2()
+	b := m.Dot(d)
+	k := m.Len2() - r*r
+	// Exit if the origin is outside the circle and the ray points away from it.
+	if k > 0 && b > 0 {
+		return 0, false
+	}
+	discr := b*b - a*k
+	if discr < 0 || a == 0 {
+		return 0, k <= 0
+	}
+	t = (-b - math.Sqrt(discr)) / a
+	if t < 0 {
+		t = 0
+	}
+	return t, true
+}
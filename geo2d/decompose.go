@@ -0,0 +1,91 @@
+package geo2d
+
+import (
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// ConvexDecomposition splits the polygon outer with the given holes into
+// convex polygons with the Hertel-Mehlhorn algorithm. The polygon is
+// triangulated and the diagonals that aren't needed for convexity are removed.
+// The result has at most 4 times as many pieces as the optimal decomposition.
+//
+// The pieces are counter-clockwise. The holes must be inside outer and must not
+// overlap each other, the windings don't matter.
+func ConvexDecomposition(outer Polygon, holes ...Polygon) []Polygon {
+	points := append([]glm.Vec2(nil), outer...)
+	for _, h := range holes {
+		points = append(points, h...)
+	}
+	tris := Triangulate(outer, holes...)
+	pieces := make([][]int, 0, len(tris)/3)
+	for i := 0; i < len(tris); i += 3 {
+		pieces = append(pieces, tris[i:i+3:i+3])
+	}
+
+	for merged := true; merged; {
+		merged = false
+		// The piece on the left of each edge.
+		owner := make(map[[2]int]int)
+		for i, piece := range pieces {
+			for k := range piece {
+				owner[[2]int{piece[k], piece[(k+1)%len(piece)]}] = i
+			}
+		}
+		for i := range pieces {
+			for k := 0; k < len(pieces[i]); k++ {
+				a, b := pieces[i][k], pieces[i][(k+1)%len(pieces[i])]
+				j, ok := owner[[2]int{b, a}]
+				if !ok || j == i || pieces[j] == nil {
+					continue
+				}
+				if m := mergePieces(points, pieces[i], k, pieces[j]); m != nil {
+					pieces[i], pieces[j] = m, nil
+					merged = true
+				}
+			}
+		}
+	}
+
+	var out []Polygon
+	for _, piece := range pieces {
+		if piece == nil {
+			continue
+		}
+		p := make(Polygon, len(piece))
+		for i, v := range piece {
+			p[i] = points[v]
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// mergePieces returns the union of the convex pieces p and q, which share the
+// edge starting at p[k], or nil if it isn't convex.
+func mergePieces(points []glm.Vec2, p []int, k int, q []int) []int {
+	np, nq := len(p), len(q)
+	a, b := p[k], p[(k+1)%np]
+	j := 0
+	for j < nq && !(q[j] == b && q[(j+1)%nq] == a) {
+		j++
+	}
+	if j == nq {
+		return nil
+	}
+
+	// Only the angles at the ends of the removed edge change.
+	if robust.Orient2D(&points[p[(k+np-1)%np]], &points[a], &points[q[(j+2)%nq]]) < 0 ||
+		robust.Orient2D(&points[q[(j+nq-1)%nq]], &points[b], &points[p[(k+2)%np]]) < 0 {
+		return nil
+	}
+
+	m := make([]int, 0, np+nq-2)
+	for i := 1; i <= np; i++ {
+		m = append(m, p[(k+i)%np])
+	}
+	for i := 2; i < nq; i++ {
+		m = append(m, q[(j+i)%nq])
+	}
+	return m
+}
@@ -0,0 +1,620 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// Triangulation is a triangle mesh over a set of points, stored as half-edges.
+// Half-edge e belongs to triangle e/3 and goes from Points[Triangles[e]] to
+// Points[Triangles[NextHalfedge(e)]].
+type Triangulation struct {
+	// Points are the vertices of the triangulation. Duplicate points are not
+	// used by any triangle.
+	Points []glm.Vec2
+	// Triangles are the indices in Points of the vertices of the triangles, 3
+	// per triangle in counter-clockwise order.
+	Triangles []int
+	// Halfedges are the indices of the opposite half-edges, -1 for the
+	// half-edges on the convex hull.
+	Halfedges []int
+	// Constrained tells for each half-edge if it's a constrained edge. It's
+	// nil for unconstrained triangulations.
+	Constrained []bool
+}
+
+// NextHalfedge returns the half-edge following e in its triangle.
+func NextHalfedge(e int) int {
+	if e%3 == 2 {
+		return e - 2
+	}
+	return e + 1
+}
+
+// PrevHalfedge returns the half-edge preceding e in its triangle.
+func PrevHalfedge(e int) int {
+	if e%3 == 0 {
+		return e + 2
+	}
+	return e - 1
+}
+
+// Delaunay returns the Delaunay triangulation of the points, no point is inside
+// the circumcircle of any triangle. The triangulation covers the convex hull
+// of the points, it has no triangles if they are all collinear.
+//
+// The points are inserted one by one, restoring the Delaunay property with
+// edge flips after each insertion. See Guibas, Stolfi "Primitives for the
+// manipulation of general subdivisions and the computation of Voronoi
+// diagrams".
+func Delaunay(points []glm.Vec2) *Triangulation {
+	m := newMesh(points)
+	return m.triangulation(false)
+}
+
+// ConstrainedDelaunay returns the constrained Delaunay triangulation of the
+// points, where the edges between the pairs of point indices in edges are
+// forced into the triangulation. Only the triangles whose circumcircle can see
+// another point through a constrained edge aren't Delaunay.
+//
+// Constrained edges must not cross each other, an edge passing exactly
+// through other points is split at them. An edge ending at a duplicate point
+// ends at the copy used by the triangles.
+func ConstrainedDelaunay(points []glm.Vec2, edges [][2]int) *Triangulation {
+	m := newMesh(points)
+	for _, e := range edges {
+		m.constrain(m.same[e[0]], m.same[e[1]])
+	}
+	return m.triangulation(true)
+}
+
+// mesh is the mutable triangulation used while building a Triangulation.
+// Edge i of triangle t goes from v[t][i] to v[t][(i+1)%3], adj[t][i] is the
+// triangle on the other side of it.
+type mesh struct {
+	points      []glm.Vec2
+	v, adj      [][3]int
+	constrained [][3]bool
+	dead        []bool
+	// vt is a triangle using each vertex.
+	vt []int
+	// same is the point kept for each point, an earlier one for duplicates.
+	same []int
+	last int
+}
+
+// newMesh returns the Delaunay triangulation of the points, without the
+// triangles of the enclosing triangle.
+func newMesh(points []glm.Vec2) *mesh {
+	n := len(points)
+	m := &mesh{points: make([]glm.Vec2, n, n+3), vt: make([]int, n+3), same: make([]int, n)}
+	copy(m.points, points)
+	for i := range m.vt {
+		m.vt[i] = -1
+	}
+	for i := range m.same {
+		m.same[i] = i
+	}
+	if n == 0 {
+		return m
+	}
+
+	// A triangle large enough that its vertices don't change the
+	// triangulation of the points, except maybe near the hull.
+	box := AABBFromPoints(points)
+	d := 2 * math.Max(box.HalfExtend[0], box.HalfExtend[1])
+	if d == 0 {
+		d = 1
+	}
+	c := box.Center
+	m.points = append(m.points,
+		glm.Vec2{c[0] - 100*d, c[1] - 100*d},
+		glm.Vec2{c[0] + 100*d, c[1] - 100*d},
+		glm.Vec2{c[0], c[1] + 100*d},
+	)
+	m.newTriangle([3]int{n, n + 1, n + 2}, [3]int{-1, -1, -1}, [3]bool{})
+
+	for i := 0; i < n; i++ {
+		m.insert(i)
+	}
+
+	// Remove the enclosing triangle.
+	for t := range m.v {
+		if !m.dead[t] && (m.v[t][0] >= n || m.v[t][1] >= n || m.v[t][2] >= n) {
+			m.kill(t)
+		}
+	}
+	m.points = m.points[:n]
+	m.vt = m.vt[:n]
+	for i := range m.vt {
+		m.vt[i] = -1
+	}
+	for t := range m.v {
+		if !m.dead[t] {
+			for _, a := range m.v[t] {
+				m.vt[a] = t
+			}
+		}
+	}
+	m.fillHull()
+	return m
+}
+
+// newTriangle adds a triangle and returns its index.
+func (m *mesh) newTriangle(v, adj [3]int, constrained [3]bool) int {
+	m.v = append(m.v, v)
+	m.adj = append(m.adj, adj)
+	m.constrained = append(m.constrained, constrained)
+	m.dead = append(m.dead, false)
+	t := len(m.v) - 1
+	m.set(t, v, adj, constrained)
+	return t
+}
+
+// set sets the vertices, neighbors and constraints of t and makes its
+// neighbors point back to it.
+func (m *mesh) set(t int, v, adj [3]int, constrained [3]bool) {
+	m.v[t], m.adj[t], m.constrained[t] = v, adj, constrained
+	for i := 0; i < 3; i++ {
+		m.vt[v[i]] = t
+		if n := adj[i]; n >= 0 {
+			// The neighbor has the same edge in the other direction.
+			a, b := v[i], v[(i+1)%3]
+			for j := 0; j < 3; j++ {
+				if m.v[n][j] == b && m.v[n][(j+1)%3] == a {
+					m.adj[n][j] = t
+				}
+			}
+		}
+	}
+	m.last = t
+}
+
+// kill removes t, its neighbors become hull edges. The vertices may still
+// refer to t.
+func (m *mesh) kill(t int) {
+	m.dead[t] = true
+	for i := 0; i < 3; i++ {
+		if n := m.adj[t][i]; n >= 0 {
+			for j := 0; j < 3; j++ {
+				if m.adj[n][j] == t {
+					m.adj[n][j] = -1
+				}
+			}
+		}
+	}
+}
+
+// locate returns the triangle containing p, walking from the last modified
+// triangle.
+func (m *mesh) locate(p *glm.Vec2) int {
+	t, r := m.last, 0
+	for steps := 0; steps < 4*len(m.v); steps++ {
+		moved := false
+		for k := 0; k < 3; k++ {
+			// Rotating the first edge tested avoids walking in circles.
+			i := (k + r) % 3
+			a, b := &m.points[m.v[t][i]], &m.points[m.v[t][(i+1)%3]]
+			if robust.Orient2D(a, b, p) < 0 && m.adj[t][i] >= 0 {
+				t, moved = m.adj[t][i], true
+				break
+			}
+		}
+		if !moved {
+			return t
+		}
+		r++
+	}
+	return t
+}
+
+// insert adds the point i to the triangulation.
+func (m *mesh) insert(i int) {
+	p := &m.points[i]
+	t := m.locate(p)
+
+	// Find if p is on an edge or on a vertex.
+	edge, zeros := -1, 0
+	for k := 0; k < 3; k++ {
+		a, b := &m.points[m.v[t][k]], &m.points[m.v[t][(k+1)%3]]
+		if robust.Orient2D(a, b, p) == 0 {
+			edge = k
+			zeros++
+		}
+	}
+	if zeros >= 2 {
+		// A duplicate point, of the vertex where the 2 edges meet.
+		for _, v := range m.v[t] {
+			if m.points[v] == *p {
+				m.same[i] = v
+			}
+		}
+		return
+	}
+	if edge >= 0 && m.adj[t][edge] >= 0 {
+		m.splitEdge(t, edge, i)
+	} else {
+		m.splitTriangle(t, i)
+	}
+}
+
+// splitTriangle splits t in 3 around p.
+func (m *mesh) splitTriangle(t, p int) {
+	a, b, c := m.v[t][0], m.v[t][1], m.v[t][2]
+	nab, nbc, nca := m.adj[t][0], m.adj[t][1], m.adj[t][2]
+	cab, cbc, cca := m.constrained[t][0], m.constrained[t][1], m.constrained[t][2]
+
+	t1 := m.newTriangle([3]int{b, c, p}, [3]int{nbc, -1, t}, [3]bool{cbc})
+	t2 := m.newTriangle([3]int{c, a, p}, [3]int{nca, t, t1}, [3]bool{cca})
+	m.set(t1, [3]int{b, c, p}, [3]int{nbc, t2, t}, [3]bool{cbc})
+	m.set(t, [3]int{a, b, p}, [3]int{nab, t1, t2}, [3]bool{cab})
+
+	m.legalize(t, 0)
+	m.legalize(t1, 0)
+	m.legalize(t2, 0)
+}
+
+// splitEdge splits edge i of t and the triangle on the other side in 2 at p.
+func (m *mesh) splitEdge(t, i, p int) {
+	u := m.adj[t][i]
+	a, b, c := m.v[t][i], m.v[t][(i+1)%3], m.v[t][(i+2)%3]
+	j := m.edgeIndex(u, b, a)
+	d := m.v[u][(j+2)%3]
+	nbc, nca := m.adj[t][(i+1)%3], m.adj[t][(i+2)%3]
+	nad, ndb := m.adj[u][(j+1)%3], m.adj[u][(j+2)%3]
+	cab := m.constrained[t][i]
+	cbc, cca := m.constrained[t][(i+1)%3], m.constrained[t][(i+2)%3]
+	cad, cdb := m.constrained[u][(j+1)%3], m.constrained[u][(j+2)%3]
+
+	t1 := m.newTriangle([3]int{c, p, b}, [3]int{t, -1, nbc}, [3]bool{false, cab, cbc})
+	t3 := m.newTriangle([3]int{d, p, a}, [3]int{-1, t, nad}, [3]bool{false, cab, cad})
+	m.set(t, [3]int{c, a, p}, [3]int{nca, t3, t1}, [3]bool{cca, cab, false})
+	m.set(u, [3]int{d, b, p}, [3]int{ndb, t1, t3}, [3]bool{cdb, cab, false})
+	m.set(t1, [3]int{c, p, b}, [3]int{t, u, nbc}, [3]bool{false, cab, cbc})
+	m.set(t3, [3]int{d, p, a}, [3]int{u, t, nad}, [3]bool{false, cab, cad})
+
+	m.legalize(t, 0)
+	m.legalize(t1, 2)
+	m.legalize(u, 0)
+	m.legalize(t3, 2)
+}
+
+// edgeIndex returns the index of the edge a, b in t, or -1.
+func (m *mesh) edgeIndex(t, a, b int) int {
+	for i := 0; i < 3; i++ {
+		if m.v[t][i] == a && m.v[t][(i+1)%3] == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// flip replaces the edge i of t, shared with u, by the other diagonal of the
+// quad they form. t becomes c, a, d and u becomes d, b, c where c is the
+// vertex of t and d the vertex of u opposite to the edge a, b.
+func (m *mesh) flip(t, i int) (u int) {
+	u = m.adj[t][i]
+	a, b, c := m.v[t][i], m.v[t][(i+1)%3], m.v[t][(i+2)%3]
+	j := m.edgeIndex(u, b, a)
+	d := m.v[u][(j+2)%3]
+	nbc, nca := m.adj[t][(i+1)%3], m.adj[t][(i+2)%3]
+	nad, ndb := m.adj[u][(j+1)%3], m.adj[u][(j+2)%3]
+	cbc, cca := m.constrained[t][(i+1)%3], m.constrained[t][(i+2)%3]
+	cad, cdb := m.constrained[u][(j+1)%3], m.constrained[u][(j+2)%3]
+
+	m.set(t, [3]int{c, a, d}, [3]int{nca, nad, u}, [3]bool{cca, cad, false})
+	m.set(u, [3]int{d, b, c}, [3]int{ndb, nbc, t}, [3]bool{cdb, cbc, false})
+	return u
+}
+
+// legalize flips edge i of t if the vertex opposite to it in t is inside the
+// circumcircle of the triangle on the other side, and recursively the edges
+// that flipping makes suspect.
+func (m *mesh) legalize(t, i int) {
+	type edge struct{ t, i int }
+	stack := []edge{{t, i}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !m.illegal(e.t, e.i) {
+			continue
+		}
+		t, u := e.t, m.flip(e.t, e.i)
+		// t is p, a, d and u is d, b, p, check their edges opposite to p.
+		stack = append(stack, edge{t, 1}, edge{u, 0})
+	}
+}
+
+// fillHull adds the triangles missing between the hull and its convex hull.
+// They're left out when the enclosing triangle is too close to the points.
+func (m *mesh) fillHull() {
+	// next links the vertices of the hull, counter-clockwise.
+	next := make(map[int]int)
+	owner := make(map[int]int)
+	for t := range m.v {
+		if m.dead[t] {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			if m.adj[t][i] < 0 {
+				next[m.v[t][i]] = m.v[t][(i+1)%3]
+				owner[m.v[t][i]] = t
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for a, b := range next {
+			c, ok := next[b]
+			if !ok || c == a || robust.Orient2D(&m.points[a], &m.points[b], &m.points[c]) >= 0 {
+				continue
+			}
+			// b is a reflex vertex, fill the pocket with a, c, b.
+			tab, tbc := owner[a], owner[b]
+			t := m.newTriangle([3]int{a, c, b}, [3]int{-1, tbc, tab}, [3]bool{})
+			next[a], owner[a] = c, t
+			delete(next, b)
+			delete(owner, b)
+			changed = true
+			break
+		}
+	}
+
+	// The new triangles may not be Delaunay, flipping them doesn't change the
+	// hull.
+	for changed := true; changed; {
+		changed = false
+		for t := range m.v {
+			for i := 0; i < 3 && !m.dead[t]; i++ {
+				if m.illegal(t, i) {
+					m.flip(t, i)
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+// illegal returns true if edge i of t isn't constrained and isn't Delaunay.
+func (m *mesh) illegal(t, i int) bool {
+	u := m.adj[t][i]
+	if u < 0 || m.constrained[t][i] {
+		return false
+	}
+	a, b, c := m.v[t][i], m.v[t][(i+1)%3], m.v[t][(i+2)%3]
+	d := m.v[u][(m.edgeIndex(u, b, a)+2)%3]
+	return robust.InCircle(&m.points[a], &m.points[b], &m.points[c], &m.points[d]) > 0
+}
+
+// findEdge returns the triangle and index of the edge a, b or -1.
+func (m *mesh) findEdge(a, b int) (t, i int) {
+	for _, t := range m.around(a) {
+		if i := m.edgeIndex(t, a, b); i >= 0 {
+			return t, i
+		}
+	}
+	return -1, -1
+}
+
+// around returns the triangles using the vertex a.
+func (m *mesh) around(a int) []int {
+	start := m.vt[a]
+	if start < 0 {
+		return nil
+	}
+	tris := []int{start}
+	// Turn counter-clockwise then, if a is on the hull, clockwise.
+	for t := start; ; {
+		i := (m.vertexIndex(t, a) + 2) % 3
+		if t = m.adj[t][i]; t < 0 {
+			break
+		}
+		if t == start {
+			return tris
+		}
+		tris = append(tris, t)
+	}
+	for t := start; ; {
+		i := m.vertexIndex(t, a)
+		if t = m.adj[t][i]; t < 0 {
+			break
+		}
+		tris = append(tris, t)
+	}
+	return tris
+}
+
+// vertexIndex returns the index of the vertex a in t.
+func (m *mesh) vertexIndex(t, a int) int {
+	for i := 0; i < 3; i++ {
+		if m.v[t][i] == a {
+			return i
+		}
+	}
+	return -1
+}
+
+// constrain forces the edge a, b in the triangulation with Sloan's algorithm.
+// See Sloan "A fast algorithm for generating constrained Delaunay
+// triangulations".
+func (m *mesh) constrain(a, b int) {
+	if a == b || m.vt[a] < 0 || m.vt[b] < 0 {
+		return
+	}
+	pa, pb := &m.points[a], &m.points[b]
+
+	// Find the edges crossing ab, stored by their vertices, from right to
+	// left.
+	var crossing [][2]int
+	var t, right, left int
+	found := false
+	for _, t0 := range m.around(a) {
+		i := m.vertexIndex(t0, a)
+		r, l := m.v[t0][(i+1)%3], m.v[t0][(i+2)%3]
+		if r == b || l == b {
+			m.setConstrained(a, b)
+			return
+		}
+		or, ol := robust.Orient2D(pa, pb, &m.points[r]), robust.Orient2D(pa, pb, &m.points[l])
+		// A vertex on the segment splits the constraint.
+		if or == 0 && m.ahead(pa, pb, r) {
+			m.constrain(a, r)
+			m.constrain(r, b)
+			return
+		}
+		if ol == 0 && m.ahead(pa, pb, l) {
+			m.constrain(a, l)
+			m.constrain(l, b)
+			return
+		}
+		if or < 0 && ol > 0 {
+			t, right, left, found = t0, r, l, true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	for {
+		crossing = append(crossing, [2]int{right, left})
+		i := m.edgeIndex(t, right, left)
+		u := m.adj[t][i]
+		if u < 0 {
+			return
+		}
+		d := m.v[u][(m.edgeIndex(u, left, right)+2)%3]
+		if d == b {
+			break
+		}
+		od := robust.Orient2D(pa, pb, &m.points[d])
+		if od == 0 {
+			// d is on the segment, constrain up to it then continue.
+			m.removeCrossing(a, d, crossing)
+			m.constrain(d, b)
+			return
+		}
+		if od > 0 {
+			left = d
+		} else {
+			right = d
+		}
+		t = u
+	}
+	m.removeCrossing(a, b, crossing)
+}
+
+// ahead returns true if the point c is between a and b, which it is
+// collinear with.
+func (m *mesh) ahead(a, b *glm.Vec2, c int) bool {
+	ab, ac := b.Sub(a), m.points[c].Sub(a)
+	d := ab.Dot(&ac)
+	return d > 0 && d < ab.Len2()
+}
+
+// removeCrossing flips the crossing edges out of the way of the edge a, b,
+// constrains it and restores the Delaunay property around it.
+func (m *mesh) removeCrossing(a, b int, crossing [][2]int) {
+	pa, pb := &m.points[a], &m.points[b]
+	var created [][2]int
+	for guard := 0; len(crossing) > 0 && guard < 100*len(m.v); guard++ {
+		e := crossing[0]
+		crossing = crossing[1:]
+		t, i := m.findEdge(e[0], e[1])
+		if t < 0 {
+			continue
+		}
+		u := m.adj[t][i]
+		c := m.v[t][(i+2)%3]
+		d := m.v[u][(m.edgeIndex(u, e[1], e[0])+2)%3]
+		// The quad must be convex for the flip to be valid.
+		pc, pd := &m.points[c], &m.points[d]
+		if robust.Orient2D(pc, &m.points[e[0]], pd) <= 0 || robust.Orient2D(pd, &m.points[e[1]], pc) <= 0 {
+			crossing = append(crossing, e)
+			continue
+		}
+		m.flip(t, i)
+		oc, od := robust.Orient2D(pa, pb, pc), robust.Orient2D(pa, pb, pd)
+		if c != a && c != b && d != a && d != b && (oc > 0) != (od > 0) && oc != 0 && od != 0 {
+			// Still crossing, in the same right to left order.
+			if oc < 0 {
+				crossing = append(crossing, [2]int{c, d})
+			} else {
+				crossing = append(crossing, [2]int{d, c})
+			}
+		} else {
+			created = append(created, [2]int{c, d})
+		}
+	}
+	m.setConstrained(a, b)
+
+	// Restore the Delaunay property on the new edges.
+	for changed := true; changed; {
+		changed = false
+		for k, e := range created {
+			if (e[0] == a && e[1] == b) || (e[0] == b && e[1] == a) {
+				continue
+			}
+			t, i := m.findEdge(e[0], e[1])
+			if t >= 0 && m.illegal(t, i) {
+				u := m.adj[t][i]
+				c := m.v[t][(i+2)%3]
+				d := m.v[u][(m.edgeIndex(u, e[1], e[0])+2)%3]
+				m.flip(t, i)
+				created[k] = [2]int{c, d}
+				changed = true
+			}
+		}
+	}
+}
+
+// setConstrained marks both sides of the edge a, b constrained.
+func (m *mesh) setConstrained(a, b int) {
+	if t, i := m.findEdge(a, b); t >= 0 {
+		m.constrained[t][i] = true
+	}
+	if t, i := m.findEdge(b, a); t >= 0 {
+		m.constrained[t][i] = true
+	}
+}
+
+// triangulation returns the compact half-edge form of m.
+func (m *mesh) triangulation(constrained bool) *Triangulation {
+	index := make([]int, len(m.v))
+	n := 0
+	for t := range m.v {
+		if m.dead[t] {
+			index[t] = -1
+			continue
+		}
+		index[t] = n
+		n++
+	}
+
+	tr := &Triangulation{
+		Points:    m.points,
+		Triangles: make([]int, 0, 3*n),
+		Halfedges: make([]int, 0, 3*n),
+	}
+	if constrained {
+		tr.Constrained = make([]bool, 0, 3*n)
+	}
+	for t := range m.v {
+		if m.dead[t] {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			tr.Triangles = append(tr.Triangles, m.v[t][i])
+			h := -1
+			if u := m.adj[t][i]; u >= 0 {
+				h = 3*index[u] + m.edgeIndex(u, m.v[t][(i+1)%3], m.v[t][i])
+			}
+			tr.Halfedges = append(tr.Halfedges, h)
+			if constrained {
+				tr.Constrained = append(tr.Constrained, m.constrained[t][i])
+			}
+		}
+	}
+	return tr
+}
@@ -0,0 +1,190 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+	"testing"
+)
+
+// randomPoints returns n reproducible pseudo random points in [0, 1)².
+func randomPoints(n int, seed uint32) []glm.Vec2 {
+	rnd := func() float32 {
+		seed = seed*1664525 + 1013904223
+		return float32(seed>>8) / (1 << 24)
+	}
+	points := make([]glm.Vec2, n)
+	for i := range points {
+		points[i] = glm.Vec2{rnd(), rnd()}
+	}
+	return points
+}
+
+// checkTriangulation checks the topology of tr, that its triangles cover the
+// convex hull of its points and, for the unconstrained edges, the Delaunay
+// property.
+func checkTriangulation(t *testing.T, name string, tr *Triangulation) {
+	var area float32
+	for e, h := range tr.Halfedges {
+		if h >= 0 && (tr.Halfedges[h] != e || tr.Triangles[h] != tr.Triangles[NextHalfedge(e)]) {
+			t.Errorf("%s: half-edges %d and %d don't match", name, e, h)
+		}
+	}
+	for i := 0; i < len(tr.Triangles); i += 3 {
+		a, b, c := &tr.Points[tr.Triangles[i]], &tr.Points[tr.Triangles[i+1]], &tr.Points[tr.Triangles[i+2]]
+		o := robust.Orient2D(a, b, c)
+		if o <= 0 {
+			t.Errorf("%s: triangle %d isn't counter-clockwise", name, i/3)
+		}
+		area += float32(o / 2)
+		for k := 0; k < 3; k++ {
+			e := i + k
+			h := tr.Halfedges[e]
+			if h < 0 || (tr.Constrained != nil && tr.Constrained[e]) {
+				continue
+			}
+			d := &tr.Points[tr.Triangles[PrevHalfedge(h)]]
+			if robust.InCircle(a, b, c, d) > 1e-9 {
+				t.Errorf("%s: edge %d isn't Delaunay", name, e)
+			}
+		}
+	}
+	hull := ConvexHull(tr.Points)
+	if want := hull.Area(); math.Abs(area-want) > 1e-4*want {
+		t.Errorf("%s: area = %f, want %f", name, area, want)
+	}
+}
+
+func TestDelaunay(t *testing.T) {
+	t.Parallel()
+	random := randomPoints(200, 1)
+	random = append(random, random[5], random[7])
+	var grid []glm.Vec2
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			grid = append(grid, glm.Vec2{float32(x), float32(y)})
+		}
+	}
+	// Points on a circle, the worst case for the hull.
+	var circle []glm.Vec2
+	for i := 0; i < 32; i++ {
+		s, c := math.Sincos(float32(i) * 2 * math.Pi / 32)
+		circle = append(circle, glm.Vec2{1000 + c, s})
+	}
+	tests := []struct {
+		name      string
+		points    []glm.Vec2
+		triangles int
+	}{
+		{"random", random, -1},
+		{"grid", grid, 32},
+		{"circle", circle, 30},
+		{"collinear", []glm.Vec2{{0, 0}, {1, 1}, {2, 2}}, 0},
+		{"single", []glm.Vec2{{1, 2}}, 0},
+		{"empty", nil, 0},
+	}
+	for _, test := range tests {
+		tr := Delaunay(test.points)
+		checkTriangulation(t, test.name, tr)
+		if n := len(tr.Triangles) / 3; test.triangles >= 0 && n != test.triangles {
+			t.Errorf("%s: %d triangles, want %d", test.name, n, test.triangles)
+		}
+	}
+}
+
+func TestConstrainedDelaunay(t *testing.T) {
+	t.Parallel()
+	points := randomPoints(100, 7)
+	// A zig-zag of edges crossing many Delaunay edges, one passing exactly
+	// through another point.
+	points = append(points, glm.Vec2{-0.125, 0.5}, glm.Vec2{1.125, 0.4375}, glm.Vec2{0.5, 1.125}, glm.Vec2{0.1875, 0.8125})
+	n := len(points)
+	edges := [][2]int{{n - 4, n - 3}, {n - 3, n - 2}, {n - 2, n - 4}}
+	tr := ConstrainedDelaunay(points, edges)
+	checkTriangulation(t, "constrained", tr)
+
+	has := func(a, b int) bool {
+		for e := range tr.Triangles {
+			if tr.Triangles[e] == a && tr.Triangles[NextHalfedge(e)] == b {
+				return tr.Constrained[e]
+			}
+		}
+		return false
+	}
+	for _, e := range edges[:2] {
+		if !has(e[0], e[1]) && !has(e[1], e[0]) {
+			t.Errorf("edge %v missing", e)
+		}
+	}
+	// The last edge is split by the point on it.
+	if !(has(n-2, n-1) || has(n-1, n-2)) || !(has(n-1, n-4) || has(n-4, n-1)) {
+		t.Errorf("split edge missing")
+	}
+
+	// Edges between duplicate points use the copies that were kept.
+	points = nil
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			points = append(points, glm.Vec2{float32(x), float32(y)})
+		}
+	}
+	points = append(points, points[6], points[18], points[8], points[6])
+	edges = [][2]int{{25, 13}, {26, 22}, {27, 19}, {0, 28}}
+	tr = ConstrainedDelaunay(points, edges)
+	checkTriangulation(t, "duplicates", tr)
+	hasPoints := func(a, b glm.Vec2) bool {
+		for e := range tr.Triangles {
+			p, q := tr.Points[tr.Triangles[e]], tr.Points[tr.Triangles[NextHalfedge(e)]]
+			if (p == a && q == b || p == b && q == a) && tr.Constrained[e] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, e := range edges {
+		if !hasPoints(points[e[0]], points[e[1]]) {
+			t.Errorf("edge %v from %v to %v missing", e, points[e[0]], points[e[1]])
+		}
+	}
+}
+
+func TestVoronoi(t *testing.T) {
+	t.Parallel()
+	var points []glm.Vec2
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			points = append(points, glm.Vec2{float32(x), float32(y)})
+		}
+	}
+	points = append(points, glm.Vec2{0.3, 2.6})
+	tr := Delaunay(points)
+	bounds := AABB{Center: glm.Vec2{1, 1}, HalfExtend: glm.Vec2{2, 2}}
+	cells := tr.Voronoi(&bounds)
+
+	var area float32
+	for i, cell := range cells {
+		if cell.SignedArea() <= 0 || !cell.IsConvex() {
+			t.Errorf("cell %d = %v isn't convex and counter-clockwise", i, cell)
+		}
+		if !cell.Contains(&points[i]) {
+			t.Errorf("cell %d = %v doesn't contain its point", i, cell)
+		}
+		// The points of the cell are closer to its point than to any other.
+		c := cell.Centroid()
+		d := c.Sub(&points[i])
+		for j := range points {
+			e := c.Sub(&points[j])
+			if e.Len2() < d.Len2()-1e-5 {
+				t.Errorf("cell %d centroid is closer to point %d", i, j)
+			}
+		}
+		area += cell.Area()
+	}
+	if math.Abs(area-16) > 1e-3 {
+		t.Errorf("cells area = %f, want 16", area)
+	}
+	// The cell of the center point isn't affected by the extra point.
+	if a := cells[4].Area(); math.Abs(a-1) > 1e-4 {
+		t.Errorf("center cell area = %f, want 1", a)
+	}
+}
@@ -0,0 +1,95 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// OBB is an oriented bounding box.
+type OBB struct {
+	// The center of the OBB.
+	Center glm.Vec2
+	// The orientation of the OBB, these need to be orthonormal.
+	Orientation [2]glm.Vec2
+	// The half extends of the OBB.
+	HalfExtend glm.Vec2
+}
+
+// OBBFromAngle returns the OBB centered on center, rotated by angle radians
+// counter-clockwise.
+func OBBFromAngle(center *glm.Vec2, angle float32, halfExtend *glm.Vec2) OBB {
+	s, c := math.Sincos(angle)
+	return OBB{
+		Center:      *center,
+		Orientation: [2]glm.Vec2{{c, s}, {-s, c}},
+		HalfExtend:  *halfExtend,
+	}
+}
+
+// Polygon returns the corners of the OBB as a counter-clockwise polygon.
+func (o *OBB) Polygon() Polygon {
+	u := o.Orientation[0].Mul(o.HalfExtend[0])
+	v := o.Orientation[1].Mul(o.HalfExtend[1])
+	p := make(Polygon, 4)
+	for i, s := range [4][2]float32{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		p[i] = o.Center
+		p[i].AddScaledVec(s[0], &u)
+		p[i].AddScaledVec(s[1], &v)
+	}
+	return p
+}
+
+// local returns p in the frame of the OBB.
+func (o *OBB) local(p *glm.Vec2) glm.Vec2 {
+	d := p.Sub(&o.Center)
+	return glm.Vec2{d.Dot(&o.Orientation[0]), d.Dot(&o.Orientation[1])}
+}
+
+// ClosestPointOBBPoint returns the point in or on the OBB closest to p.
+func ClosestPointOBBPoint(o *OBB, p *glm.Vec2) glm.Vec2 {
+	l := o.local(p)
+	q := o.Center
+	for i := 0; i < 2; i++ {
+		q.AddScaledVec(math.Clamp(l[i], -o.HalfExtend[i], o.HalfExtend[i]), &o.Orientation[i])
+	}
+	return q
+}
+
+// SqDistOBBPoint returns the square distance of p to the OBB.
+func SqDistOBBPoint(o *OBB, p *glm.Vec2) float32 {
+	l := o.local(p)
+	var sqDist float32
+	for i := 0; i < 2; i++ {
+		if d := math.Abs(l[i]) - o.HalfExtend[i]; d > 0 {
+			sqDist += d * d
+		}
+	}
+	return sqDist
+}
+
+// TestOBBOBB returns true if these OBB overlap. In 2D only the 4 face normals
+// can separate two boxes.
+func TestOBBOBB(a, b *OBB) bool {
+	t := b.Center.Sub(&a.Center)
+	for _, axis := range [4]glm.Vec2{a.Orientation[0], a.Orientation[1], b.Orientation[0], b.Orientation[1]} {
+		ra := a.HalfExtend[0]*math.Abs(axis.Dot(&a.Orientation[0])) + a.HalfExtend[1]*math.Abs(axis.Dot(&a.Orientation[1]))
+		rb := b.HalfExtend[0]*math.Abs(axis.Dot(&b.Orientation[0])) + b.HalfExtend[1]*math.Abs(axis.Dot(&b.Orientation[1]))
+		if math.Abs(t.Dot(&axis)) > ra+rb {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectRayOBB intersects the ray r with the OBB o. When intersecting it
+// returns the intersection distance t and the point q of intersection, t is 0
+// if the ray starts inside o.
+func IntersectRayOBB(r *Ray, o *OBB) (t float32, q glm.Vec2, overlap bool) {
+	p := o.local(&r.Origin)
+	d := glm.Vec2{r.Direction.Dot(&o.Orientation[0]), r.Direction.Dot(&o.Orientation[1])}
+	t, overlap = raySlabs(&p, &d, &glm.Vec2{}, &o.HalfExtend)
+	if overlap {
+		q = r.At(t)
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Join is the shape of the corners added by OffsetPolygon.
+type Join int
+
+// The corner shapes.
+const (
+	// JoinMiter extends the edges until they meet, or bevels the corner if
+	// they meet further than the miter limit.
+	JoinMiter Join = iota
+	// JoinRound adds an arc around the corner.
+	JoinRound
+	// JoinBevel cuts the corner with a straight edge.
+	JoinBevel
+)
+
+// roundJoinStep is the angle between the points of round joins, it keeps the
+// arcs within 1% of the offset.
+const roundJoinStep = 0.28
+
+// OffsetPolygon returns p with its edges moved by delta along their normals,
+// outwards if delta is positive and inwards if it's negative. join selects the
+// shape of the corners that open up, miterLimit is how far miter corners can
+// go, as a multiple of delta. 2 is a common value.
+//
+// The result keeps the winding of p. It isn't cleaned up, offsetting inwards by
+// more than the size of the features of p makes it self-intersect.
+func OffsetPolygon(p Polygon, delta float32, join Join, miterLimit float32) Polygon {
+	n := len(p)
+	if n < 3 || delta == 0 {
+		return append(Polygon(nil), p...)
+	}
+	// The outward normals of the edges.
+	sign := float32(1)
+	if p.SignedArea() < 0 {
+		sign = -1
+	}
+	normals := make([]glm.Vec2, n)
+	for i := range p {
+		e := p[(i+1)%n].Sub(&p[i])
+		normals[i] = glm.Vec2{e[1], -e[0]}
+		if l := e.Len(); l > 0 {
+			normals[i].MulWith(sign / l)
+		}
+	}
+
+	out := make(Polygon, 0, 2*n)
+	for i := range p {
+		v := &p[i]
+		n0, n1 := &normals[(i+n-1)%n], &normals[i]
+		dot, cross := n0.Dot(n1), n0.Cross(n1)
+		corner := func(nv *glm.Vec2) glm.Vec2 {
+			q := *v
+			q.AddScaledVec(delta, nv)
+			return q
+		}
+
+		// The corner opens up when the offset goes towards the outside of
+		// the turn.
+		opens := cross*sign*delta > 0
+		if 1+dot < 1e-6 {
+			// The edges fold back on each other, the offset lines are
+			// parallel.
+			out = append(out, corner(n0), corner(n1))
+			continue
+		}
+		// The miter point, where the offset lines meet.
+		miter := n0.Add(n1)
+		miter.MulWith(1 / (1 + dot))
+		if !opens || math.Abs(cross) < 1e-6 {
+			out = append(out, corner(&miter))
+			continue
+		}
+
+		switch join {
+		case JoinMiter:
+			if miter.Len() <= miterLimit {
+				out = append(out, corner(&miter))
+			} else {
+				out = append(out, corner(n0), corner(n1))
+			}
+		case JoinRound:
+			angle := math.Atan2(cross, dot)
+			steps := int(math.Ceil(math.Abs(angle) / roundJoinStep))
+			for k := 0; k <= steps; k++ {
+				s, c := math.Sincos(angle * float32(k) / float32(steps))
+				r := glm.Vec2{c*n0[0] - s*n0[1], s*n0[0] + c*n0[1]}
+				out = append(out, corner(&r))
+			}
+		default:
+			out = append(out, corner(n0), corner(n1))
+		}
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package geo2d
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// Polygon is a simple polygon given by its vertices, without repeating the
+// first one at the end.
+type Polygon []glm.Vec2
+
+// SignedArea returns the area of p, positive if p is counter-clockwise and
+// negative if it's clockwise.
+func (p Polygon) SignedArea() float32 {
+	if len(p) < 3 {
+		return 0
+	}
+	// Relative to the first vertex to keep the products small.
+	var a float32
+	for i := 1; i < len(p)-1; i++ {
+		u, v := p[i].Sub(&p[0]), p[i+1].Sub(&p[0])
+		a += u.Cross(&v)
+	}
+	return a * 0.5
+}
+
+// Area returns the area of p.
+func (p Polygon) Area() float32 {
+	return math.Abs(p.SignedArea())
+}
+
+// Centroid returns the center of mass of p. The centroid of a degenerate
+// polygon is the average of its vertices.
+func (p Polygon) Centroid() glm.Vec2 {
+	var c glm.Vec2
+	if len(p) == 0 {
+		return c
+	}
+	var a float32
+	for i := 1; i < len(p)-1; i++ {
+		u, v := p[i].Sub(&p[0]), p[i+1].Sub(&p[0])
+		w := u.Cross(&v)
+		a += w
+		// The centroid of each triangle (p0, pi, pi+1) weighted by its area.
+		s := u.Add(&v)
+		c.AddScaledVec(w, &s)
+	}
+	if a == 0 {
+		for i := range p {
+			c.AddWith(&p[i])
+		}
+		c.MulWith(1 / float32(len(p)))
+		return c
+	}
+	c.MulWith(1 / (3 * a))
+	return c.Add(&p[0])
+}
+
+// Contains returns true if q is inside p, using the even-odd rule. Points
+// exactly on the edges may be either inside or outside.
+func (p Polygon) Contains(q *glm.Vec2) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := &p[j], &p[i]
+		// Count the edges crossing the horizontal half line right of q.
+		if (a[1] > q[1]) != (b[1] > q[1]) {
+			x := a[0] + (q[1]-a[1])/(b[1]-a[1])*(b[0]-a[0])
+			if q[0] < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// IsConvex returns true if p is convex. Collinear vertices are allowed.
+func (p Polygon) IsConvex() bool {
+	var sign float32
+	for i := range p {
+		a, b, c := &p[i], &p[(i+1)%len(p)], &p[(i+2)%len(p)]
+		u, v := b.Sub(a), c.Sub(b)
+		cr := u.Cross(&v)
+		if cr == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = cr
+		} else if (cr > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reverse reverses the order of the vertices of p, switching between
+// clockwise and counter-clockwise.
+func (p Polygon) Reverse() {
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
+
+// Edge returns the edge of p starting at vertex i.
+func (p Polygon) Edge(i int) Segment {
+	return Segment{p[i], p[(i+1)%len(p)]}
+}
+
+// ConvexHull returns the counter-clockwise convex hull of the points using
+// Andrew's monotone chain algorithm. Collinear points on the hull are dropped.
+func ConvexHull(points []glm.Vec2) Polygon {
+	sorted := append([]glm.Vec2(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+	if len(sorted) < 3 {
+		if len(sorted) == 2 && sorted[0] == sorted[1] {
+			sorted = sorted[:1]
+		}
+		return sorted
+	}
+
+	hull := make(Polygon, 0, 2*len(sorted))
+	// Lower hull, left to right.
+	for i := range sorted {
+		for len(hull) >= 2 && robust.Orient2D(&hull[len(hull)-2], &hull[len(hull)-1], &sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+	// Upper hull, right to left.
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		for len(hull) >= lower && robust.Orient2D(&hull[len(hull)-2], &hull[len(hull)-1], &sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+	// The last point is the first one again.
+	return hull[:len(hull)-1]
+}
+
+// project returns the interval covered by p projected on axis.
+func project(p Polygon, axis *glm.Vec2) (min, max float32) {
+	min, max = math.MaxFloat32, -math.MaxFloat32
+	for i := range p {
+		d := p[i].Dot(axis)
+		min, max = math.Min(min, d), math.Max(max, d)
+	}
+	return
+}
+
+// separated returns true if one of the edge normals of a separates a and b.
+func separated(a, b Polygon) bool {
+	for i, j := 0, len(a)-1; i < len(a); j, i = i, i+1 {
+		e := a[i].Sub(&a[j])
+		n := e.Perp()
+		amin, amax := project(a, &n)
+		bmin, bmax := project(b, &n)
+		if amax < bmin || bmax < amin {
+			return true
+		}
+	}
+	return false
+}
+
+// TestPolygonPolygon returns true if the convex polygons a and b overlap,
+// using the separating axis theorem. The winding of the polygons doesn't
+// matter. Other shapes can be tested through their Polygon method.
+func TestPolygonPolygon(a, b Polygon) bool {
+	return !separated(a, b) && !separated(b, a)
+}
+
+// ClosestPointPolygonPoint returns the point in or on p closest to q. p doesn't
+// need to be convex.
+func ClosestPointPolygonPoint(p Polygon, q *glm.Vec2) glm.Vec2 {
+	if p.Contains(q) {
+		return *q
+	}
+	best, bestDist := *q, float32(math.MaxFloat32)
+	for i := range p {
+		e := p.Edge(i)
+		_, c := ClosestPointSegmentPoint(&e, q)
+		d := c.Sub(q)
+		if l := d.Len2(); l < bestDist {
+			best, bestDist = c, l
+		}
+	}
+	return best
+}
+
+// SqDistPolygonPoint returns the square distance of q to p, 0 if q is inside
+// p.
+func SqDistPolygonPoint(p Polygon, q *glm.Vec2) float32 {
+	c := ClosestPointPolygonPoint(p, q)
+	d := c.Sub(q)
+	return d.Len2()
+}
+
+// IntersectRayPolygon intersects the ray r with the polygon p. When
+// intersecting it returns the intersection distance t and the point q of
+// intersection, t is 0 if the ray starts inside p. p doesn't need to be
+// convex.
+func IntersectRayPolygon(r *Ray, p Polygon) (t float32, q glm.Vec2, overlap bool) {
+	if p.Contains(&r.Origin) {
+		return 0, r.Origin, true
+	}
+	for i := range p {
+		e := p.Edge(i)
+		if te, qe, ok := IntersectRaySegment(r, &e); ok && (!overlap || te < t) {
+			t, q, overlap = te, qe, true
+		}
+	}
+	return
+}
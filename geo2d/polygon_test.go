@@ -0,0 +1,170 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func vecNear(a, b *glm.Vec2, tol float32) bool {
+	return math.Abs(a[0]-b[0]) <= tol && math.Abs(a[1]-b[1]) <= tol
+}
+
+func TestPolygon_AreaCentroid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		p        Polygon
+		area     float32
+		centroid glm.Vec2
+	}{
+		{ // 0 unit square
+			p:        Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+			area:     1,
+			centroid: glm.Vec2{0.5, 0.5},
+		},
+		{ // 1 clockwise triangle
+			p:        Polygon{{0, 0}, {0, 3}, {3, 0}},
+			area:     -4.5,
+			centroid: glm.Vec2{1, 1},
+		},
+		{ // 2 L shape
+			p:        Polygon{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}},
+			area:     3,
+			centroid: glm.Vec2{5.0 / 6, 5.0 / 6},
+		},
+		{ // 3 far from the origin
+			p:        Polygon{{1000, 1000}, {1002, 1000}, {1002, 1004}, {1000, 1004}},
+			area:     8,
+			centroid: glm.Vec2{1001, 1002},
+		},
+		{ // 4 degenerate
+			p:        Polygon{{0, 0}, {1, 1}, {2, 2}},
+			area:     0,
+			centroid: glm.Vec2{1, 1},
+		},
+	}
+	for i, test := range tests {
+		if a := test.p.SignedArea(); math.Abs(a-test.area) > 1e-4 {
+			t.Errorf("[%d] SignedArea() = %f, want %f", i, a, test.area)
+		}
+		if c := test.p.Centroid(); !vecNear(&c, &test.centroid, 1e-4) {
+			t.Errorf("[%d] Centroid() = %v, want %v", i, c, test.centroid)
+		}
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	t.Parallel()
+	// A U shape, concave.
+	p := Polygon{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}
+	tests := []struct {
+		q    glm.Vec2
+		want bool
+	}{
+		{glm.Vec2{0.5, 0.5}, true},
+		{glm.Vec2{0.5, 2.5}, true},
+		{glm.Vec2{2.5, 2.5}, true},
+		{glm.Vec2{1.5, 2}, false},
+		{glm.Vec2{1.5, 0.5}, true},
+		{glm.Vec2{-1, 0.5}, false},
+		{glm.Vec2{4, 2}, false},
+		{glm.Vec2{1.5, 4}, false},
+	}
+	for i, test := range tests {
+		if got := p.Contains(&test.q); got != test.want {
+			t.Errorf("[%d] Contains(%v) = %t, want %t", i, test.q, got, test.want)
+		}
+	}
+	if p.IsConvex() {
+		t.Errorf("IsConvex() = true for a U shape")
+	}
+	if h := ConvexHull(p); !h.IsConvex() {
+		t.Errorf("IsConvex() = false for %v", h)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	t.Parallel()
+	points := []glm.Vec2{
+		{0, 0}, {1, 1}, {2, 0}, {2, 2}, {0, 2}, {1, 0}, {1, 2}, {0.5, 1.5}, {2, 1}, {1.5, 0.2},
+	}
+	want := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	h := ConvexHull(points)
+	if len(h) != len(want) {
+		t.Fatalf("ConvexHull() = %v, want %v", h, want)
+	}
+	for i := range want {
+		if h[i] != want[i] {
+			t.Errorf("ConvexHull() = %v, want %v", h, want)
+			break
+		}
+	}
+	if h.SignedArea() <= 0 {
+		t.Errorf("ConvexHull() is clockwise")
+	}
+
+	if h := ConvexHull([]glm.Vec2{{0, 0}, {1, 1}, {2, 2}, {3, 3}}); len(h) != 2 {
+		t.Errorf("ConvexHull(collinear) = %v", h)
+	}
+}
+
+func TestPolygon_Overlap(t *testing.T) {
+	t.Parallel()
+	square := Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	tests := []struct {
+		b    Polygon
+		want bool
+	}{
+		{Polygon{{0.5, 0.5}, {2, 0.5}, {2, 2}}, true},
+		{Polygon{{2, 0}, {3, 0}, {3, 1}}, false},
+		// Only the triangle's hypotenuse separates them.
+		{Polygon{{1.2, 2}, {2, 1.2}, {2, 2}}, false},
+		{Polygon{{0.9, 1.1}, {1.1, 0.9}, {2, 2}}, true},
+		// Clockwise.
+		{Polygon{{0.2, 0.2}, {0.2, 0.8}, {0.8, 0.2}}, true},
+	}
+	for i, test := range tests {
+		if got := TestPolygonPolygon(square, test.b); got != test.want {
+			t.Errorf("[%d] TestPolygonPolygon() = %t, want %t", i, got, test.want)
+		}
+		if got := TestPolygonPolygon(test.b, square); got != test.want {
+			t.Errorf("[%d] TestPolygonPolygon(b, a) = %t, want %t", i, got, test.want)
+		}
+	}
+}
+
+func TestIntersectRayPolygon(t *testing.T) {
+	t.Parallel()
+	p := Polygon{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}
+	tests := []struct {
+		r       Ray
+		t       float32
+		overlap bool
+	}{
+		{Ray{glm.Vec2{1.5, 5}, glm.Vec2{0, -1}}, 4, true},
+		{Ray{glm.Vec2{-2, 2}, glm.Vec2{2, 0}}, 1, true},
+		{Ray{glm.Vec2{-2, 4}, glm.Vec2{1, 0}}, 0, false},
+		{Ray{glm.Vec2{0.5, 0.5}, glm.Vec2{1, 0}}, 0, true},
+	}
+	for i, test := range tests {
+		tt, q, overlap := IntersectRayPolygon(&test.r, p)
+		if overlap != test.overlap {
+			t.Errorf("[%d] overlap = %t, want %t", i, overlap, test.overlap)
+			continue
+		}
+		if !overlap {
+			continue
+		}
+		if want := test.r.At(test.t); math.Abs(tt-test.t) > 1e-5 || !vecNear(&q, &want, 1e-5) {
+			t.Errorf("[%d] IntersectRayPolygon() = %f, %v, want %f, %v", i, tt, q, test.t, want)
+		}
+	}
+
+	q := glm.Vec2{1.4, 2}
+	if c, want := ClosestPointPolygonPoint(p, &q), (glm.Vec2{1, 2}); !vecNear(&c, &want, 1e-5) {
+		t.Errorf("ClosestPointPolygonPoint() = %v, want %v", c, want)
+	}
+	if d := SqDistPolygonPoint(p, &q); math.Abs(d-0.16) > 1e-5 {
+		t.Errorf("SqDistPolygonPoint() = %f, want 0.16", d)
+	}
+}
@@ -0,0 +1,19 @@
+package geo2d
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Ray is a half line starting at Origin going in Direction. Direction doesn't
+// need to be normalized, the t values returned by the raycasts are in units of
+// Direction.
+type Ray struct {
+	Origin, Direction glm.Vec2
+}
+
+// At returns the point Origin + t*Direction.
+func (r *Ray) At(t float32) glm.Vec2 {
+	p := r.Origin
+	p.AddScaledVec(t, &r.Direction)
+	return p
+}
@@ -0,0 +1,134 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Segment is the line segment between A and B.
+type Segment struct {
+	A, B glm.Vec2
+}
+
+// ClosestPointSegmentPoint returns the point on s closest to p. Also returns t
+// for the position of the point, point = A + t*(B - A).
+func ClosestPointSegmentPoint(s *Segment, p *glm.Vec2) (t float32, point glm.Vec2) {
+	ab := s.B.Sub(&s.A)
+	ap := p.Sub(&s.A)
+
+	// Project p onto ab, but deferring the division by ab.Dot(ab)
+	t = ap.Dot(&ab)
+	if t <= 0 {
+		return 0, s.A
+	}
+	denom := ab.Len2()
+	if t >= denom {
+		return 1, s.B
+	}
+	t /= denom
+	point = s.A
+	point.AddScaledVec(t, &ab)
+	return
+}
+
+// SqDistSegmentPoint returns the square distance of p to the segment s.
+func SqDistSegmentPoint(s *Segment, p *glm.Vec2) float32 {
+	ab, ap, bp := s.B.Sub(&s.A), p.Sub(&s.A), p.Sub(&s.B)
+	e := ap.Dot(&ab)
+	if e <= 0 {
+		return ap.Len2()
+	}
+	f := ab.Len2()
+	if e >= f {
+		return bp.Len2()
+	}
+	return math.Max(ap.Len2()-e*e/f, 0)
+}
+
+// ClosestPointSegmentSegment computes the closest points c1 = s1.A + s*(s1.B -
+// s1.A) and c2 = s2.A + t*(s2.B - s2.A) of the two segments, returning s, t
+// and the squared distance u between c1 and c2.
+func ClosestPointSegmentSegment(s1, s2 *Segment) (s, t, u float32, c1, c2 glm.Vec2) {
+	const epsilon = 0.0001
+
+	d1 := s1.B.Sub(&s1.A)
+	d2 := s2.B.Sub(&s2.A)
+	r := s1.A.Sub(&s2.A)
+	a, e, f := d1.Len2(), d2.Len2(), d2.Dot(&r)
+
+	switch {
+	case a <= epsilon && e <= epsilon:
+		// Both segments degenerate into points.
+		return 0, 0, r.Len2(), s1.A, s2.A
+	case a <= epsilon:
+		// First segment degenerates into a point.
+		t = math.Clamp(f/e, 0, 1)
+	default:
+		c := d1.Dot(&r)
+		if e <= epsilon {
+			// Second segment degenerates into a point.
+			s = math.Clamp(-c/a, 0, 1)
+			break
+		}
+		// If segments are not parallel, compute closest point on the first
+		// line to the second and clamp to the segment. Else pick s = 0.
+		b := d1.Dot(&d2)
+		if denom := a*e - b*b; denom != 0 {
+			s = math.Clamp((b*f-c*e)/denom, 0, 1)
+		}
+		// Compute the point on the second segment closest to the first at s,
+		// if it's outside the segment clamp it and recompute s.
+		t = (b*s + f) / e
+		if t < 0 {
+			t = 0
+			s = math.Clamp(-c/a, 0, 1)
+		} else if t > 1 {
+			t = 1
+			s = math.Clamp((b-c)/a, 0, 1)
+		}
+	}
+
+	c1, c2 = s1.A, s2.A
+	c1.AddScaledVec(s, &d1)
+	c2.AddScaledVec(t, &d2)
+	d := c1.Sub(&c2)
+	u = d.Len2()
+	return
+}
+
+// IntersectSegmentSegment returns the position t along s1, and the point q,
+// where the two segments cross. Parallel segments never intersect, even when
+// they are collinear and overlapping.
+func IntersectSegmentSegment(s1, s2 *Segment) (t float32, q glm.Vec2, overlap bool) {
+	d := s1.B.Sub(&s1.A)
+	t, u, ok := intersectLines(&s1.A, &d, s2)
+	if !ok || t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, q, false
+	}
+	q = s1.A
+	q.AddScaledVec(t, &d)
+	return t, q, true
+}
+
+// IntersectRaySegment intersects the ray r with the segment s. When
+// intersecting it returns the intersection distance t and the point q of
+// intersection.
+func IntersectRaySegment(r *Ray, s *Segment) (t float32, q glm.Vec2, overlap bool) {
+	t, u, ok := intersectLines(&r.Origin, &r.Direction, s)
+	if !ok || t < 0 || u < 0 || u > 1 {
+		return 0, q, false
+	}
+	return t, r.At(t), true
+}
+
+// intersectLines returns t and u such that p + t*d = s.A + u*(s.B - s.A). ok is
+// false if the lines are parallel.
+func intersectLines(p, d *glm.Vec2, s *Segment) (t, u float32, ok bool) {
+	e := s.B.Sub(&s.A)
+	denom := d.Cross(&e)
+	if denom == 0 {
+		return 0, 0, false
+	}
+	w := s.A.Sub(p)
+	return w.Cross(&e) / denom, w.Cross(d) / denom, true
+}
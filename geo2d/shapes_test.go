@@ -0,0 +1,195 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestOBB_Overlap(t *testing.T) {
+	t.Parallel()
+	a := OBBFromAngle(&glm.Vec2{0, 0}, 0, &glm.Vec2{1, 1})
+	tests := []struct {
+		b    OBB
+		want bool
+	}{
+		{OBBFromAngle(&glm.Vec2{1.5, 0}, 0, &glm.Vec2{1, 1}), true},
+		{OBBFromAngle(&glm.Vec2{2.5, 0}, 0, &glm.Vec2{1, 1}), false},
+		// A diamond whose corner reaches into a.
+		{OBBFromAngle(&glm.Vec2{2.3, 0}, math.Pi/4, &glm.Vec2{1, 1}), true},
+		// Separated only along the diamond's axes.
+		{OBBFromAngle(&glm.Vec2{2.3, 2.3}, math.Pi/4, &glm.Vec2{1, 1}), false},
+	}
+	for i, test := range tests {
+		if got := TestOBBOBB(&a, &test.b); got != test.want {
+			t.Errorf("[%d] TestOBBOBB() = %t, want %t", i, got, test.want)
+		}
+		if got := TestPolygonPolygon(a.Polygon(), test.b.Polygon()); got != test.want {
+			t.Errorf("[%d] TestPolygonPolygon() = %t, want %t", i, got, test.want)
+		}
+	}
+
+	o := OBBFromAngle(&glm.Vec2{1, 1}, math.Pi/2, &glm.Vec2{2, 1})
+	p := glm.Vec2{4, 1}
+	if c, want := ClosestPointOBBPoint(&o, &p), (glm.Vec2{2, 1}); !vecNear(&c, &want, 1e-5) {
+		t.Errorf("ClosestPointOBBPoint() = %v, want %v", c, want)
+	}
+	if d := SqDistOBBPoint(&o, &p); math.Abs(d-4) > 1e-4 {
+		t.Errorf("SqDistOBBPoint() = %f, want 4", d)
+	}
+	if pa := o.Polygon(); pa.SignedArea() <= 0 || math.Abs(pa.Area()-8) > 1e-4 {
+		t.Errorf("Polygon() = %v", pa)
+	}
+}
+
+func TestRaycasts(t *testing.T) {
+	t.Parallel()
+	aabb := AABB{Center: glm.Vec2{5, 0}, HalfExtend: glm.Vec2{1, 2}}
+	obb := OBBFromAngle(&glm.Vec2{5, 0}, math.Pi/2, &glm.Vec2{2, 1})
+	circle := Circle{Center: glm.Vec2{5, 0}, Radius: 1}
+	capsule := Capsule{A: glm.Vec2{5, -3}, B: glm.Vec2{5, 3}, Radius: 1}
+	segment := Segment{A: glm.Vec2{4, -1}, B: glm.Vec2{4, 1}}
+
+	type cast func(r *Ray) (float32, glm.Vec2, bool)
+	shapes := map[string]cast{
+		"AABB":    func(r *Ray) (float32, glm.Vec2, bool) { return IntersectRayAABB(r, &aabb) },
+		"OBB":     func(r *Ray) (float32, glm.Vec2, bool) { return IntersectRayOBB(r, &obb) },
+		"Circle":  func(r *Ray) (float32, glm.Vec2, bool) { return IntersectRayCircle(r, &circle) },
+		"Capsule": func(r *Ray) (float32, glm.Vec2, bool) { return IntersectRayCapsule(r, &capsule) },
+		"Segment": func(r *Ray) (float32, glm.Vec2, bool) { return IntersectRaySegment(r, &segment) },
+	}
+	tests := []struct {
+		r       Ray
+		t       float32
+		overlap bool
+	}{
+		// All shapes have their left side at x = 4 along y = 0.
+		{Ray{glm.Vec2{0, 0}, glm.Vec2{1, 0}}, 4, true},
+		{Ray{glm.Vec2{0, 0}, glm.Vec2{2, 0}}, 2, true},
+		{Ray{glm.Vec2{0, 0}, glm.Vec2{-1, 0}}, 0, false},
+		{Ray{glm.Vec2{0, 5}, glm.Vec2{1, 0}}, 0, false},
+	}
+	for name, f := range shapes {
+		for i, test := range tests {
+			tt, q, overlap := f(&test.r)
+			if overlap != test.overlap {
+				t.Errorf("%s [%d] overlap = %t, want %t", name, i, overlap, test.overlap)
+				continue
+			}
+			if !overlap {
+				continue
+			}
+			if want := test.r.At(test.t); math.Abs(tt-test.t) > 1e-5 || !vecNear(&q, &want, 1e-5) {
+				t.Errorf("%s [%d] t, q = %f, %v, want %f, %v", name, i, tt, q, test.t, want)
+			}
+		}
+	}
+
+	// Starting inside the rectangle part of the capsule.
+	r := Ray{glm.Vec2{5, 2}, glm.Vec2{1, 0}}
+	if tt, _, overlap := IntersectRayCapsule(&r, &capsule); !overlap || tt != 0 {
+		t.Errorf("IntersectRayCapsule(inside) = %f, %t", tt, overlap)
+	}
+	// Hitting the round end of the capsule.
+	r = Ray{glm.Vec2{5, 10}, glm.Vec2{0, -1}}
+	if tt, _, overlap := IntersectRayCapsule(&r, &capsule); !overlap || math.Abs(tt-6) > 1e-5 {
+		t.Errorf("IntersectRayCapsule(end) = %f, %t, want 6", tt, overlap)
+	}
+}
+
+func TestSegment_ClosestPoints(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s1, s2 Segment
+		s, t   float32
+		u      float32
+	}{
+		{ // 0 crossing
+			Segment{glm.Vec2{-1, 0}, glm.Vec2{1, 0}}, Segment{glm.Vec2{0, -1}, glm.Vec2{0, 1}},
+			0.5, 0.5, 0,
+		},
+		{ // 1 T shape
+			Segment{glm.Vec2{-1, 0}, glm.Vec2{1, 0}}, Segment{glm.Vec2{0, 1}, glm.Vec2{0, 3}},
+			0.5, 0, 1,
+		},
+		{ // 2 end to end
+			Segment{glm.Vec2{0, 0}, glm.Vec2{1, 0}}, Segment{glm.Vec2{3, 0}, glm.Vec2{2, 1}},
+			1, 1, 2,
+		},
+		{ // 3 parallel
+			Segment{glm.Vec2{0, 0}, glm.Vec2{1, 0}}, Segment{glm.Vec2{0, 2}, glm.Vec2{1, 2}},
+			0, 0, 4,
+		},
+	}
+	for i, test := range tests {
+		s, tt, u, c1, c2 := ClosestPointSegmentSegment(&test.s1, &test.s2)
+		if math.Abs(s-test.s) > 1e-5 || math.Abs(tt-test.t) > 1e-5 || math.Abs(u-test.u) > 1e-5 {
+			t.Errorf("[%d] s, t, u = %f, %f, %f, want %f, %f, %f", i, s, tt, u, test.s, test.t, test.u)
+		}
+		d := c1.Sub(&c2)
+		if math.Abs(d.Len2()-u) > 1e-5 {
+			t.Errorf("[%d] |c1 - c2|² = %f, want %f", i, d.Len2(), u)
+		}
+	}
+
+	s1 := Segment{glm.Vec2{0, 0}, glm.Vec2{2, 2}}
+	s2 := Segment{glm.Vec2{0, 2}, glm.Vec2{2, 0}}
+	if tt, q, ok := IntersectSegmentSegment(&s1, &s2); !ok || tt != 0.5 || q != (glm.Vec2{1, 1}) {
+		t.Errorf("IntersectSegmentSegment() = %f, %v, %t", tt, q, ok)
+	}
+	s2.B = glm.Vec2{0.9, 1.1}
+	if _, _, ok := IntersectSegmentSegment(&s1, &s2); ok {
+		t.Errorf("IntersectSegmentSegment() = true for disjoint segments")
+	}
+}
+
+func TestCircle(t *testing.T) {
+	t.Parallel()
+	points := []glm.Vec2{{0, 0}, {4, 0}, {2, 3}, {1, -1}, {3, 1}}
+	c := CircleFromPoints(points)
+	for _, p := range points {
+		if d := p.Sub(&c.Center); d.Len() > c.Radius*1.0001 {
+			t.Errorf("CircleFromPoints() = %v doesn't contain %v", c, p)
+		}
+	}
+
+	a := Circle{Center: glm.Vec2{0, 0}, Radius: 1}
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"circle", TestCircleCircle(&a, &Circle{glm.Vec2{1.9, 0}, 1}), true},
+		{"circle miss", TestCircleCircle(&a, &Circle{glm.Vec2{2.1, 0}, 1}), false},
+		{"AABB corner", TestCircleAABB(&a, &AABB{glm.Vec2{1.6, 1.6}, glm.Vec2{1, 1}}), true},
+		{"AABB corner miss", TestCircleAABB(&a, &AABB{glm.Vec2{1.8, 1.8}, glm.Vec2{1, 1}}), false},
+		{"OBB", TestCircleOBB(&a, &OBB{glm.Vec2{2.9, 0}, [2]glm.Vec2{{0, 1}, {-1, 0}}, glm.Vec2{1, 2}}), true},
+		{"polygon", TestCirclePolygon(&a, Polygon{{0.9, -1}, {3, -1}, {0.9, 1}}), true},
+		{"polygon inside", TestCirclePolygon(&a, Polygon{{-5, -5}, {5, -5}, {0, 5}}), true},
+		{"segment", TestCircleSegment(&a, &Segment{glm.Vec2{-2, 1.1}, glm.Vec2{2, 1.1}}), false},
+		{"capsule", TestCapsuleCircle(&Capsule{glm.Vec2{-3, 1.5}, glm.Vec2{3, 1.5}, 0.6}, &a), true},
+		{"capsules", TestCapsuleCapsule(
+			&Capsule{glm.Vec2{0, 0}, glm.Vec2{0, 4}, 0.5},
+			&Capsule{glm.Vec2{1.2, 3}, glm.Vec2{5, 3}, 0.5}), false},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s = %t, want %t", test.name, test.got, test.want)
+		}
+	}
+
+	p := glm.Vec2{3, 4}
+	if q, want := ClosestPointCirclePoint(&a, &p), (glm.Vec2{0.6, 0.8}); !vecNear(&q, &want, 1e-5) {
+		t.Errorf("ClosestPointCirclePoint() = %v, want %v", q, want)
+	}
+	if d := SqDistCirclePoint(&a, &p); math.Abs(d-16) > 1e-4 {
+		t.Errorf("SqDistCirclePoint() = %f, want 16", d)
+	}
+	caps := Capsule{glm.Vec2{0, 0}, glm.Vec2{4, 0}, 1}
+	if q, want := ClosestPointCapsulePoint(&caps, &glm.Vec2{2, 3}), (glm.Vec2{2, 1}); !vecNear(&q, &want, 1e-5) {
+		t.Errorf("ClosestPointCapsulePoint() = %v, want %v", q, want)
+	}
+	if d := SqDistCapsulePoint(&caps, &glm.Vec2{7, 0}); math.Abs(d-4) > 1e-4 {
+		t.Errorf("SqDistCapsulePoint() = %f, want 4", d)
+	}
+}
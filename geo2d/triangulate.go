@@ -0,0 +1,225 @@
+package geo2d
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo/robust"
+)
+
+// Triangulate triangulates the simple polygon outer with the given holes by
+// ear clipping. The holes must be inside outer and must not overlap each
+// other, the windings don't matter.
+//
+// It returns the indices of the triangles, 3 per triangle in counter-clockwise
+// order. The indices refer to the vertices of outer followed by the vertices
+// of each hole, in order. Collinear vertices can be left out of the
+// triangulation.
+//
+// See Eberly "Triangulation by Ear Clipping" for how holes are bridged to the
+// outer polygon.
+func Triangulate(outer Polygon, holes ...Polygon) []int {
+	points := append([]glm.Vec2(nil), outer...)
+	poly := windingIndices(outer, 0, true)
+
+	type hole struct {
+		indices []int
+		maxX    float32
+	}
+	hs := make([]hole, 0, len(holes))
+	for _, h := range holes {
+		if len(h) < 3 {
+			points = append(points, h...)
+			continue
+		}
+		idx := windingIndices(h, len(points), false)
+		points = append(points, h...)
+		maxX := float32(-math.MaxFloat32)
+		for i := range h {
+			maxX = math.Max(maxX, h[i][0])
+		}
+		hs = append(hs, hole{idx, maxX})
+	}
+	// Bridging the rightmost holes first guarantees the bridges of the
+	// following holes can't cross them.
+	sort.Slice(hs, func(i, j int) bool { return hs[i].maxX > hs[j].maxX })
+	for _, h := range hs {
+		poly = bridgeHole(points, poly, h.indices)
+	}
+
+	return earClip(points, poly)
+}
+
+// windingIndices returns the indices of the vertices of p, offset by offset,
+// in counter-clockwise order if ccw is true or clockwise otherwise.
+func windingIndices(p Polygon, offset int, ccw bool) []int {
+	idx := make([]int, len(p))
+	for i := range idx {
+		idx[i] = offset + i
+	}
+	if (p.SignedArea() > 0) != ccw {
+		for i, j := 0, len(idx)-1; i < j; i, j = i+1, j-1 {
+			idx[i], idx[j] = idx[j], idx[i]
+		}
+	}
+	return idx
+}
+
+// inTriangle returns true if p is in or on the triangle abc, of any winding.
+func inTriangle(p, a, b, c *glm.Vec2) bool {
+	d1, d2, d3 := robust.Orient2D(a, b, p), robust.Orient2D(b, c, p), robust.Orient2D(c, a, p)
+	neg := d1 < 0 || d2 < 0 || d3 < 0
+	pos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(neg && pos)
+}
+
+// bridgeHole merges the clockwise hole into the counter-clockwise polygon poly
+// by connecting them with a pair of coincident edges.
+func bridgeHole(points []glm.Vec2, poly, hole []int) []int {
+	// The rightmost vertex of the hole.
+	mi := 0
+	for i := range hole {
+		if points[hole[i]][0] > points[hole[mi]][0] {
+			mi = i
+		}
+	}
+	m := points[hole[mi]]
+
+	// Cast a ray towards +x and find the closest edge it hits. Seen from the
+	// inside the edges go up on the right.
+	best, bx := -1, float32(math.MaxFloat32)
+	for i := range poly {
+		a, b := &points[poly[i]], &points[poly[(i+1)%len(poly)]]
+		if a[1] > m[1] || b[1] < m[1] || a[1] == b[1] {
+			continue
+		}
+		x := a[0] + (m[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+		if x >= m[0] && x < bx {
+			best, bx = i, x
+		}
+	}
+	if best < 0 {
+		// The hole isn't inside poly.
+		return poly
+	}
+
+	// The bridge goes to the endpoint of the edge with the largest x, unless
+	// a reflex vertex is in the way, then the one closest to the ray.
+	ip := glm.Vec2{bx, m[1]}
+	pi, next := best, (best+1)%len(poly)
+	if ip == points[poly[next]] || (ip != points[poly[pi]] && points[poly[next]][0] > points[poly[pi]][0]) {
+		pi = next
+	}
+	if ip != points[poly[pi]] {
+		p := points[poly[pi]]
+		bestCos, bestDist := float32(-2), float32(math.MaxFloat32)
+		for i := range poly {
+			r := &points[poly[i]]
+			if i == pi || *r == p || !inTriangle(r, &m, &ip, &p) {
+				continue
+			}
+			prev, next := &points[poly[(i+len(poly)-1)%len(poly)]], &points[poly[(i+1)%len(poly)]]
+			if robust.Orient2D(prev, r, next) >= 0 {
+				continue
+			}
+			d := r.Sub(&m)
+			l := d.Len()
+			if l == 0 {
+				continue
+			}
+			if c := d[0] / l; c > bestCos || (c == bestCos && l < bestDist) {
+				pi, bestCos, bestDist = i, c, l
+			}
+		}
+	}
+
+	// A vertex used by a previous bridge appears twice, use the occurrence
+	// whose wedge contains the hole.
+	for i := range poly {
+		if i != pi && poly[i] == poly[pi] && inCone(points, poly, i, &m) {
+			pi = i
+			break
+		}
+	}
+
+	merged := make([]int, 0, len(poly)+len(hole)+2)
+	merged = append(merged, poly[:pi+1]...)
+	for i := 0; i <= len(hole); i++ {
+		merged = append(merged, hole[(mi+i)%len(hole)])
+	}
+	merged = append(merged, poly[pi:]...)
+	return merged
+}
+
+// inCone returns true if p is strictly inside the interior angle of vertex i
+// of the counter-clockwise polygon poly.
+func inCone(points []glm.Vec2, poly []int, i int, p *glm.Vec2) bool {
+	a := &points[poly[(i+len(poly)-1)%len(poly)]]
+	b := &points[poly[i]]
+	c := &points[poly[(i+1)%len(poly)]]
+	if robust.Orient2D(a, b, c) >= 0 {
+		return robust.Orient2D(a, b, p) > 0 && robust.Orient2D(b, c, p) > 0
+	}
+	return robust.Orient2D(a, b, p) > 0 || robust.Orient2D(b, c, p) > 0
+}
+
+// earClip triangulates the counter-clockwise polygon poly.
+func earClip(points []glm.Vec2, poly []int) []int {
+	n := len(poly)
+	if n < 3 {
+		return nil
+	}
+	tris := make([]int, 0, 3*(n-2))
+	prev, next := make([]int, n), make([]int, n)
+	for i := range poly {
+		prev[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+	remove := func(i int) {
+		next[prev[i]], prev[next[i]] = next[i], prev[i]
+		n--
+	}
+
+	isEar := func(i int) bool {
+		a, b, c := &points[poly[prev[i]]], &points[poly[i]], &points[poly[next[i]]]
+		// Only reflex vertices can be inside an ear.
+		for j := next[next[i]]; j != prev[i]; j = next[j] {
+			p := &points[poly[j]]
+			if *p == *a || *p == *b || *p == *c {
+				continue
+			}
+			if robust.Orient2D(&points[poly[prev[j]]], p, &points[poly[next[j]]]) <= 0 && inTriangle(p, a, b, c) {
+				return false
+			}
+		}
+		return true
+	}
+
+	i, stall := 0, 0
+	for n > 3 {
+		o := robust.Orient2D(&points[poly[prev[i]]], &points[poly[i]], &points[poly[next[i]]])
+		switch {
+		case o == 0:
+			// A collinear vertex, or a spike, doesn't add any area.
+			remove(i)
+			i, stall = prev[i], 0
+		case o > 0 && (isEar(i) || stall >= n):
+			// If no ear was found on a full turn, rounding errors made the
+			// polygon not simple, clip the first convex vertex anyway.
+			tris = append(tris, poly[prev[i]], poly[i], poly[next[i]])
+			remove(i)
+			i, stall = prev[i], 0
+		default:
+			i = next[i]
+			stall++
+			if stall > 3*n {
+				// Only reflex vertices left.
+				return tris
+			}
+		}
+	}
+	if robust.Orient2D(&points[poly[prev[i]]], &points[poly[i]], &points[poly[next[i]]]) > 0 {
+		tris = append(tris, poly[prev[i]], poly[i], poly[next[i]])
+	}
+	return tris
+}
@@ -0,0 +1,88 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestTriangulate(t *testing.T) {
+	t.Parallel()
+	square := func(x, y, s float32) Polygon {
+		return Polygon{{x, y}, {x + s, y}, {x + s, y + s}, {x, y + s}}
+	}
+	tests := []struct {
+		outer Polygon
+		holes []Polygon
+	}{
+		{ // 0 convex
+			outer: square(0, 0, 1),
+		},
+		{ // 1 U shape, clockwise
+			outer: Polygon{{0, 3}, {1, 3}, {1, 1}, {2, 1}, {2, 3}, {3, 3}, {3, 0}, {0, 0}},
+		},
+		{ // 2 one hole, the ray from the hole hits a vertex
+			outer: Polygon{{0, 0}, {4, 0}, {4, 2}, {5, 4}, {0, 4}},
+			holes: []Polygon{square(1, 1, 1)},
+		},
+		{ // 3 two holes, bridged through each other
+			outer: square(0, 0, 10),
+			holes: []Polygon{square(1, 4, 2), Polygon{{5, 4}, {5, 6}, {7, 6}, {7, 4}}},
+		},
+		{ // 4 a comb
+			outer: Polygon{{0, 0}, {7, 0}, {7, 3}, {6, 3}, {6, 1}, {5, 1}, {5, 3}, {4, 3}, {4, 1}, {3, 1}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}},
+			holes: []Polygon{{{0.5, 0.3}, {6.5, 0.3}, {6.5, 0.7}, {0.5, 0.7}}},
+		},
+	}
+	for i, test := range tests {
+		points := append([]glm.Vec2(nil), test.outer...)
+		want := test.outer.Area()
+		vertices := len(test.outer)
+		for _, h := range test.holes {
+			points = append(points, h...)
+			want -= h.Area()
+			vertices += len(h)
+		}
+
+		tris := Triangulate(test.outer, test.holes...)
+		if n, want := len(tris)/3, vertices+2*len(test.holes)-2; n != want {
+			t.Errorf("[%d] %d triangles, want %d", i, n, want)
+		}
+		var area float32
+		for j := 0; j < len(tris); j += 3 {
+			tri := Polygon{points[tris[j]], points[tris[j+1]], points[tris[j+2]]}
+			a := tri.SignedArea()
+			if a <= 0 {
+				t.Errorf("[%d] triangle %v isn't counter-clockwise", i, tri)
+			}
+			area += a
+			c := tri.Centroid()
+			r := Region{Outer: test.outer, Holes: test.holes}
+			if !r.Contains(&c) {
+				t.Errorf("[%d] triangle %v is outside the polygon", i, tri)
+			}
+		}
+		if math.Abs(area-want) > 1e-4 {
+			t.Errorf("[%d] area = %f, want %f", i, area, want)
+		}
+
+		pieces := ConvexDecomposition(test.outer, test.holes...)
+		area = 0
+		for _, p := range pieces {
+			if !p.IsConvex() || p.SignedArea() <= 0 {
+				t.Errorf("[%d] piece %v isn't convex and counter-clockwise", i, p)
+			}
+			area += p.Area()
+		}
+		if math.Abs(area-want) > 1e-4 {
+			t.Errorf("[%d] pieces area = %f, want %f", i, area, want)
+		}
+		if len(pieces) > len(tris)/3 {
+			t.Errorf("[%d] %d pieces for %d triangles", i, len(pieces), len(tris)/3)
+		}
+	}
+
+	if pieces := ConvexDecomposition(tests[1].outer); len(pieces) != 3 {
+		t.Errorf("ConvexDecomposition(U) = %d pieces, want 3", len(pieces))
+	}
+}
@@ -0,0 +1,121 @@
+package geo2d
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Circumcenter returns the center of the circumcircle of the triangle t, the
+// vertex of the Voronoi diagram dual to it.
+func (tr *Triangulation) Circumcenter(t int) glm.Vec2 {
+	a, b, c := &tr.Points[tr.Triangles[3*t]], &tr.Points[tr.Triangles[3*t+1]], &tr.Points[tr.Triangles[3*t+2]]
+	bx, by := float64(b[0])-float64(a[0]), float64(b[1])-float64(a[1])
+	cx, cy := float64(c[0])-float64(a[0]), float64(c[1])-float64(a[1])
+	bl, cl := bx*bx+by*by, cx*cx+cy*cy
+	d := 0.5 / (bx*cy - by*cx)
+	return glm.Vec2{
+		a[0] + float32((cy*bl-by*cl)*d),
+		a[1] + float32((bx*cl-cx*bl)*d),
+	}
+}
+
+// Voronoi returns the Voronoi cell of each point, the region closer to it than
+// to any other point, clipped to bounds. The cells are convex and
+// counter-clockwise. The cells of the points not used by any triangle are nil.
+func (tr *Triangulation) Voronoi(bounds *AABB) []Polygon {
+	// An outgoing half-edge of each point, the one on the hull for the hull
+	// points so turning counter-clockwise from it visits all their triangles.
+	start := make([]int, len(tr.Points))
+	for i := range start {
+		start[i] = -1
+	}
+	for e, p := range tr.Triangles {
+		if start[p] < 0 || tr.Halfedges[e] < 0 {
+			start[p] = e
+		}
+	}
+
+	centers := make([]glm.Vec2, len(tr.Triangles)/3)
+	box := *bounds
+	for t := range centers {
+		centers[t] = tr.Circumcenter(t)
+		box = mergeAABB(&box, &centers[t])
+	}
+	// Far enough that the unbounded cells are closed outside bounds.
+	far := 100 * (box.HalfExtend.Len() + 1)
+
+	cells := make([]Polygon, len(tr.Points))
+	for p, e0 := range start {
+		if e0 < 0 {
+			continue
+		}
+		var cell Polygon
+		e := e0
+		for {
+			cell = append(cell, centers[e/3])
+			next := tr.Halfedges[PrevHalfedge(e)]
+			if next < 0 || next == e0 {
+				break
+			}
+			e = next
+		}
+
+		if tr.Halfedges[e0] < 0 {
+			// A hull point, the cell extends to infinity between the
+			// outward normals of the 2 hull edges.
+			q := tr.Points[tr.Triangles[NextHalfedge(e0)]]
+			r := tr.Points[tr.Triangles[PrevHalfedge(e)]]
+			n0, n1 := q.Sub(&tr.Points[p]), tr.Points[p].Sub(&r)
+			n0, n1 = glm.Vec2{n0[1], -n0[0]}, glm.Vec2{n1[1], -n1[0]}
+			n0.Normalize()
+			n1.Normalize()
+			first, end := cell[0], cell[len(cell)-1]
+			first.AddScaledVec(far, &n0)
+			end.AddScaledVec(far, &n1)
+			cell = append(Polygon{first}, cell...)
+			cell = append(cell, end)
+		}
+		cells[p] = clipAABB(cell, bounds)
+	}
+	return cells
+}
+
+// mergeAABB returns the AABB containing a and p.
+func mergeAABB(a *AABB, p *glm.Vec2) AABB {
+	var min, max glm.Vec2
+	for i := 0; i < 2; i++ {
+		min[i] = math.Min(a.Center[i]-a.HalfExtend[i], p[i])
+		max[i] = math.Max(a.Center[i]+a.HalfExtend[i], p[i])
+	}
+	return AABBFromPoints([]glm.Vec2{min, max})
+}
+
+// clipAABB clips the convex polygon p to the AABB a with the
+// Sutherland-Hodgman algorithm.
+func clipAABB(p Polygon, a *AABB) Polygon {
+	for axis := 0; axis < 2; axis++ {
+		for _, side := range [2]float32{-1, 1} {
+			// Keep the points with side*x <= side*limit.
+			limit := a.Center[axis] + side*a.HalfExtend[axis]
+			var out Polygon
+			for i := range p {
+				cur, next := p[i], p[(i+1)%len(p)]
+				din, dnext := side*(cur[axis]-limit), side*(next[axis]-limit)
+				if din <= 0 {
+					out = append(out, cur)
+				}
+				if (din < 0 && dnext > 0) || (din > 0 && dnext < 0) {
+					d := next.Sub(&cur)
+					q := cur
+					q.AddScaledVec(din/(din-dnext), &d)
+					out = append(out, q)
+				}
+			}
+			p = out
+			if len(p) == 0 {
+				return nil
+			}
+		}
+	}
+	return p
+}
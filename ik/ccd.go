@@ -0,0 +1,36 @@
+package ik
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// CCD solves c with cyclic coordinate descent. Each iteration turns every
+// joint, from the end effector to the first one, so the end effector points
+// at target, within the joint constraints. It stops after iterations
+// iterations or once the end effector is within tolerance of target, and
+// returns whether it is.
+//
+// CCD is cheap and handles constraints well, but favors the joints close to
+// the end effector which can make poses curl.
+func (c *Chain) CCD(target *glm.Vec3, tolerance float32, iterations int) bool {
+	n := len(c.Positions)
+	tol2 := tolerance * tolerance
+	for it := 0; it < iterations; it++ {
+		for i := n - 2; i >= 0; i-- {
+			end := c.End()
+			if d := end.Sub(target); d.Len2() <= tol2 {
+				return true
+			}
+			toEnd := end.Sub(&c.Positions[i])
+			toTarget := target.Sub(&c.Positions[i])
+			if toEnd.Len2() < 1e-12 || toTarget.Len2() < 1e-12 {
+				continue
+			}
+			q := glm.QuatBetweenVectors(&toEnd, &toTarget)
+			c.turn(i, &q)
+		}
+	}
+	end := c.End()
+	d := end.Sub(target)
+	return d.Len2() <= tol2
+}
@@ -0,0 +1,109 @@
+// Package ik implements inverse kinematics solvers on chains of joints: the
+// analytic two bone solver, cyclic coordinate descent (CCD) and forward and
+// backward reaching inverse kinematics (FABRIK).
+package ik
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Constraint limits the rotation of a joint. It receives the rotation of the
+// joint relative to its parent and returns the closest allowed one.
+type Constraint func(local glm.Quat) glm.Quat
+
+// Hinge returns a constraint keeping only the rotation around axis, with an
+// angle between min and max radians. axis is relative to the parent and must
+// be normalized.
+func Hinge(axis *glm.Vec3, min, max float32) Constraint {
+	a := *axis
+	return func(local glm.Quat) glm.Quat {
+		_, twist := local.SwingTwist(&a)
+		return glm.ClampTwist(&twist, &a, min, max)
+	}
+}
+
+// SwingTwist returns a constraint limiting the twist around axis between
+// twistMin and twistMax radians and the swing of axis to a cone of half angle
+// swingMax, see glm.ConstrainSwingTwist. axis is relative to the parent and
+// must be normalized.
+func SwingTwist(axis *glm.Vec3, twistMin, twistMax, swingMax float32) Constraint {
+	a := *axis
+	return func(local glm.Quat) glm.Quat {
+		return glm.ConstrainSwingTwist(&local, &a, twistMin, twistMax, swingMax)
+	}
+}
+
+// Chain is a chain of joints, each the parent of the next, the last one being
+// the end effector. The solvers move the joints by rotating them, the first
+// joint stays in place and the bone lengths are kept.
+type Chain struct {
+	// Positions are the model space positions of the joints.
+	Positions []glm.Vec3
+	// Rotations are the model space rotations of the joints. Rotating a
+	// joint rotates the bones after it.
+	Rotations []glm.Quat
+	// Root is the model space rotation of the parent of the first joint.
+	Root glm.Quat
+	// Constraints limit the rotation of each joint relative to its parent,
+	// nil for free joints. The slice may be shorter than the chain.
+	Constraints []Constraint
+}
+
+// NewChain returns a chain of the joints at positions with the given model
+// space rotations, without constraints and the identity as Root.
+func NewChain(positions []glm.Vec3, rotations []glm.Quat) *Chain {
+	if len(positions) != len(rotations) {
+		panic("ik: as many positions as rotations are needed")
+	}
+	return &Chain{Positions: positions, Rotations: rotations, Root: glm.QuatIdent()}
+}
+
+// End returns the position of the end effector of c.
+func (c *Chain) End() glm.Vec3 {
+	return c.Positions[len(c.Positions)-1]
+}
+
+// parent returns the model space rotation of the parent of joint i.
+func (c *Chain) parent(i int) glm.Quat {
+	if i == 0 {
+		return c.Root
+	}
+	return c.Rotations[i-1]
+}
+
+// constrain returns the model space rotation r of joint i limited by its
+// constraint.
+func (c *Chain) constrain(i int, r glm.Quat) glm.Quat {
+	if i >= len(c.Constraints) || c.Constraints[i] == nil {
+		return r
+	}
+	p := c.parent(i)
+	inv := p.Conjugated()
+	local := inv.Mul(&r)
+	local = c.Constraints[i](local)
+	return p.Mul(&local)
+}
+
+// rotate rotates joint i by q, in model space around its position, moving the
+// joints after it.
+func (c *Chain) rotate(i int, q *glm.Quat) {
+	origin := c.Positions[i]
+	for j := i; j < len(c.Positions); j++ {
+		if j > i {
+			d := c.Positions[j].Sub(&origin)
+			d = q.Rotate(&d)
+			c.Positions[j] = origin.Add(&d)
+		}
+		c.Rotations[j] = q.Mul(&c.Rotations[j])
+		c.Rotations[j].Normalize()
+	}
+}
+
+// turn rotates joint i by q limited by the joint constraint.
+func (c *Chain) turn(i int, q *glm.Quat) {
+	r := q.Mul(&c.Rotations[i])
+	r = c.constrain(i, r)
+	inv := c.Rotations[i].Conjugated()
+	delta := r.Mul(&inv)
+	c.rotate(i, &delta)
+}
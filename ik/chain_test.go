@@ -0,0 +1,77 @@
+package ik
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// straight returns a chain of n joints along X, one unit apart.
+func straight(n int) *Chain {
+	positions := make([]glm.Vec3, n)
+	rotations := make([]glm.Quat, n)
+	for i := range positions {
+		positions[i] = glm.Vec3{float32(i), 0, 0}
+		rotations[i] = glm.QuatIdent()
+	}
+	return NewChain(positions, rotations)
+}
+
+// checkChain checks that c is still rigid compared to the chain before: the
+// first joint didn't move and the bones, seen from their joint, didn't change.
+func checkChain(t *testing.T, name string, before, c *Chain) {
+	t.Helper()
+	if d := c.Positions[0].Sub(&before.Positions[0]); d.Len() > 1e-5 {
+		t.Errorf("%s: first joint moved to %v", name, c.Positions[0])
+	}
+	for i := 0; i+1 < len(c.Positions); i++ {
+		b0 := before.Positions[i+1].Sub(&before.Positions[i])
+		inv := before.Rotations[i].Conjugated()
+		b0 = inv.Rotate(&b0)
+		b := c.Positions[i+1].Sub(&c.Positions[i])
+		inv = c.Rotations[i].Conjugated()
+		b = inv.Rotate(&b)
+		if d := b.Sub(&b0); d.Len() > 1e-3 {
+			t.Errorf("%s: bone %d = %v in its joint, was %v", name, i, b, b0)
+		}
+	}
+}
+
+// clone returns a deep copy of c.
+func clone(c *Chain) *Chain {
+	d := *c
+	d.Positions = append([]glm.Vec3(nil), c.Positions...)
+	d.Rotations = append([]glm.Quat(nil), c.Rotations...)
+	return &d
+}
+
+func TestHinge(t *testing.T) {
+	t.Parallel()
+	z := glm.Vec3{0, 0, 1}
+	hinge := Hinge(&z, 0, math.Pi/2)
+	tests := []struct {
+		angle float32
+		axis  glm.Vec3
+		want  float32
+	}{
+		{0.5, glm.Vec3{0, 0, 1}, 0.5},
+		{2, glm.Vec3{0, 0, 1}, math.Pi / 2},
+		{-0.5, glm.Vec3{0, 0, 1}, 0},
+		{0.5, glm.Vec3{1, 0, 0}, 0},
+	}
+	for i, test := range tests {
+		q := glm.QuatRotate(test.angle, &test.axis)
+		want := glm.QuatRotate(test.want, &z)
+		if got := hinge(q); !got.OrientationEqualThreshold(&want, 1e-4) {
+			t.Errorf("[%d] hinge(%v) = %v, want %v", i, q, got, want)
+		}
+	}
+
+	x := glm.Vec3{1, 0, 0}
+	cone := SwingTwist(&x, -0.1, 0.1, 0.5)
+	q := glm.QuatRotate(1, &z)
+	got := cone(q)
+	if d := got.Rotate(&x); math.Acos(math.Clamp(d.Dot(&x), -1, 1)) > 0.5+1e-4 {
+		t.Errorf("cone(%v) = %v, swings %v", q, got, d)
+	}
+}
@@ -0,0 +1,68 @@
+package ik
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// FABRIK solves c with forward and backward reaching inverse kinematics. Each
+// iteration moves the end effector on target and drags the joints behind it
+// keeping the bone lengths, then moves the first joint back in place and
+// drags the joints the other way. The joints are then rotated to follow the
+// new positions, within their constraints. It stops after iterations
+// iterations or once the end effector is within tolerance of target, and
+// returns whether it is.
+//
+// FABRIK converges in fewer iterations than CCD and spreads the rotation
+// along the chain, giving more natural poses.
+func (c *Chain) FABRIK(target *glm.Vec3, tolerance float32, iterations int) bool {
+	n := len(c.Positions)
+	tol2 := tolerance * tolerance
+	lengths := make([]float32, n-1)
+	for i := range lengths {
+		d := c.Positions[i+1].Sub(&c.Positions[i])
+		lengths[i] = d.Len()
+	}
+	pos := make([]glm.Vec3, n)
+	root := c.Positions[0]
+
+	for it := 0; it < iterations; it++ {
+		end := c.End()
+		if d := end.Sub(target); d.Len2() <= tol2 {
+			return true
+		}
+		copy(pos, c.Positions)
+
+		pos[n-1] = *target
+		for i := n - 2; i >= 0; i-- {
+			reach(&pos[i], &pos[i+1], lengths[i])
+		}
+		pos[0] = root
+		for i := 0; i < n-1; i++ {
+			reach(&pos[i+1], &pos[i], lengths[i])
+		}
+
+		for i := 0; i < n-1; i++ {
+			bone := c.Positions[i+1].Sub(&c.Positions[i])
+			want := pos[i+1].Sub(&c.Positions[i])
+			if bone.Len2() < 1e-12 || want.Len2() < 1e-12 {
+				continue
+			}
+			q := glm.QuatBetweenVectors(&bone, &want)
+			c.turn(i, &q)
+		}
+	}
+	end := c.End()
+	d := end.Sub(target)
+	return d.Len2() <= tol2
+}
+
+// reach moves p on the line from anchor to p at distance length from anchor.
+func reach(p, anchor *glm.Vec3, length float32) {
+	d := p.Sub(anchor)
+	l := d.Len()
+	if l < 1e-6 {
+		return
+	}
+	*p = *anchor
+	p.AddScaledVec(length/l, &d)
+}
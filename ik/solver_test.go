@@ -0,0 +1,73 @@
+package ik
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestSolvers(t *testing.T) {
+	t.Parallel()
+	solvers := []struct {
+		name  string
+		solve func(c *Chain, target *glm.Vec3) bool
+	}{
+		{"CCD", func(c *Chain, target *glm.Vec3) bool { return c.CCD(target, 1e-3, 100) }},
+		{"FABRIK", func(c *Chain, target *glm.Vec3) bool { return c.FABRIK(target, 1e-3, 100) }},
+	}
+	targets := []glm.Vec3{{1.5, 1.5, 0.5}, {0, 2, 0}, {-1, 0.5, 1}, {2.9, 0.1, 0}}
+	for _, s := range solvers {
+		for i, target := range targets {
+			c := straight(4)
+			before := clone(c)
+			if !s.solve(c, &target) {
+				t.Errorf("%s: [%d] didn't reach %v, end = %v", s.name, i, target, c.End())
+			}
+			checkChain(t, s.name, before, c)
+		}
+
+		// Out of reach, the chain points at the target.
+		c := straight(4)
+		before := clone(c)
+		target := glm.Vec3{0, 10, 0}
+		if s.solve(c, &target) {
+			t.Errorf("%s: reached %v", s.name, target)
+		}
+		checkChain(t, s.name, before, c)
+		if end := c.End(); end[1] < 2.9 {
+			t.Errorf("%s: end = %v, want close to [0 3 0]", s.name, end)
+		}
+	}
+}
+
+func TestSolvers_Constraints(t *testing.T) {
+	t.Parallel()
+	z := glm.Vec3{0, 0, 1}
+	hinge := Hinge(&z, 0, math.Pi/2)
+	solvers := []string{"CCD", "FABRIK"}
+	for _, name := range solvers {
+		c := straight(4)
+		c.Constraints = []Constraint{nil, hinge, hinge}
+		before := clone(c)
+		// Reachable bending one way only.
+		target := glm.Vec3{1, 1.5, 0}
+		var reached bool
+		if name == "CCD" {
+			reached = c.CCD(&target, 1e-3, 200)
+		} else {
+			reached = c.FABRIK(&target, 1e-3, 200)
+		}
+		if !reached {
+			t.Errorf("%s: didn't reach %v, end = %v", name, target, c.End())
+		}
+		checkChain(t, name, before, c)
+		for i := 1; i < 3; i++ {
+			inv := c.Rotations[i-1].Conjugated()
+			local := inv.Mul(&c.Rotations[i])
+			want := hinge(local)
+			if !local.OrientationEqualThreshold(&want, 1e-3) {
+				t.Errorf("%s: joint %d rotation %v breaks its constraint", name, i, local)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package ik
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// TwoBone solves a chain of 3 joints, like a hip, a knee and an ankle,
+// analytically. The middle joint bends towards pole, a point in model space,
+// in the plane of the first joint, the target and the pole. The constraints
+// are ignored, the pole is what controls the bending.
+//
+// If the target is out of reach the chain is stretched towards it and
+// TwoBone returns false.
+func (c *Chain) TwoBone(target, pole *glm.Vec3) bool {
+	if len(c.Positions) != 3 {
+		panic("ik: TwoBone needs a chain of 3 joints")
+	}
+	a, b, end := c.Positions[0], c.Positions[1], c.Positions[2]
+	ab, bc := b.Sub(&a), end.Sub(&b)
+	l1, l2 := ab.Len(), bc.Len()
+
+	at := target.Sub(&a)
+	d := at.Len()
+	if d < 1e-6 {
+		return false
+	}
+	dir := at.Mul(1 / d)
+	min, max := math.Abs(l1-l2), l1+l2
+	reached := d >= min && d <= max
+	d = math.Clamp(d, min, max)
+
+	// The direction in which the middle joint bends, perpendicular to the
+	// target direction. Without a usable pole keep the current bending.
+	bend := pole.Sub(&a)
+	bend.AddScaledVec(-bend.Dot(&dir), &dir)
+	if bend.Len2() < 1e-12 {
+		bend = ab
+		bend.AddScaledVec(-bend.Dot(&dir), &dir)
+	}
+	if bend.Len2() < 1e-12 {
+		bend = perpendicular(&dir)
+	}
+	bend.Normalize()
+
+	// Law of cosines for the angle at the first joint. Its sine is computed
+	// from factors that don't cancel when the chain is nearly straight or
+	// folded.
+	var cos, sin float32 = 1, 0
+	if d > 0 && l1 > 0 {
+		cos = math.Clamp((l1*l1+d*d-l2*l2)/(2*l1*d), -1, 1)
+		s2 := (d + l1 - l2) * (d - l1 + l2) * (l1 + l2 - d) * (l1 + l2 + d)
+		sin = math.Sqrt(math.Max(s2, 0)) / (2 * l1 * d)
+	}
+	ab2 := dir.Mul(l1 * cos)
+	ab2.AddScaledVec(l1*sin, &bend)
+
+	// A bone turning half a turn turns about the normal of the plane of the
+	// target direction and the bend direction.
+	normal := dir.Cross(&bend)
+	q := rotation(&ab, &ab2, &normal)
+	c.rotate(0, &q)
+	// The end effector goes at distance d along the target direction.
+	end = c.Positions[2]
+	b = c.Positions[1]
+	bc = end.Sub(&b)
+	goal := a
+	goal.AddScaledVec(d, &dir)
+	bc2 := goal.Sub(&b)
+	q = rotation(&bc, &bc2, &normal)
+	c.rotate(1, &q)
+	return reached
+}
+
+// rotation returns the rotation of the direction of from to the direction of
+// to, an angle about an explicit axis. Opposite directions turn half a turn
+// about normal made perpendicular to from.
+func rotation(from, to, normal *glm.Vec3) glm.Quat {
+	if from.Len2() == 0 || to.Len2() == 0 {
+		return glm.QuatIdent()
+	}
+	f, t := from.Normalized(), to.Normalized()
+	if f.Dot(&t) < 0 {
+		// The axis of the cross product is inaccurate when the directions
+		// are nearly opposite, turn half a turn first.
+		axis := *normal
+		axis.AddScaledVec(-axis.Dot(&f), &f)
+		if axis.Len2() < 1e-12 {
+			axis = perpendicular(&f)
+		}
+		axis.Normalize()
+		half := glm.QuatRotate(math.Pi, &axis)
+		f.MulWith(-1)
+		q := rotation(&f, &t, normal)
+		return q.Mul(&half)
+	}
+	axis := f.Cross(&t)
+	sin := axis.Len()
+	if sin == 0 {
+		return glm.QuatIdent()
+	}
+	axis.MulWith(1 / sin)
+	return glm.QuatRotate(math.Atan2(sin, f.Dot(&t)), &axis)
+}
+
+// perpendicular returns a unit vector perpendicular to the unit vector v.
+func perpendicular(v *glm.Vec3) glm.Vec3 {
+	axis := glm.Vec3{1, 0, 0}
+	if math.Abs(v[0]) > 0.9 {
+		axis = glm.Vec3{0, 1, 0}
+	}
+	p := v.Cross(&axis)
+	p.Normalize()
+	return p
+}
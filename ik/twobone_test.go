@@ -0,0 +1,92 @@
+package ik
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestChain_TwoBone(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		target, pole glm.Vec3
+		reached      bool
+	}{
+		{glm.Vec3{1, 1, 0}, glm.Vec3{0, 5, 0}, true},
+		{glm.Vec3{1, 1, 0}, glm.Vec3{0, -5, 0}, true},
+		{glm.Vec3{0.5, 0.5, 1}, glm.Vec3{0, 0, -3}, true},
+		{glm.Vec3{0, 3, 0}, glm.Vec3{1, 0, 0}, false},
+	}
+	for i, test := range tests {
+		c := straight(3)
+		before := clone(c)
+		if got := c.TwoBone(&test.target, &test.pole); got != test.reached {
+			t.Errorf("[%d] TwoBone() = %t, want %t", i, got, test.reached)
+		}
+		checkChain(t, "TwoBone", before, c)
+
+		end := c.End()
+		want := test.target
+		if !test.reached {
+			want = test.target.Normalized()
+			want.MulWith(2)
+		}
+		if d := end.Sub(&want); d.Len() > 1e-4 {
+			t.Errorf("[%d] end = %v, want %v", i, end, want)
+		}
+
+		// The middle joint is on the side of the pole.
+		if test.reached {
+			dir := test.target.Normalized()
+			mid := c.Positions[1]
+			mid.AddScaledVec(-mid.Dot(&dir), &dir)
+			if mid.Dot(&test.pole) <= 0 {
+				t.Errorf("[%d] middle joint %v not towards the pole %v", i, c.Positions[1], test.pole)
+			}
+		}
+	}
+}
+
+func TestChain_TwoBone_Folded(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		target, pole glm.Vec3
+	}{
+		// The knee is almost fully folded, the second bone turns nearly
+		// half a turn.
+		{"folded", glm.Vec3{1.0001, 0, 0}, glm.Vec3{0, 1, 0}},
+		{"folded off axis", glm.Vec3{0, 1.0001, 0}, glm.Vec3{1, 0, 1}},
+		// The target is behind the first joint, the first bone flips.
+		{"flip", glm.Vec3{-2.999, 0.001, 0}, glm.Vec3{0, 0, 1}},
+		{"flip exactly", glm.Vec3{-2.5, 0, 0}, glm.Vec3{0, 1, 0}},
+	}
+	for _, test := range tests {
+		// Bones of length 2 and 1.
+		c := NewChain([]glm.Vec3{{0, 0, 0}, {2, 0, 0}, {3, 0, 0}}, []glm.Quat{glm.QuatIdent(), glm.QuatIdent(), glm.QuatIdent()})
+		before := clone(c)
+		if !c.TwoBone(&test.target, &test.pole) {
+			t.Errorf("%s: TwoBone() = false, want true", test.name)
+		}
+		checkChain(t, test.name, before, c)
+		end := c.End()
+		if d := end.Sub(&test.target); d.Len() > 1e-5 {
+			t.Errorf("%s: end = %v, want %v", test.name, end, test.target)
+		}
+		for i := 0; i < 2; i++ {
+			bone := c.Positions[i+1].Sub(&c.Positions[i])
+			if l := bone.Len(); math.Abs(l-float32(2-i)) > 1e-5 {
+				t.Errorf("%s: bone %d has length %f", test.name, i, l)
+			}
+		}
+		// The middle joint is in the plane of the target and the pole, on
+		// the side of the pole.
+		dir := test.target.Normalized()
+		mid := c.Positions[1]
+		mid.AddScaledVec(-mid.Dot(&dir), &dir)
+		normal := dir.Cross(&test.pole)
+		if math.Abs(mid.Dot(&normal)) > 1e-4 || mid.Dot(&test.pole) <= 0 {
+			t.Errorf("%s: middle joint %v not towards the pole %v", test.name, c.Positions[1], test.pole)
+		}
+	}
+}
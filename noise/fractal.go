@@ -0,0 +1,156 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// octaveOffset shifts each octave so their lattices don't line up at the
+// origin.
+var octaveOffset = glm.Vec4{19.19, 7.31, 13.57, 3.71}
+
+// warpOffsets decorrelate the components of the domain warp displacement.
+var warpOffsets = [4]glm.Vec4{
+	{0, 0, 0, 0},
+	{5.2, 1.3, 7.7, 2.9},
+	{9.1, 4.7, 3.3, 8.6},
+	{2.5, 8.3, 6.1, 1.7},
+}
+
+// Fractal sums octaves of a noise at increasing frequencies and decreasing
+// amplitudes. The zero value is not usable, see NewFractal.
+type Fractal struct {
+	// Octaves is the number of noises summed.
+	Octaves int
+	// Lacunarity multiplies the frequency from an octave to the next,
+	// usually 2.
+	Lacunarity float32
+	// Gain multiplies the amplitude from an octave to the next, usually
+	// 0.5.
+	Gain float32
+}
+
+// NewFractal returns a fractal of octaves octaves with a lacunarity of 2 and
+// a gain of 0.5.
+func NewFractal(octaves int) Fractal {
+	return Fractal{Octaves: octaves, Lacunarity: 2, Gain: 0.5}
+}
+
+// sum returns the sum of the octaves of noise, normalized by the sum of the
+// amplitudes. shape transforms the noise of each octave.
+func (f *Fractal) sum(noise func(frequency float32, octave int) float32, shape func(float32) float32) float32 {
+	var sum, total float32
+	var frequency, amplitude float32 = 1, 1
+	for i := 0; i < f.Octaves; i++ {
+		sum += amplitude * shape(noise(frequency, i))
+		total += amplitude
+		frequency *= f.Lacunarity
+		amplitude *= f.Gain
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// identity returns x.
+func identity(x float32) float32 { return x }
+
+// ridge folds the noise x in [-1, 1] into sharp ridges in [0, 1].
+func ridge(x float32) float32 {
+	r := 1 - math.Abs(x)
+	return r * r
+}
+
+// at returns v scaled by frequency and shifted for octave.
+func at(v *glm.Vec4, frequency float32, octave int) glm.Vec4 {
+	p := v.Mul(frequency)
+	p.AddScaledVec(float32(octave), &octaveOffset)
+	return p
+}
+
+// FBm2 returns the fractal Brownian motion of n at v, the sum of its octaves.
+// It's in the range of n.
+func (f *Fractal) FBm2(n Source2, v *glm.Vec2) float32 {
+	v4 := v.Vec4(0, 0)
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(&v4, frequency, octave)
+		return n.Noise2(&glm.Vec2{p[0], p[1]})
+	}, identity)
+}
+
+// FBm3 returns the fractal Brownian motion of n at v, see FBm2.
+func (f *Fractal) FBm3(n Source3, v *glm.Vec3) float32 {
+	v4 := v.Vec4(0)
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(&v4, frequency, octave)
+		return n.Noise3(&glm.Vec3{p[0], p[1], p[2]})
+	}, identity)
+}
+
+// FBm4 returns the fractal Brownian motion of n at v, see FBm2.
+func (f *Fractal) FBm4(n Source4, v *glm.Vec4) float32 {
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(v, frequency, octave)
+		return n.Noise4(&p)
+	}, identity)
+}
+
+// Ridged2 returns the ridged fractal of n at v, the sum of its octaves folded
+// into ridges where n crosses 0. n must be in [-1, 1], the result is in
+// [0, 1].
+func (f *Fractal) Ridged2(n Source2, v *glm.Vec2) float32 {
+	v4 := v.Vec4(0, 0)
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(&v4, frequency, octave)
+		return n.Noise2(&glm.Vec2{p[0], p[1]})
+	}, ridge)
+}
+
+// Ridged3 returns the ridged fractal of n at v, see Ridged2.
+func (f *Fractal) Ridged3(n Source3, v *glm.Vec3) float32 {
+	v4 := v.Vec4(0)
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(&v4, frequency, octave)
+		return n.Noise3(&glm.Vec3{p[0], p[1], p[2]})
+	}, ridge)
+}
+
+// Ridged4 returns the ridged fractal of n at v, see Ridged2.
+func (f *Fractal) Ridged4(n Source4, v *glm.Vec4) float32 {
+	return f.sum(func(frequency float32, octave int) float32 {
+		p := at(v, frequency, octave)
+		return n.Noise4(&p)
+	}, ridge)
+}
+
+// Warp2 returns n at v displaced by warp, each component of the displacement
+// being warp sampled at a different offset and scaled by amount.
+func Warp2(n, warp Source2, v *glm.Vec2, amount float32) float32 {
+	var p glm.Vec2
+	for k := range p {
+		o := glm.Vec2{v[0] + warpOffsets[k][0], v[1] + warpOffsets[k][1]}
+		p[k] = v[k] + amount*warp.Noise2(&o)
+	}
+	return n.Noise2(&p)
+}
+
+// Warp3 returns n at v displaced by warp, see Warp2.
+func Warp3(n, warp Source3, v *glm.Vec3, amount float32) float32 {
+	var p glm.Vec3
+	for k := range p {
+		o := glm.Vec3{v[0] + warpOffsets[k][0], v[1] + warpOffsets[k][1], v[2] + warpOffsets[k][2]}
+		p[k] = v[k] + amount*warp.Noise3(&o)
+	}
+	return n.Noise3(&p)
+}
+
+// Warp4 returns n at v displaced by warp, see Warp2.
+func Warp4(n, warp Source4, v *glm.Vec4, amount float32) float32 {
+	var p glm.Vec4
+	for k := range p {
+		o := v.Add(&warpOffsets[k])
+		p[k] = v[k] + amount*warp.Noise4(&o)
+	}
+	return n.Noise4(&p)
+}
@@ -0,0 +1,78 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestFractal(t *testing.T) {
+	t.Parallel()
+	p := Perlin{Seed: 7}
+	one := NewFractal(1)
+	f := NewFractal(6)
+	for _, v := range points {
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+
+		// A single octave is the noise itself.
+		if got, want := one.FBm3(&p, &v3), p.Noise3(&v3); got != want {
+			t.Errorf("1 octave FBm3(%v) = %f, want %f", v3, got, want)
+		}
+		if got, want := one.Ridged2(&p, &v2), ridge(p.Noise2(&v2)); got != want {
+			t.Errorf("1 octave Ridged2(%v) = %f, want %f", v2, got, want)
+		}
+
+		// The sums are normalized.
+		for _, n := range []float32{f.FBm2(&p, &v2), f.FBm3(&p, &v3), f.FBm4(&p, &v)} {
+			if n < -1 || n > 1 {
+				t.Errorf("FBm(%v) = %f, out of [-1, 1]", v, n)
+			}
+		}
+		for _, n := range []float32{f.Ridged2(&p, &v2), f.Ridged3(&p, &v3), f.Ridged4(&p, &v)} {
+			if n < 0 || n > 1 {
+				t.Errorf("Ridged(%v) = %f, out of [0, 1]", v, n)
+			}
+		}
+	}
+
+	// The octaves are there: the sum has smaller details than the noise.
+	var rough, smooth float32
+	for i := 0; i < 1000; i++ {
+		a, b := glm.Vec2{float32(i) * 0.01, 0.5}, glm.Vec2{float32(i+1) * 0.01, 0.5}
+		rough += math.Abs(f.FBm2(&p, &b) - f.FBm2(&p, &a))
+		smooth += math.Abs(p.Noise2(&b) - p.Noise2(&a))
+	}
+	if rough <= smooth {
+		t.Errorf("FBm2 variation = %f, noise variation %f", rough, smooth)
+	}
+}
+
+func TestWarp(t *testing.T) {
+	t.Parallel()
+	p := Perlin{Seed: 1}
+	s := OpenSimplex2{Seed: 2}
+	f := NewFractal(4)
+	// Warping by an fBm, through a Func3.
+	fbm := Func3(func(v *glm.Vec3) float32 { return f.FBm3(&s, v) })
+	for _, v := range points {
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+		if got, want := Warp2(&p, &s, &v2, 0), p.Noise2(&v2); got != want {
+			t.Errorf("Warp2(%v, 0) = %f, want %f", v2, got, want)
+		}
+		if got, want := Warp4(&p, &s, &v, 0), p.Noise4(&v); got != want {
+			t.Errorf("Warp4(%v, 0) = %f, want %f", v, got, want)
+		}
+
+		// The displacement is warp sampled at offsets.
+		d := glm.Vec3{fbm.Noise3(&v3), 0, 0}
+		o := glm.Vec3{v3[0] + warpOffsets[1][0], v3[1] + warpOffsets[1][1], v3[2] + warpOffsets[1][2]}
+		d[1] = fbm.Noise3(&o)
+		o = glm.Vec3{v3[0] + warpOffsets[2][0], v3[1] + warpOffsets[2][1], v3[2] + warpOffsets[2][2]}
+		d[2] = fbm.Noise3(&o)
+		q := v3
+		q.AddScaledVec(0.5, &d)
+		if got, want := Warp3(&p, fbm, &v3, 0.5), p.Noise3(&q); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Warp3(%v, 0.5) = %f, want %f", v3, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package noise implements seeded procedural noise: Perlin and OpenSimplex2
+// gradient noise in 2, 3 and 4 dimensions with their analytic derivatives,
+// Worley cellular noise, and fractal sums and domain warping on top of them.
+//
+// The noises are pure functions of their seed and input, the lattice hashing
+// only uses integer arithmetic so the same seed gives the same values on
+// every platform, up to float32 rounding.
+package noise
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Source2 is a 2D noise.
+type Source2 interface {
+	Noise2(v *glm.Vec2) float32
+}
+
+// Source3 is a 3D noise.
+type Source3 interface {
+	Noise3(v *glm.Vec3) float32
+}
+
+// Source4 is a 4D noise.
+type Source4 interface {
+	Noise4(v *glm.Vec4) float32
+}
+
+// Func2 is a function usable as a Source2, to combine the combinators.
+type Func2 func(v *glm.Vec2) float32
+
+// Noise2 returns f(v).
+func (f Func2) Noise2(v *glm.Vec2) float32 { return f(v) }
+
+// Func3 is a function usable as a Source3.
+type Func3 func(v *glm.Vec3) float32
+
+// Noise3 returns f(v).
+func (f Func3) Noise3(v *glm.Vec3) float32 { return f(v) }
+
+// Func4 is a function usable as a Source4.
+type Func4 func(v *glm.Vec4) float32
+
+// Noise4 returns f(v).
+func (f Func4) Noise4(v *glm.Vec4) float32 { return f(v) }
+
+// hash returns a pseudo random number from the first n coordinates of the
+// lattice point c and seed.
+func hash(seed uint32, c *[4]int32, n int) uint32 {
+	// Large odd constants spread the coordinates, then the finalizer of
+	// MurmurHash3 mixes them.
+	primes := [4]uint32{0x9e3779b1, 0x85ebca77, 0xc2b2ae3d, 0x27d4eb2f}
+	h := seed ^ 0x5bd1e995
+	for i := 0; i < n; i++ {
+		h ^= uint32(c[i]) * primes[i]
+		h = (h << 13) | (h >> 19)
+		h = h*5 + 0xe6546b64
+	}
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// gradients are the gradient vectors of the lattice points, indexed by the
+// number of dimensions. There are a power of 2 of them in each dimension, so
+// gradient picks one by masking the hash.
+var gradients = [5][][4]float32{
+	2: {
+		{1, 1}, {-1, 1}, {1, -1}, {-1, -1},
+		{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	},
+	// The 12 edges of a cube, 4 are repeated.
+	3: {
+		{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+		{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+		{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+		{1, 1, 0}, {-1, 1, 0}, {0, -1, 1}, {0, -1, -1},
+	},
+	// The 32 edges of a tesseract.
+	4: {
+		{0, 1, 1, 1}, {0, 1, 1, -1}, {0, 1, -1, 1}, {0, 1, -1, -1},
+		{0, -1, 1, 1}, {0, -1, 1, -1}, {0, -1, -1, 1}, {0, -1, -1, -1},
+		{1, 0, 1, 1}, {1, 0, 1, -1}, {1, 0, -1, 1}, {1, 0, -1, -1},
+		{-1, 0, 1, 1}, {-1, 0, 1, -1}, {-1, 0, -1, 1}, {-1, 0, -1, -1},
+		{1, 1, 0, 1}, {1, 1, 0, -1}, {1, -1, 0, 1}, {1, -1, 0, -1},
+		{-1, 1, 0, 1}, {-1, 1, 0, -1}, {-1, -1, 0, 1}, {-1, -1, 0, -1},
+		{1, 1, 1, 0}, {1, 1, -1, 0}, {1, -1, 1, 0}, {1, -1, -1, 0},
+		{-1, 1, 1, 0}, {-1, 1, -1, 0}, {-1, -1, 1, 0}, {-1, -1, -1, 0},
+	},
+}
+
+// gradient returns the gradient of the lattice point c in n dimensions.
+func gradient(seed uint32, c *[4]int32, n int) *[4]float32 {
+	g := gradients[n]
+	return &g[hash(seed, c, n)&uint32(len(g)-1)]
+}
@@ -0,0 +1,128 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// perlinScales bring the Perlin noise in [-1, 1]. The noise is at most the
+// gradient length times half the diagonal of a cell.
+var perlinScales = [5]float32{
+	2: 1,
+	3: 0.81649658, // 2 / (√2 √3)
+	4: 0.57735027, // 2 / (√3 √4)
+}
+
+// Perlin is Ken Perlin's improved gradient noise, with the quintic fade
+// curve. Its values are in [-1, 1] and it's 0 on the integer lattice. The
+// zero value is a noise with seed 0.
+type Perlin struct {
+	Seed uint32
+}
+
+// perlin returns the noise at the first n coordinates of x and writes its
+// gradient in grad.
+func (p *Perlin) perlin(x *[4]float32, n int, grad *[4]float32) float32 {
+	var cell [4]int32
+	var f, u, du [4]float32
+	for k := 0; k < n; k++ {
+		fl := math.Floor(x[k])
+		cell[k] = int32(fl)
+		f[k] = x[k] - fl
+		t := f[k]
+		u[k] = t * t * t * (t*(t*6-15) + 10)
+		du[k] = 30 * t * t * (t*(t-2) + 1)
+	}
+
+	// Interpolate the dot products of the corners' gradients with the offset
+	// from the corners, the weights are products of the fade curves.
+	var value float32
+	*grad = [4]float32{}
+	for c := 0; c < 1<<uint(n); c++ {
+		corner := cell
+		var d [4]float32
+		for k := 0; k < n; k++ {
+			bit := int32(c>>uint(k)) & 1
+			corner[k] += bit
+			d[k] = f[k] - float32(bit)
+		}
+		g := gradient(p.Seed, &corner, n)
+		var dot float32
+		for k := 0; k < n; k++ {
+			dot += g[k] * d[k]
+		}
+
+		var w float32 = 1
+		for k := 0; k < n; k++ {
+			w *= weight(c, k, &u)
+		}
+		value += w * dot
+		for k := 0; k < n; k++ {
+			// The derivative of the weight along k.
+			dw := du[k]
+			if c>>uint(k)&1 == 0 {
+				dw = -dw
+			}
+			for e := 0; e < n; e++ {
+				if e != k {
+					dw *= weight(c, e, &u)
+				}
+			}
+			grad[k] += w*g[k] + dw*dot
+		}
+	}
+
+	s := perlinScales[n]
+	for k := 0; k < n; k++ {
+		grad[k] *= s
+	}
+	return value * s
+}
+
+// weight returns the weight along k of the corner c of a cell, u are the fade
+// curves.
+func weight(c, k int, u *[4]float32) float32 {
+	if c>>uint(k)&1 == 0 {
+		return 1 - u[k]
+	}
+	return u[k]
+}
+
+// Noise2 returns the noise at v.
+func (p *Perlin) Noise2(v *glm.Vec2) float32 {
+	n, _ := p.Deriv2(v)
+	return n
+}
+
+// Deriv2 returns the noise at v and its gradient.
+func (p *Perlin) Deriv2(v *glm.Vec2) (float32, glm.Vec2) {
+	var grad [4]float32
+	n := p.perlin(&[4]float32{v[0], v[1]}, 2, &grad)
+	return n, glm.Vec2{grad[0], grad[1]}
+}
+
+// Noise3 returns the noise at v.
+func (p *Perlin) Noise3(v *glm.Vec3) float32 {
+	n, _ := p.Deriv3(v)
+	return n
+}
+
+// Deriv3 returns the noise at v and its gradient.
+func (p *Perlin) Deriv3(v *glm.Vec3) (float32, glm.Vec3) {
+	var grad [4]float32
+	n := p.perlin(&[4]float32{v[0], v[1], v[2]}, 3, &grad)
+	return n, glm.Vec3{grad[0], grad[1], grad[2]}
+}
+
+// Noise4 returns the noise at v.
+func (p *Perlin) Noise4(v *glm.Vec4) float32 {
+	n, _ := p.Deriv4(v)
+	return n
+}
+
+// Deriv4 returns the noise at v and its gradient.
+func (p *Perlin) Deriv4(v *glm.Vec4) (float32, glm.Vec4) {
+	var grad [4]float32
+	n := p.perlin((*[4]float32)(v), 4, &grad)
+	return n, glm.Vec4(grad)
+}
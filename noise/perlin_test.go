@@ -0,0 +1,94 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// points are scattered test points, the first coordinates are used in lower
+// dimensions.
+var points = []glm.Vec4{
+	{0.5, 0.25, 0.75, 0.1},
+	{-3.7, 12.1, 5.5, -0.3},
+	{100.3, -42.9, 7.25, 2.5},
+	{-0.01, 0.99, -7.51, 3.33},
+	{13.13, 2.72, -3.14, -21.5},
+}
+
+// deriv is a noise in up to 4 dimensions returning its value and gradient.
+type deriv func(v glm.Vec4) (float32, glm.Vec4)
+
+// checkDeriv checks the gradient of f in dim dimensions against central
+// differences and the values against [-1, 1].
+func checkDeriv(t *testing.T, name string, dim int, f deriv) {
+	t.Helper()
+	const h = 1e-3
+	for i := 0; i < 500; i++ {
+		// A deterministic spread of points, close enough to the origin for
+		// float32 central differences to be precise.
+		k := float32(i)
+		v := glm.Vec4{k*0.0731 - 15, k*0.0377 - 9, k*0.0213 - 5, k*0.0119 - 3}
+		n, g := f(v)
+		if n < -1 || n > 1 {
+			t.Errorf("%s: noise(%v) = %f, out of [-1, 1]", name, v, n)
+		}
+		for d := 0; d < dim; d++ {
+			a, b := v, v
+			a[d] -= h
+			b[d] += h
+			na, _ := f(a)
+			nb, _ := f(b)
+			want := (nb - na) / (2 * h)
+			if math.Abs(g[d]-want) > 2e-2*math.Max(1, math.Abs(want)) {
+				t.Errorf("%s: gradient(%v)[%d] = %f, want %f", name, v, d, g[d], want)
+			}
+		}
+	}
+}
+
+func TestPerlin(t *testing.T) {
+	t.Parallel()
+	p := Perlin{Seed: 42}
+	checkDeriv(t, "Perlin 2D", 2, func(v glm.Vec4) (float32, glm.Vec4) {
+		n, g := p.Deriv2(&glm.Vec2{v[0], v[1]})
+		return n, g.Vec4(0, 0)
+	})
+	checkDeriv(t, "Perlin 3D", 3, func(v glm.Vec4) (float32, glm.Vec4) {
+		n, g := p.Deriv3(&glm.Vec3{v[0], v[1], v[2]})
+		return n, g.Vec4(0)
+	})
+	checkDeriv(t, "Perlin 4D", 4, func(v glm.Vec4) (float32, glm.Vec4) {
+		return p.Deriv4(&v)
+	})
+
+	// Zero on the lattice.
+	for _, v := range []glm.Vec4{{0, 0, 0, 0}, {3, -7, 12, 1}} {
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+		if a, b, c := p.Noise2(&v2), p.Noise3(&v3), p.Noise4(&v); a != 0 || b != 0 || c != 0 {
+			t.Errorf("noise(%v) = %f, %f, %f, want 0", v, a, b, c)
+		}
+	}
+
+	// The values don't depend on the platform.
+	want := [][3]float32{
+		{0.4482422, -0.3291963, -0.02665731},
+		{0.3458527, 0.005477445, 0.0003448729},
+		{-0.03782652, 0.2100538, -0.1041315},
+	}
+	for i, w := range want {
+		v := points[i]
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+		got := [3]float32{p.Noise2(&v2), p.Noise3(&v3), p.Noise4(&v)}
+		for d := range got {
+			if math.Abs(got[d]-w[d]) > 1e-5 {
+				t.Errorf("[%d] %dD noise = %.7g, want %.7g", i, d+2, got[d], w[d])
+			}
+		}
+	}
+
+	other := Perlin{Seed: 43}
+	if v := points[0]; p.Noise4(&v) == other.Noise4(&v) {
+		t.Errorf("seeds 42 and 43 give the same noise")
+	}
+}
@@ -0,0 +1,168 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// The skew factors of the simplex lattices, and the squared radius of the
+// kernels around the lattice points.
+const (
+	skew2   = 0.36602540378 // (√3 - 1) / 2
+	unskew2 = 0.21132486540 // (3 - √3) / 6
+	skew4   = 0.30901699437 // (√5 - 1) / 4
+	unskew4 = 0.13819660112 // (5 - √5) / 20
+
+	radius2 = 0.5
+	radius3 = 0.6
+	radius4 = 0.5
+	rotate3 = 2.0 / 3
+)
+
+// simplexScales bring the simplex noises in [-1, 1]. They are measured, the
+// largest values found by gradient ascent from many points.
+var simplexScales = [5]float32{
+	2: 70,
+	3: 32.6,
+	4: 62.6,
+}
+
+// OpenSimplex2 is gradient noise summing radial kernels around the points of
+// a lattice, which has fewer directional artifacts than Perlin noise. Like
+// OpenSimplex2 it uses the triangular lattice in 2D and the body centered
+// cubic lattice in 3D, reflected so its axes don't line up with X, Y and Z.
+// In 4D it uses the simplex lattice. Its values are in [-1, 1] and its
+// derivatives are continuous. The zero value is a noise with seed 0.
+//
+// The hashing and gradients are this package's own, the values don't match
+// other OpenSimplex2 implementations.
+type OpenSimplex2 struct {
+	Seed uint32
+}
+
+// kernel adds to value and grad the contribution of a lattice point with
+// gradient g at offset d from x, within the squared radius r2.
+func kernel(g, d *[4]float32, n int, r2 float32, value *float32, grad *[4]float32) {
+	a := r2
+	var dot float32
+	for k := 0; k < n; k++ {
+		a -= d[k] * d[k]
+		dot += g[k] * d[k]
+	}
+	if a <= 0 {
+		return
+	}
+	a2 := a * a
+	a4 := a2 * a2
+	*value += a4 * dot
+	// d(a⁴ g·d)/dx = a⁴ g - 8 a³ (g·d) d
+	for k := 0; k < n; k++ {
+		grad[k] += a4*g[k] - 8*a2*a*dot*d[k]
+	}
+}
+
+// skewed adds the contributions of the 2^n corners of the cell of x in the
+// lattice skewed by skew and unskew, and returns the noise value.
+func (s *OpenSimplex2) skewed(x *[4]float32, n int, skew, unskew, r2 float32, grad *[4]float32) float32 {
+	var sum float32
+	for k := 0; k < n; k++ {
+		sum += x[k]
+	}
+	sum *= skew
+	var cell [4]int32
+	for k := 0; k < n; k++ {
+		cell[k] = int32(math.Floor(x[k] + sum))
+	}
+
+	// The lattice points within the radius are corners of the skewed cell.
+	var value float32
+	for c := 0; c < 1<<uint(n); c++ {
+		corner := cell
+		var t float32
+		for k := 0; k < n; k++ {
+			corner[k] += int32(c>>uint(k)) & 1
+			t += float32(corner[k])
+		}
+		t *= unskew
+		var d [4]float32
+		for k := 0; k < n; k++ {
+			d[k] = x[k] - (float32(corner[k]) - t)
+		}
+		kernel(gradient(s.Seed, &corner, n), &d, n, r2, &value, grad)
+	}
+	return value
+}
+
+// Noise2 returns the noise at v.
+func (s *OpenSimplex2) Noise2(v *glm.Vec2) float32 {
+	n, _ := s.Deriv2(v)
+	return n
+}
+
+// Deriv2 returns the noise at v and its gradient.
+func (s *OpenSimplex2) Deriv2(v *glm.Vec2) (float32, glm.Vec2) {
+	var grad [4]float32
+	n := s.skewed(&[4]float32{v[0], v[1]}, 2, skew2, unskew2, radius2, &grad)
+	k := simplexScales[2]
+	return n * k, glm.Vec2{grad[0] * k, grad[1] * k}
+}
+
+// Noise3 returns the noise at v.
+func (s *OpenSimplex2) Noise3(v *glm.Vec3) float32 {
+	n, _ := s.Deriv3(v)
+	return n
+}
+
+// Deriv3 returns the noise at v and its gradient.
+func (s *OpenSimplex2) Deriv3(v *glm.Vec3) (float32, glm.Vec3) {
+	// The reorientation is a reflection, its own inverse and transpose, so
+	// the gradient goes back the same way.
+	r := rotate3 * (v[0] + v[1] + v[2])
+	x := [3]float32{r - v[0], r - v[1], r - v[2]}
+
+	// The body centered cubic lattice is two cubic lattices, the second
+	// offset by half a cell. Only the corners of the cells of x are within
+	// the radius.
+	var value float32
+	var grad [4]float32
+	for lattice := int32(0); lattice < 2; lattice++ {
+		offset := 0.5 * float32(lattice)
+		var cell [4]int32
+		var f [3]float32
+		for k := range x {
+			fl := math.Floor(x[k] - offset)
+			cell[k] = int32(fl)
+			f[k] = x[k] - offset - fl
+		}
+		cell[3] = lattice
+		for c := 0; c < 8; c++ {
+			corner := cell
+			var d [4]float32
+			for k := 0; k < 3; k++ {
+				bit := int32(c>>uint(k)) & 1
+				corner[k] += bit
+				d[k] = f[k] - float32(bit)
+			}
+			g := gradients[3][hash(s.Seed, &corner, 4)&15]
+			kernel(&g, &d, 3, radius3, &value, &grad)
+		}
+	}
+
+	k := simplexScales[3]
+	r = rotate3 * (grad[0] + grad[1] + grad[2])
+	return value * k, glm.Vec3{(r - grad[0]) * k, (r - grad[1]) * k, (r - grad[2]) * k}
+}
+
+// Noise4 returns the noise at v.
+func (s *OpenSimplex2) Noise4(v *glm.Vec4) float32 {
+	n, _ := s.Deriv4(v)
+	return n
+}
+
+// Deriv4 returns the noise at v and its gradient.
+func (s *OpenSimplex2) Deriv4(v *glm.Vec4) (float32, glm.Vec4) {
+	var grad [4]float32
+	n := s.skewed((*[4]float32)(v), 4, skew4, unskew4, radius4, &grad)
+	k := simplexScales[4]
+	return n * k, glm.Vec4{grad[0] * k, grad[1] * k, grad[2] * k, grad[3] * k}
+}
@@ -0,0 +1,62 @@
+package noise
+
+import (
+	"fmt"
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestOpenSimplex2(t *testing.T) {
+	t.Parallel()
+	s := OpenSimplex2{Seed: 42}
+	derivs := []struct {
+		dim int
+		f   deriv
+	}{
+		{2, func(v glm.Vec4) (float32, glm.Vec4) {
+			n, g := s.Deriv2(&glm.Vec2{v[0], v[1]})
+			return n, g.Vec4(0, 0)
+		}},
+		{3, func(v glm.Vec4) (float32, glm.Vec4) {
+			n, g := s.Deriv3(&glm.Vec3{v[0], v[1], v[2]})
+			return n, g.Vec4(0)
+		}},
+		{4, func(v glm.Vec4) (float32, glm.Vec4) {
+			return s.Deriv4(&v)
+		}},
+	}
+	for _, d := range derivs {
+		checkDeriv(t, fmt.Sprintf("OpenSimplex2 %dD", d.dim), d.dim, d.f)
+
+		// No jumps: tiny steps give tiny changes.
+		v := glm.Vec4{0.1, 0.2, 0.3, 0.4}
+		step := glm.Vec4{1e-3, 0.7e-3, 0.3e-3, 0.5e-3}
+		prev, _ := d.f(v)
+		for i := 0; i < 20000; i++ {
+			v.AddWith(&step)
+			n, _ := d.f(v)
+			if math.Abs(n-prev) > 0.02 {
+				t.Errorf("%dD: noise jumps from %f to %f at %v", d.dim, prev, n, v)
+				break
+			}
+			prev = n
+		}
+	}
+
+	want := [][3]float32{
+		{0.5126124, 0.02088757, -0.100948},
+		{-0.7384924, -0.4360631, 0.03874485},
+		{-0.05514911, 0.9401199, 0.4170513},
+	}
+	for i, w := range want {
+		v := points[i]
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+		got := [3]float32{s.Noise2(&v2), s.Noise3(&v3), s.Noise4(&v)}
+		for d := range got {
+			if math.Abs(got[d]-w[d]) > 1e-5 {
+				t.Errorf("[%d] %dD noise = %.7g, want %.7g", i, d+2, got[d], w[d])
+			}
+		}
+	}
+}
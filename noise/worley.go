@@ -0,0 +1,117 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// Worley is cellular noise: every cell of the integer lattice has a feature
+// point at a random position and the noise is about the distances to the
+// nearest ones. The zero value is a noise with seed 0.
+type Worley struct {
+	Seed uint32
+}
+
+// Cell is the result of a Worley noise lookup.
+type Cell struct {
+	// F1 and F2 are the distances to the nearest and second nearest
+	// feature points.
+	F1, F2 float32
+	// ID is a random number identifying the nearest feature point, all the
+	// points of its Voronoi cell have the same.
+	ID uint32
+}
+
+// cell returns the Worley cell of the first n coordinates of x.
+func (w *Worley) cell(x *[4]float32, n int) Cell {
+	var base [4]int32
+	var f [4]float32
+	for k := 0; k < n; k++ {
+		fl := math.Floor(x[k])
+		base[k] = int32(fl)
+		f[k] = x[k] - fl
+	}
+
+	// Feature points 3 cells away are at least 2 away, further than the
+	// feature point of a neighbour cell nearly always, the 5^n cells
+	// around are searched and pruned by their distance.
+	best := Cell{F1: math.MaxFloat32, F2: math.MaxFloat32}
+	f1, f2 := best.F1, best.F2
+	count := 1
+	for k := 0; k < n; k++ {
+		count *= 5
+	}
+	for i := 0; i < count; i++ {
+		var o [4]int32
+		var box float32
+		for k, j := 0, i; k < n; k, j = k+1, j/5 {
+			o[k] = int32(j%5) - 2
+			// Distance from x to the cell along k.
+			var d float32
+			if o[k] < 0 {
+				d = f[k] - float32(o[k]+1)
+			} else if o[k] > 0 {
+				d = float32(o[k]) - f[k]
+			}
+			box += d * d
+		}
+		if box >= f2 {
+			continue
+		}
+
+		c := base
+		var d2 float32
+		for k := 0; k < n; k++ {
+			c[k] += o[k]
+		}
+		for k := 0; k < n; k++ {
+			d := float32(o[k]) + feature(w.Seed, &c, n, k) - f[k]
+			d2 += d * d
+		}
+		if d2 < f1 {
+			f1, f2 = d2, f1
+			best.ID = hash(w.Seed, &c, n)
+		} else if d2 < f2 {
+			f2 = d2
+		}
+	}
+	best.F1, best.F2 = math.Sqrt(f1), math.Sqrt(f2)
+	return best
+}
+
+// feature returns the coordinate k, in [0, 1), of the feature point in the
+// cell c.
+func feature(seed uint32, c *[4]int32, n, k int) float32 {
+	h := hash(seed+uint32(k+1)*0x68e31da4, c, n)
+	return float32(h>>8) / (1 << 24)
+}
+
+// Cell2 returns the Worley cell of v.
+func (w *Worley) Cell2(v *glm.Vec2) Cell {
+	return w.cell(&[4]float32{v[0], v[1]}, 2)
+}
+
+// Cell3 returns the Worley cell of v.
+func (w *Worley) Cell3(v *glm.Vec3) Cell {
+	return w.cell(&[4]float32{v[0], v[1], v[2]}, 3)
+}
+
+// Cell4 returns the Worley cell of v.
+func (w *Worley) Cell4(v *glm.Vec4) Cell {
+	return w.cell(&[4]float32{v[0], v[1], v[2], v[3]}, 4)
+}
+
+// Noise2 returns the distance from v to the nearest feature point, F1.
+func (w *Worley) Noise2(v *glm.Vec2) float32 {
+	return w.Cell2(v).F1
+}
+
+// Noise3 returns the distance from v to the nearest feature point, F1.
+func (w *Worley) Noise3(v *glm.Vec3) float32 {
+	return w.Cell3(v).F1
+}
+
+// Noise4 returns the distance from v to the nearest feature point, F1.
+func (w *Worley) Noise4(v *glm.Vec4) float32 {
+	return w.Cell4(v).F1
+}
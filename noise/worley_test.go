@@ -0,0 +1,78 @@
+package noise
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// bruteCell returns the Worley cell of x searching the 7^n cells around.
+func bruteCell(w *Worley, x *[4]float32, n int) Cell {
+	best := Cell{F1: math.MaxFloat32, F2: math.MaxFloat32}
+	count := 1
+	for k := 0; k < n; k++ {
+		count *= 7
+	}
+	for i := 0; i < count; i++ {
+		var c [4]int32
+		var d2 float32
+		for k, j := 0, i; k < n; k, j = k+1, j/7 {
+			c[k] = int32(math.Floor(x[k])) + int32(j%7) - 3
+		}
+		for k := 0; k < n; k++ {
+			d := float32(c[k]) + feature(w.Seed, &c, n, k) - x[k]
+			d2 += d * d
+		}
+		d := math.Sqrt(d2)
+		if d < best.F1 {
+			best.F2, best.F1, best.ID = best.F1, d, hash(w.Seed, &c, n)
+		} else if d < best.F2 {
+			best.F2 = d
+		}
+	}
+	return best
+}
+
+func TestWorley(t *testing.T) {
+	t.Parallel()
+	w := Worley{Seed: 42}
+	for i := 0; i < 300; i++ {
+		k := float32(i)
+		v := [4]float32{k*0.731 - 150, k*0.377 - 90, k*0.213 - 50, k*0.119 - 30}
+		for n := 2; n <= 4; n++ {
+			got, want := w.cell(&v, n), bruteCell(&w, &v, n)
+			if math.Abs(got.F1-want.F1) > 1e-5 || math.Abs(got.F2-want.F2) > 1e-5 || got.ID != want.ID {
+				t.Errorf("%dD cell(%v) = %v, want %v", n, v, got, want)
+			}
+			if got.F1 > got.F2 {
+				t.Errorf("%dD cell(%v) F1 > F2", n, v)
+			}
+		}
+	}
+
+	want := []struct {
+		c2, c3, c4 Cell
+	}{
+		{Cell{0.295913, 0.6921004, 721273232}, Cell{0.654397, 0.7089505, 2510928208}, Cell{0.4837965, 0.8706063, 472364560}},
+		{Cell{0.2297435, 0.422456, 1350180602}, Cell{0.2481865, 0.7965714, 2392314193}, Cell{0.4722215, 0.6838168, 4180876031}},
+		{Cell{0.3642492, 0.5985857, 1053937740}, Cell{0.4187326, 0.5930836, 1476796178}, Cell{0.832594, 0.8907715, 1189291190}},
+	}
+	for i, test := range want {
+		v := points[i]
+		v2, v3 := glm.Vec2{v[0], v[1]}, glm.Vec3{v[0], v[1], v[2]}
+		for n, c := range []Cell{w.Cell2(&v2), w.Cell3(&v3), w.Cell4(&v)} {
+			wc := []Cell{test.c2, test.c3, test.c4}[n]
+			if math.Abs(c.F1-wc.F1) > 1e-5 || math.Abs(c.F2-wc.F2) > 1e-5 || c.ID != wc.ID {
+				t.Errorf("[%d] %dD cell = %v, want %v", i, n+2, c, wc)
+			}
+		}
+	}
+	v := glm.Vec2{3.3, 4.4}
+	if w.Noise2(&v) != w.Cell2(&v).F1 {
+		t.Errorf("Noise2 isn't F1")
+	}
+	v4 := glm.Vec4{3.3, 4.4, 5.5, 6.6}
+	if w.Noise4(&v4) != w.Cell4(&v4).F1 {
+		t.Errorf("Noise4 isn't F1")
+	}
+}
@@ -88,41 +88,18 @@ func LookAtV(eye, center, up *Vec3) Mat4 {
 // coordinates (with depth)
 //
 // Window coordinates are continuous, not discrete, so you won't get exact pixel
-// locations without rounding.
+// locations without rounding. For other conventions than OpenGL's see
+// ClipSpace.Project.
 func Project(obj *Vec3, modelview, projection *Mat4, initialX, initialY, width, height int) Vec3 {
-	obj4 := obj.Vec4(1)
-
-	pm := projection.Mul4(modelview)
-	vpp := pm.Mul4x1(&obj4)
-	return Vec3{
-		float32(initialX) + (float32(width)*(vpp[0]+1))*0.5,
-		float32(initialY) + (float32(height)*(vpp[1]+1))*0.5,
-		(vpp[2] + 1) * 0.5,
-	}
+	return ClipOpenGL.Project(obj, modelview, projection, initialX, initialY, width, height)
 }
 
 // UnProject transforms a set of window coordinates to object space. If your MVP
 // matrix is not invertible this will return garbage.
 //
 // Note that the projection may not be perfect if you use strict pixel locations
-// rather than the exact values given by Project.
+// rather than the exact values given by Project. For other conventions than
+// OpenGL's see ClipSpace.UnProject.
 func UnProject(win *Vec3, modelview, projection *Mat4, initialX, initialY, width, height int) Vec3 {
-	pm := projection.Mul4(modelview)
-	inv := pm.Inverse()
-
-	obj4 := inv.Mul4x1(&Vec4{
-		(2 * (win[0] - float32(initialX)) / float32(width)) - 1,
-		(2 * (win[1] - float32(initialY)) / float32(height)) - 1,
-		2*win[2] - 1,
-		1.0,
-	})
-	obj := obj4.Vec3()
-
-	//if obj4[3] > MinValue {}
-	over := 1 / obj4[3]
-	obj[0] *= over
-	obj[1] *= over
-	obj[2] *= over
-
-	return obj
+	return ClipOpenGL.UnProject(win, modelview, projection, initialX, initialY, width, height)
 }
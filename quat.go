@@ -302,6 +302,115 @@ func (q1 *Quat) Invert() {
 	q1.ScaleWith(1.0 / q1.Dot(q1))
 }
 
+// Exp returns the exponential of the quaternion. For a pure quaternion
+// {0, θ*axis} this is the unit quaternion {cos(θ), sin(θ)*axis}, which makes
+// Exp the inverse of Log.
+func (q1 *Quat) Exp() Quat {
+	var q Quat
+	q.ExpOf(q1)
+	return q
+}
+
+// ExpOf is a memory friendly version of Exp. q1 = exp(q2)
+func (q1 *Quat) ExpOf(q2 *Quat) {
+	theta := q2.V.Len()
+	e := math.Exp(q2.W)
+	s, c := math.Sincos(theta)
+
+	// sin(θ)/θ tends to 1 when θ tends to 0, don't divide by almost 0.
+	k := e
+	if theta > 1e-6 {
+		k *= s / theta
+	}
+	q1.W = e * c
+	q1.V.MulOf(k, &q2.V)
+}
+
+// Exponentiate is a memory friendly version of Exp. q1 = exp(q1)
+func (q1 *Quat) Exponentiate() {
+	q1.ExpOf(q1)
+}
+
+// Log returns the natural logarithm of the quaternion. For a unit quaternion
+// representing a rotation of angle θ around axis this is the pure quaternion
+// {0, θ/2*axis}.
+func (q1 *Quat) Log() Quat {
+	var q Quat
+	q.LogOf(q1)
+	return q
+}
+
+// LogOf is a memory friendly version of Log. q1 = log(q2)
+func (q1 *Quat) LogOf(q2 *Quat) {
+	vl := q2.V.Len()
+	l := q2.Len()
+
+	// atan2 is more precise than acos(w/|q|) when w is close to |q|.
+	theta := math.Atan2(vl, q2.W)
+	k := float32(0)
+	if vl > 1e-6 {
+		k = theta / vl
+	} else if l > 0 {
+		k = 1 / l
+	}
+	q1.W = math.Log(l)
+	q1.V.MulOf(k, &q2.V)
+}
+
+// Logarithmize is a memory friendly version of Log. q1 = log(q1)
+func (q1 *Quat) Logarithmize() {
+	q1.LogOf(q1)
+}
+
+// Pow returns the quaternion raised to the power t. For a unit quaternion this
+// scales the angle of the rotation by t, so q.Pow(0.5) is half the rotation of
+// q.
+func (q1 *Quat) Pow(t float32) Quat {
+	var q Quat
+	q.PowOf(q1, t)
+	return q
+}
+
+// PowOf is a memory friendly version of Pow. q1 = q2^t
+func (q1 *Quat) PowOf(q2 *Quat, t float32) {
+	q1.LogOf(q2)
+	q1.ScaleWith(t)
+	q1.Exponentiate()
+}
+
+// PowWith is a memory friendly version of Pow. q1 = q1^t
+func (q1 *Quat) PowWith(t float32) {
+	q1.PowOf(q1, t)
+}
+
+// Angle returns the angle, in radians and between 0 and 2π, of the rotation
+// this unit quaternion represents.
+func (q1 *Quat) Angle() float32 {
+	return 2 * math.Atan2(q1.V.Len(), q1.W)
+}
+
+// AxisAngle returns the angle and axis of the rotation this unit quaternion
+// represents, such that QuatRotate(q.AxisAngle()) is the same orientation as
+// q. If the rotation is the identity the axis is arbitrarily {1, 0, 0}.
+func (q1 *Quat) AxisAngle() (angle float32, axis Vec3) {
+	l := q1.V.Len()
+	if l < 1e-6 {
+		return 0, Vec3{1, 0, 0}
+	}
+	axis.MulOf(1/l, &q1.V)
+	return 2 * math.Atan2(l, q1.W), axis
+}
+
+// QuatAngleBetween returns the smallest angle, in radians and between 0 and π,
+// of the rotation that takes the orientation q1 to the orientation q2. q and -q
+// are considered the same orientation.
+func QuatAngleBetween(q1, q2 *Quat) float32 {
+	var d Quat
+	d.ConjugateOf(q1)
+	d.MulWith(q2)
+	return 2 * math.Atan2(d.V.Len(), math.Abs(d.W))
+}
+
 // Rotate rotates a vector by the rotation this quaternion represents. This will
 // result in a 3D vector. Strictly speaking, this is equivalent to q1.v.q* where
 // the "."" is quaternion multiplication and v is interpreted as a quaternion
@@ -614,6 +723,56 @@ func Mat4ToQuat(m *Mat4) Quat {
 	}
 }
 
+// Mat3ToQuat converts a pure rotation matrix into a quaternion
+func Mat3ToQuat(m *Mat3) Quat {
+	// Same as Mat4ToQuat with the indices of a 3x3 matrix.
+	if tr := m[0] + m[4] + m[8]; tr > 0 {
+		s := 0.5 / math.Sqrt(tr+1.0)
+		return Quat{
+			0.25 / s,
+			Vec3{
+				(m[5] - m[7]) * s,
+				(m[6] - m[2]) * s,
+				(m[1] - m[3]) * s,
+			},
+		}
+	}
+
+	if (m[0] > m[4]) && (m[0] > m[8]) {
+		s := 2.0 * math.Sqrt(1.0+m[0]-m[4]-m[8])
+		return Quat{
+			(m[5] - m[7]) / s,
+			Vec3{
+				0.25 * s,
+				(m[3] + m[1]) / s,
+				(m[6] + m[2]) / s,
+			},
+		}
+	}
+
+	if m[4] > m[8] {
+		s := 2.0 * math.Sqrt(1.0+m[4]-m[0]-m[8])
+		return Quat{
+			(m[6] - m[2]) / s,
+			Vec3{
+				(m[3] + m[1]) / s,
+				0.25 * s,
+				(m[7] + m[5]) / s,
+			},
+		}
+	}
+
+	s := 2.0 * math.Sqrt(1.0+m[8]-m[0]-m[4])
+	return Quat{
+		(m[1] - m[3]) / s,
+		Vec3{
+			(m[6] + m[2]) / s,
+			(m[7] + m[5]) / s,
+			0.25 * s,
+		},
+	}
+}
+
 // QuatLookAtV creates a rotation from an eye vector to a center vector
 //
 // It assumes the front of the rotated object at Z- and up at Y+
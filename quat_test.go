@@ -1116,3 +1116,133 @@ func TestQuat_Mat4(t *testing.T) {
 		}
 	}
 }
+
+func TestQuat_ExpLog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		q, log Quat
+	}{
+		{QuatIdent(), Quat{0, Vec3{0, 0, 0}}},
+		{QuatRotate(math.Pi/2, &Vec3{1, 0, 0}), Quat{0, Vec3{math.Pi / 4, 0, 0}}},
+		{QuatRotate(1, &Vec3{0, 0.6, 0.8}), Quat{0, Vec3{0, 0.3, 0.4}}},
+		{Quat{2, Vec3{0, 0, 0}}, Quat{math.Log(2), Vec3{0, 0, 0}}},
+	}
+
+	for i, test := range tests {
+		l := test.q.Log()
+		if math.Abs(l.W-test.log.W) > 1e-4 || !l.V.EqualThreshold(&test.log.V, 1e-4) {
+			t.Errorf("[%d] log(%v) = %v, want %v", i, test.q, l, test.log)
+		}
+		e := l.Exp()
+		if !e.EqualThreshold(&test.q, 1e-4) {
+			t.Errorf("[%d] exp(log(%v)) = %v", i, test.q, e)
+		}
+
+		var q Quat
+		q.LogOf(&test.q)
+		q.Exponentiate()
+		if !q.EqualThreshold(&test.q, 1e-4) {
+			t.Errorf("[%d] Exponentiate(LogOf(%v)) = %v", i, test.q, q)
+		}
+	}
+}
+
+func TestQuat_Pow(t *testing.T) {
+	t.Parallel()
+	axis := Vec3{0, 1, 0}
+	tests := []struct {
+		q        Quat
+		t        float32
+		expected Quat
+	}{
+		{QuatRotate(1, &axis), 0.5, QuatRotate(0.5, &axis)},
+		{QuatRotate(1, &axis), 2, QuatRotate(2, &axis)},
+		{QuatRotate(1, &axis), 0, QuatIdent()},
+		{QuatRotate(1, &axis), -1, QuatRotate(-1, &axis)},
+		{Quat{4, Vec3{0, 0, 0}}, 0.5, Quat{2, Vec3{0, 0, 0}}},
+	}
+
+	for i, test := range tests {
+		p := test.q.Pow(test.t)
+		if !p.EqualThreshold(&test.expected, 1e-4) {
+			t.Errorf("[%d] %v^%v = %v, want %v", i, test.q, test.t, p, test.expected)
+		}
+		p = test.q
+		p.PowWith(test.t)
+		if !p.EqualThreshold(&test.expected, 1e-4) {
+			t.Errorf("[%d] PowWith %v^%v = %v, want %v", i, test.q, test.t, p, test.expected)
+		}
+	}
+}
+
+func TestQuat_AxisAngle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		angle float32
+		axis  Vec3
+	}{
+		{0.5, Vec3{1, 0, 0}},
+		{2, Vec3{0, 0.6, 0.8}},
+		{math.Pi, Vec3{0, 0, 1}},
+	}
+
+	for i, test := range tests {
+		q := QuatRotate(test.angle, &test.axis)
+		angle, axis := q.AxisAngle()
+		if !FloatEqualThreshold(angle, test.angle, 1e-4) || !axis.EqualThreshold(&test.axis, 1e-4) {
+			t.Errorf("[%d] AxisAngle = %v, %v, want %v, %v", i, angle, axis, test.angle, test.axis)
+		}
+		if a := q.Angle(); !FloatEqualThreshold(a, test.angle, 1e-4) {
+			t.Errorf("[%d] Angle = %v, want %v", i, a, test.angle)
+		}
+	}
+
+	ident := QuatIdent()
+	if angle, axis := ident.AxisAngle(); angle != 0 || axis != (Vec3{1, 0, 0}) {
+		t.Errorf("identity AxisAngle = %v, %v", angle, axis)
+	}
+}
+
+func TestQuatAngleBetween(t *testing.T) {
+	t.Parallel()
+	axis := Vec3{0, 0, 1}
+	tests := []struct {
+		a, b     float32
+		expected float32
+	}{
+		{0, 0, 0},
+		{0, 1, 1},
+		{1, -1, 2},
+		{-3, 3, 2*math.Pi - 6},
+	}
+
+	for i, test := range tests {
+		q1, q2 := QuatRotate(test.a, &axis), QuatRotate(test.b, &axis)
+		if a := QuatAngleBetween(&q1, &q2); !FloatEqualThreshold(a, test.expected, 1e-3) {
+			t.Errorf("[%d] QuatAngleBetween = %v, want %v", i, a, test.expected)
+		}
+		q2.ScaleWith(-1)
+		if a := QuatAngleBetween(&q1, &q2); !FloatEqualThreshold(a, test.expected, 1e-3) {
+			t.Errorf("[%d] QuatAngleBetween(q1, -q2) = %v, want %v", i, a, test.expected)
+		}
+	}
+}
+
+func TestMat3ToQuat(t *testing.T) {
+	t.Parallel()
+	tests := []Quat{
+		QuatIdent(),
+		QuatRotate(0.5, &Vec3{1, 0, 0}),
+		QuatRotate(3, &Vec3{1, 0, 0}),
+		QuatRotate(3, &Vec3{0, 1, 0}),
+		QuatRotate(3, &Vec3{0, 0, 1}),
+		QuatRotate(-2, &Vec3{0, 0.6, 0.8}),
+	}
+
+	for i, test := range tests {
+		m := test.Mat3()
+		if q := Mat3ToQuat(&m); !q.OrientationEqualThreshold(&test, 1e-4) {
+			t.Errorf("[%d] Mat3ToQuat(%v) = %v, want %v", i, m, q, test)
+		}
+	}
+}
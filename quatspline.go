@@ -0,0 +1,107 @@
+package glm
+
+// QuatSquad is *S*pherical and *Quad*rangle interpolation between q1 and q2
+// using s1 and s2 as inner control points. It's to QuatSlerp what a cubic
+// Bezier curve is to a line: the path goes through q1 at 0 and q2 at 1 but
+// bends towards s1 and s2 in between.
+//
+// Use QuatSquadInner to compute control points that make a sequence of SQUAD
+// segments continuous in angular velocity.
+func QuatSquad(q1, q2, s1, s2 *Quat, amount float32) Quat {
+	a := QuatSlerp(q1, q2, amount)
+	b := QuatSlerp(s1, s2, amount)
+	return QuatSlerp(&a, &b, 2*amount*(1-amount))
+}
+
+// QuatSquadInner returns the SQUAD inner control point of cur given its
+// neighbouring keys prev and next. All three need to be unit quaternions.
+//
+//	s = cur * exp(-(log(cur⁻¹*next) + log(cur⁻¹*prev)) / 4)
+func QuatSquadInner(prev, cur, next *Quat) Quat {
+	p, n := quatSameHemisphere(cur, prev), quatSameHemisphere(cur, next)
+
+	inv := cur.Conjugated()
+	var a, b Quat
+	a.MulOf(&inv, &n)
+	a.Logarithmize()
+	b.MulOf(&inv, &p)
+	b.Logarithmize()
+
+	a.AddWith(&b)
+	a.ScaleWith(-0.25)
+	a.Exponentiate()
+	return cur.Mul(&a)
+}
+
+// QuatSquadSpline interpolates between q1 and q2 using SQUAD with the inner
+// control points computed from the neighbouring keys q0 and q3. Chaining
+// segments of the same key sequence gives a path that is continuous in
+// angular velocity, unlike chaining QuatSlerp.
+func QuatSquadSpline(q0, q1, q2, q3 *Quat, amount float32) Quat {
+	a, b, c, d := quatAlignKeys(q0, q1, q2, q3)
+	s1 := QuatSquadInner(&a, &b, &c)
+	s2 := QuatSquadInner(&b, &c, &d)
+	return QuatSquad(&b, &c, &s1, &s2, amount)
+}
+
+// QuatSquadPath interpolates along all the keys using QuatSquadSpline. t goes
+// from 0 at the first key to len(keys)-1 at the last one and is clamped to
+// that range. The first and last keys are used as their own neighbour. This
+// will panic if keys is empty.
+func QuatSquadPath(keys []Quat, t float32) Quat {
+	last := len(keys) - 1
+	if last == 0 {
+		return keys[0]
+	}
+	t = Clamp(t, 0, float32(last))
+	i := int(t)
+	if i == last {
+		i--
+	}
+	i0, i3 := i-1, i+2
+	if i0 < 0 {
+		i0 = 0
+	}
+	if i3 > last {
+		i3 = last
+	}
+	return QuatSquadSpline(&keys[i0], &keys[i], &keys[i+1], &keys[i3], t-float32(i))
+}
+
+// QuatCatmullRom interpolates between q1 and q2 along a uniform Catmull-Rom
+// style spline going through q0, q1, q2 and q3. It uses the Barry-Goldman
+// pyramidal formulation with every linear interpolation replaced by QuatSlerp.
+func QuatCatmullRom(q0, q1, q2, q3 *Quat, amount float32) Quat {
+	a, b, c, d := quatAlignKeys(q0, q1, q2, q3)
+
+	a1 := QuatSlerp(&a, &b, amount+1)
+	a2 := QuatSlerp(&b, &c, amount)
+	a3 := QuatSlerp(&c, &d, amount-1)
+
+	b1 := QuatSlerp(&a1, &a2, (amount+1)*0.5)
+	b2 := QuatSlerp(&a2, &a3, amount*0.5)
+
+	return QuatSlerp(&b1, &b2, amount)
+}
+
+// quatSameHemisphere returns q or -q, whichever is on the same hemisphere as
+// ref, so that interpolating from ref takes the shortest path.
+func quatSameHemisphere(ref, q *Quat) Quat {
+	if ref.Dot(q) < 0 {
+		return q.Scale(-1)
+	}
+	return *q
+}
+
+// quatAlignKeys normalizes the keys and flips them so that each one is on the
+// same hemisphere as the previous one.
+func quatAlignKeys(q0, q1, q2, q3 *Quat) (a, b, c, d Quat) {
+	a = q0.Normalized()
+	b = q1.Normalized()
+	c = q2.Normalized()
+	d = q3.Normalized()
+	b = quatSameHemisphere(&a, &b)
+	c = quatSameHemisphere(&b, &c)
+	d = quatSameHemisphere(&c, &d)
+	return
+}
@@ -0,0 +1,84 @@
+package glm
+
+import (
+	"testing"
+)
+
+func TestQuatSquadSplineEndpoints(t *testing.T) {
+	t.Parallel()
+	keys := []Quat{
+		QuatRotate(0.3, &Vec3{1, 0, 0}),
+		QuatRotate(1.2, &Vec3{0, 1, 0}),
+		QuatRotate(-0.7, &Vec3{0, 0.6, 0.8}),
+		QuatRotate(2, &Vec3{0.8, 0, 0.6}),
+	}
+
+	q := QuatSquadSpline(&keys[0], &keys[1], &keys[2], &keys[3], 0)
+	if !q.OrientationEqualThreshold(&keys[1], 1e-4) {
+		t.Errorf("QuatSquadSpline(0) = %v, want %v", q, keys[1])
+	}
+	q = QuatSquadSpline(&keys[0], &keys[1], &keys[2], &keys[3], 1)
+	if !q.OrientationEqualThreshold(&keys[2], 1e-4) {
+		t.Errorf("QuatSquadSpline(1) = %v, want %v", q, keys[2])
+	}
+
+	q = QuatCatmullRom(&keys[0], &keys[1], &keys[2], &keys[3], 0)
+	if !q.OrientationEqualThreshold(&keys[1], 1e-4) {
+		t.Errorf("QuatCatmullRom(0) = %v, want %v", q, keys[1])
+	}
+	q = QuatCatmullRom(&keys[0], &keys[1], &keys[2], &keys[3], 1)
+	if !q.OrientationEqualThreshold(&keys[2], 1e-4) {
+		t.Errorf("QuatCatmullRom(1) = %v, want %v", q, keys[2])
+	}
+
+	for i := range keys {
+		q = QuatSquadPath(keys, float32(i))
+		if !q.OrientationEqualThreshold(&keys[i], 1e-4) {
+			t.Errorf("QuatSquadPath(%d) = %v, want %v", i, q, keys[i])
+		}
+	}
+}
+
+func TestQuatSplineSingleAxis(t *testing.T) {
+	t.Parallel()
+	// Evenly spaced keys around a single axis should be interpolated at
+	// constant angular velocity, exactly like QuatSlerp.
+	axis := Vec3{0, 1, 0}
+	var keys [4]Quat
+	for i := range keys {
+		keys[i] = QuatRotate(0.4*float32(i), &axis)
+	}
+
+	for _, amount := range []float32{0, 0.25, 0.5, 0.75, 1} {
+		expected := QuatRotate(0.4+0.4*amount, &axis)
+		if q := QuatSquadSpline(&keys[0], &keys[1], &keys[2], &keys[3], amount); !q.OrientationEqualThreshold(&expected, 1e-4) {
+			t.Errorf("QuatSquadSpline(%v) = %v, want %v", amount, q, expected)
+		}
+		if q := QuatCatmullRom(&keys[0], &keys[1], &keys[2], &keys[3], amount); !q.OrientationEqualThreshold(&expected, 1e-4) {
+			t.Errorf("QuatCatmullRom(%v) = %v, want %v", amount, q, expected)
+		}
+	}
+}
+
+func TestQuatSquadPathContinuity(t *testing.T) {
+	t.Parallel()
+	keys := []Quat{
+		QuatRotate(0, &Vec3{1, 0, 0}),
+		QuatRotate(1, &Vec3{0, 1, 0}),
+		QuatRotate(1.5, &Vec3{0, 0.6, 0.8}),
+		QuatRotate(0.5, &Vec3{0, 0, 1}),
+	}
+	// Flipping a key doesn't change the orientation so it shouldn't change
+	// the path.
+	keys[2].ScaleWith(-1)
+
+	const h = 1e-2
+	for i := 1; i < len(keys)-1; i++ {
+		k := float32(i)
+		before, at, after := QuatSquadPath(keys, k-h), QuatSquadPath(keys, k), QuatSquadPath(keys, k+h)
+		in, out := QuatAngleBetween(&before, &at), QuatAngleBetween(&at, &after)
+		if !FloatEqualThreshold(in, out, 5e-2) {
+			t.Errorf("angular speed around key %d is discontinuous: %v != %v", i, in/h, out/h)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package glm
+
+// Ray is a half line starting at Origin and going towards Direction. Direction
+// is expected to be normalized so distances along the ray are in world units,
+// the fields can be passed directly to the ray functions of package geo.
+type Ray struct {
+	Origin    Vec3
+	Direction Vec3
+}
+
+// At returns the point at distance t along r.
+func (r *Ray) At(t float32) Vec3 {
+	p := r.Origin
+	p.AddScaledVec(t, &r.Direction)
+	return p
+}
+
+// UnProjectRay returns the world space ray going through the window
+// coordinates x, y, as given by Project. The origin is on the near plane and
+// the direction is normalized. It works for both perspective and orthographic
+// projections.
+func UnProjectRay(x, y float32, view, projection *Mat4, initialX, initialY, width, height int) Ray {
+	return ClipOpenGL.UnProjectRay(x, y, view, projection, initialX, initialY, width, height)
+}
+
+// UnProjectRay returns the world space ray going through the window
+// coordinates x, y for a projection in the cs convention. The origin is on the
+// near plane and the direction is normalized. It works for both perspective
+// and orthographic projections, with finite or infinite far planes.
+func (cs *ClipSpace) UnProjectRay(x, y float32, view, projection *Mat4, initialX, initialY, width, height int) Ray {
+	var nearDepth float32
+	if cs.ReverseZ {
+		nearDepth = 1
+	}
+	// Unprojecting the far plane doesn't work when it's at infinity, half
+	// way in window depth always is finite.
+	var r Ray
+	r.Origin = cs.UnProject(&Vec3{x, y, nearDepth}, view, projection, initialX, initialY, width, height)
+	mid := cs.UnProject(&Vec3{x, y, 0.5}, view, projection, initialX, initialY, width, height)
+	r.Direction.SubOf(&mid, &r.Origin)
+	r.Direction.Normalize()
+	return r
+}
@@ -0,0 +1,51 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+func TestUnProjectRay(t *testing.T) {
+	t.Parallel()
+	eye := Vec3{1, 2, 10}
+	rh := LookAtV(&eye, &Vec3{1, 2, 0}, &Vec3{0, 1, 0})
+	flip := Scale3D(1, 1, -1)
+	lh := flip.Mul4(&rh)
+	reverse := ClipSpace{ZeroToOne: true, ReverseZ: true}
+	tests := []struct {
+		cs    ClipSpace
+		proj  Mat4
+		ortho bool
+	}{
+		{ClipOpenGL, Perspective(math.Pi/3, 1.5, 0.5, 100), false},
+		{ClipOpenGL, Ortho(-6, 6, -4, 4, 0.5, 100), true},
+		{ClipVulkan, ClipVulkan.Perspective(math.Pi/3, 1.5, 0.5, InfPos), false},
+		{reverse, reverse.Perspective(math.Pi/3, 1.5, 0.5, InfPos), false},
+		{ClipDirect3D, ClipDirect3D.Ortho(-6, 6, -4, 4, 0.5, 100), true},
+	}
+	for i, test := range tests {
+		view := rh
+		if test.cs.LeftHanded {
+			view = lh
+		}
+		for _, win := range [][2]float32{{300, 200}, {10, 390}, {599, 0}} {
+			r := test.cs.UnProjectRay(win[0], win[1], &view, &test.proj, 0, 0, 600, 400)
+			if math.Abs(r.Direction.Len()-1) > 1e-5 {
+				t.Errorf("[%d] |Direction| = %f", i, r.Direction.Len())
+			}
+			if math.Abs(r.Origin[2]-9.5) > 1e-3 {
+				t.Errorf("[%d] Origin = %v, want on the near plane", i, r.Origin)
+			}
+			if test.ortho && math.Abs(r.Direction[2]+1) > 1e-5 {
+				t.Errorf("[%d] ortho Direction = %v, want {0, 0, -1}", i, r.Direction)
+			}
+			for _, d := range []float32{0, 5, 50} {
+				p := r.At(d)
+				got := test.cs.Project(&p, &view, &test.proj, 0, 0, 600, 400)
+				if math.Abs(got[0]-win[0]) > 0.05 || math.Abs(got[1]-win[1]) > 0.05 {
+					t.Errorf("[%d] Project(At(%f)) = %v, want %v", i, d, got, win)
+				}
+			}
+		}
+	}
+}
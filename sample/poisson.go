@@ -0,0 +1,140 @@
+package sample
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+)
+
+// poissonAttempts is the number of candidates tried around each sample before
+// giving up on it, Bridson's k.
+const poissonAttempts = 30
+
+// poisson spreads points with Bridson's algorithm in the box of the first n
+// coordinates of center and half, at least radius apart.
+func (s *Sampler) poisson(center, half *[3]float32, n int, radius float32) [][3]float32 {
+	// A grid with cells small enough to hold at most one point.
+	cell := radius / math.Sqrt(float32(n))
+	var min [3]float32
+	var size [3]int
+	cells := 1
+	for k := 0; k < n; k++ {
+		min[k] = center[k] - half[k]
+		size[k] = int(math.Ceil(2*half[k]/cell)) + 1
+		cells *= size[k]
+	}
+	grid := make([]int32, cells)
+	for i := range grid {
+		grid[i] = -1
+	}
+	index := func(p *[3]float32) (idx int, c [3]int) {
+		for k := n - 1; k >= 0; k-- {
+			c[k] = int((p[k] - min[k]) / cell)
+			idx = idx*size[k] + c[k]
+		}
+		return
+	}
+
+	var points [][3]float32
+	var active []int32
+	add := func(p [3]float32) {
+		i, _ := index(&p)
+		grid[i] = int32(len(points))
+		active = append(active, int32(len(points)))
+		points = append(points, p)
+	}
+	var first [3]float32
+	for k := 0; k < n; k++ {
+		first[k] = min[k] + 2*half[k]*s.Float32()
+	}
+	add(first)
+
+	r2 := radius * radius
+	for len(active) > 0 {
+		a := s.rng.Intn(len(active))
+		origin := points[active[a]]
+		found := false
+		for attempt := 0; attempt < poissonAttempts && !found; attempt++ {
+			// A uniform point in the shell between radius and 2 radius.
+			var d glm.Vec3
+			if n == 2 {
+				sin, cos := math.Sincos(2 * math.Pi * s.Float32())
+				d = glm.Vec3{cos, sin, 0}
+				d.MulWith(radius * math.Sqrt(1+3*s.Float32()))
+			} else {
+				d = s.UnitVector()
+				d.MulWith(radius * math.Cbrt(1+7*s.Float32()))
+			}
+			var p [3]float32
+			inside := true
+			for k := 0; k < n; k++ {
+				p[k] = origin[k] + d[k]
+				inside = inside && p[k] >= min[k] && p[k] <= min[k]+2*half[k]
+			}
+			if !inside {
+				continue
+			}
+
+			_, c := index(&p)
+			found = true
+			var o [3]int
+			neighbours := 1
+			for k := 0; k < n; k++ {
+				neighbours *= 5
+			}
+			for j := 0; j < neighbours && found; j++ {
+				idx := 0
+				valid := true
+				for k, m := n-1, j; k >= 0; k-- {
+					o[k] = c[k] + m%5 - 2
+					m /= 5
+					valid = valid && o[k] >= 0 && o[k] < size[k]
+					idx = idx*size[k] + o[k]
+				}
+				if !valid || grid[idx] < 0 {
+					continue
+				}
+				q := &points[grid[idx]]
+				var dist2 float32
+				for k := 0; k < n; k++ {
+					dist2 += (p[k] - q[k]) * (p[k] - q[k])
+				}
+				found = dist2 >= r2
+			}
+			if found {
+				add(p)
+			}
+		}
+		if !found {
+			active[a] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+// PoissonDisk returns random points in b, at least radius apart, adding
+// points until no more are found with Bridson's algorithm. They are evenly
+// spread without looking regular, which is what scattering objects needs.
+func (s *Sampler) PoissonDisk(b *geo.AABB, radius float32) []glm.Vec3 {
+	points := s.poisson((*[3]float32)(&b.Center), (*[3]float32)(&b.HalfExtend), 3, radius)
+	v := make([]glm.Vec3, len(points))
+	for i := range points {
+		v[i] = glm.Vec3(points[i])
+	}
+	return v
+}
+
+// PoissonDisk2D returns random points in b at least radius apart, see
+// PoissonDisk.
+func (s *Sampler) PoissonDisk2D(b *geo2d.AABB, radius float32) []glm.Vec2 {
+	center := [3]float32{b.Center[0], b.Center[1]}
+	half := [3]float32{b.HalfExtend[0], b.HalfExtend[1]}
+	points := s.poisson(&center, &half, 2, radius)
+	v := make([]glm.Vec2, len(points))
+	for i := range points {
+		v[i] = glm.Vec2{points[i][0], points[i][1]}
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package sample
+
+import (
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+	"testing"
+)
+
+func TestSampler_PoissonDisk2D(t *testing.T) {
+	t.Parallel()
+	s := New(7)
+	b := geo2d.AABB{Center: glm.Vec2{5, 3}, HalfExtend: glm.Vec2{5, 3}}
+	const r = 0.5
+	points := s.PoissonDisk2D(&b, r)
+	for i := range points {
+		if points[i][0] < 0 || points[i][0] > 10 || points[i][1] < 0 || points[i][1] > 6 {
+			t.Errorf("[%d] %v outside", i, points[i])
+		}
+		for j := i + 1; j < len(points); j++ {
+			if d := points[i].Sub(&points[j]); d.Len() < r {
+				t.Fatalf("[%d] %v and [%d] %v are %f apart", i, points[i], j, points[j], d.Len())
+			}
+		}
+	}
+	// The box is covered: every point is less than 2r from a sample.
+	for x := float32(0); x <= 10; x += 0.25 {
+		for y := float32(0); y <= 6; y += 0.25 {
+			p := glm.Vec2{x, y}
+			covered := false
+			for i := range points {
+				if d := points[i].Sub(&p); d.Len() < 2*r {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				t.Errorf("%v is not covered", p)
+			}
+		}
+	}
+}
+
+func TestSampler_PoissonDisk(t *testing.T) {
+	t.Parallel()
+	s := New(8)
+	b := geo.AABB{Center: glm.Vec3{0, 0, 0}, HalfExtend: glm.Vec3{2, 2, 1}}
+	const r = 0.5
+	points := s.PoissonDisk(&b, r)
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			if d := points[i].Sub(&points[j]); d.Len() < r {
+				t.Fatalf("[%d] %v and [%d] %v are %f apart", i, points[i], j, points[j], d.Len())
+			}
+		}
+	}
+	// At least as dense as a grid of cells 2r wide.
+	if min := 2 * 2 * 1; len(points) < min {
+		t.Errorf("%d points, want at least %d", len(points), min)
+	}
+	for x := float32(-2); x <= 2; x += 0.5 {
+		for y := float32(-2); y <= 2; y += 0.5 {
+			for z := float32(-1); z <= 1; z += 0.5 {
+				p := glm.Vec3{x, y, z}
+				covered := false
+				for i := range points {
+					if d := points[i].Sub(&p); d.Len() < 2*r {
+						covered = true
+						break
+					}
+				}
+				if !covered {
+					t.Errorf("%v is not covered", p)
+				}
+			}
+		}
+	}
+}
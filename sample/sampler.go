@@ -0,0 +1,297 @@
+// Package sample draws random points from geometric distributions, uniformly
+// in and on the shapes of geo and geo2d, on hemispheres and over rotations,
+// spreads Poisson disk samples and generates the Halton, Sobol and R2
+// low-discrepancy sequences.
+//
+// The random samples come from a seeded Sampler, the same seed gives the same
+// samples on every platform.
+package sample
+
+import (
+	"math/rand"
+
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+)
+
+// Sampler draws samples from a seeded pseudo random generator. It isn't safe
+// for concurrent use, use one Sampler per goroutine.
+type Sampler struct {
+	rng *rand.Rand
+}
+
+// New returns a sampler seeded with seed.
+func New(seed int64) *Sampler {
+	return &Sampler{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Float32 returns a uniform number in [0, 1).
+func (s *Sampler) Float32() float32 {
+	return s.rng.Float32()
+}
+
+// Range returns a uniform number in [min, max).
+func (s *Sampler) Range(min, max float32) float32 {
+	return min + (max-min)*s.rng.Float32()
+}
+
+// UnitVector returns a uniform direction.
+func (s *Sampler) UnitVector() glm.Vec3 {
+	z := 1 - 2*s.Float32()
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	sin, cos := math.Sincos(2 * math.Pi * s.Float32())
+	return glm.Vec3{r * cos, r * sin, z}
+}
+
+// InSphere returns a uniform point in the ball of sp.
+func (s *Sampler) InSphere(sp *geo.Sphere) glm.Vec3 {
+	d := s.UnitVector()
+	p := sp.Center
+	p.AddScaledVec(sp.Radius*math.Cbrt(s.Float32()), &d)
+	return p
+}
+
+// OnSphere returns a uniform point on the surface of sp.
+func (s *Sampler) OnSphere(sp *geo.Sphere) glm.Vec3 {
+	d := s.UnitVector()
+	p := sp.Center
+	p.AddScaledVec(sp.Radius, &d)
+	return p
+}
+
+// InAABB returns a uniform point in b.
+func (s *Sampler) InAABB(b *geo.AABB) glm.Vec3 {
+	var p glm.Vec3
+	for i := range p {
+		p[i] = b.Center[i] + b.HalfExtend[i]*(2*s.Float32()-1)
+	}
+	return p
+}
+
+// onBox returns a uniform point on the surface of the box of half extents h
+// centered on the origin.
+func (s *Sampler) onBox(h *glm.Vec3) glm.Vec3 {
+	// The faces perpendicular to each axis, weighted by their area.
+	areas := glm.Vec3{h[1] * h[2], h[0] * h[2], h[0] * h[1]}
+	x := s.Float32() * (areas[0] + areas[1] + areas[2])
+	axis := 2
+	if x < areas[0] {
+		axis = 0
+	} else if x < areas[0]+areas[1] {
+		axis = 1
+	}
+	var p glm.Vec3
+	for i := range p {
+		p[i] = h[i] * (2*s.Float32() - 1)
+	}
+	p[axis] = h[axis]
+	if s.rng.Intn(2) == 0 {
+		p[axis] = -h[axis]
+	}
+	return p
+}
+
+// OnAABB returns a uniform point on the surface of b.
+func (s *Sampler) OnAABB(b *geo.AABB) glm.Vec3 {
+	p := s.onBox(&b.HalfExtend)
+	return p.Add(&b.Center)
+}
+
+// obbPoint returns the point of b at the local coordinates p.
+func obbPoint(b *geo.OBB, p *glm.Vec3) glm.Vec3 {
+	q := b.Center
+	for i := range p {
+		q.AddScaledVec(p[i], &b.Orientation[i])
+	}
+	return q
+}
+
+// InOBB returns a uniform point in b.
+func (s *Sampler) InOBB(b *geo.OBB) glm.Vec3 {
+	var p glm.Vec3
+	for i := range p {
+		p[i] = b.HalfExtend[i] * (2*s.Float32() - 1)
+	}
+	return obbPoint(b, &p)
+}
+
+// OnOBB returns a uniform point on the surface of b.
+func (s *Sampler) OnOBB(b *geo.OBB) glm.Vec3 {
+	p := s.onBox(&b.HalfExtend)
+	return obbPoint(b, &p)
+}
+
+// InCapsule returns a uniform point in c.
+func (s *Sampler) InCapsule(c *geo.Capsule) glm.Vec3 {
+	axis := c.B.Sub(&c.A)
+	h := axis.Len()
+	ball := geo.Sphere{Radius: c.Radius}
+	if h < 1e-6 {
+		ball.Center = c.A
+		return s.InSphere(&ball)
+	}
+	axis.MulWith(1 / h)
+
+	// The cylinder against the two half balls, weighted by their volume.
+	cylinder := h
+	balls := 4.0 / 3 * c.Radius
+	if s.Float32()*(cylinder+balls) < cylinder {
+		t1, t2 := basis(&axis)
+		d := s.inUnitDisk()
+		p := c.A
+		p.AddScaledVec(h*s.Float32(), &axis)
+		p.AddScaledVec(c.Radius*d[0], &t1)
+		p.AddScaledVec(c.Radius*d[1], &t2)
+		return p
+	}
+	p := s.InSphere(&ball)
+	// Each half of the ball goes on its end.
+	if p.Dot(&axis) >= 0 {
+		return p.Add(&c.B)
+	}
+	return p.Add(&c.A)
+}
+
+// OnCapsule returns a uniform point on the surface of c.
+func (s *Sampler) OnCapsule(c *geo.Capsule) glm.Vec3 {
+	axis := c.B.Sub(&c.A)
+	h := axis.Len()
+	ball := geo.Sphere{Radius: c.Radius}
+	if h < 1e-6 {
+		ball.Center = c.A
+		return s.OnSphere(&ball)
+	}
+	axis.MulWith(1 / h)
+
+	// The side of the cylinder against the two half spheres, weighted by
+	// their area.
+	side := h
+	ends := 2 * c.Radius
+	if s.Float32()*(side+ends) < side {
+		t1, t2 := basis(&axis)
+		sin, cos := math.Sincos(2 * math.Pi * s.Float32())
+		p := c.A
+		p.AddScaledVec(h*s.Float32(), &axis)
+		p.AddScaledVec(c.Radius*cos, &t1)
+		p.AddScaledVec(c.Radius*sin, &t2)
+		return p
+	}
+	p := s.OnSphere(&ball)
+	if p.Dot(&axis) >= 0 {
+		return p.Add(&c.B)
+	}
+	return p.Add(&c.A)
+}
+
+// InTriangle returns a uniform point in the triangle abc.
+func (s *Sampler) InTriangle(a, b, c *glm.Vec3) glm.Vec3 {
+	u, v, w := s.barycentric()
+	p := a.Mul(u)
+	p.AddScaledVec(v, b)
+	p.AddScaledVec(w, c)
+	return p
+}
+
+// InTriangle2D returns a uniform point in the triangle abc.
+func (s *Sampler) InTriangle2D(a, b, c *glm.Vec2) glm.Vec2 {
+	u, v, w := s.barycentric()
+	p := a.Mul(u)
+	p.AddScaledVec(v, b)
+	p.AddScaledVec(w, c)
+	return p
+}
+
+// barycentric returns uniform barycentric coordinates of a triangle.
+func (s *Sampler) barycentric() (u, v, w float32) {
+	// Folding the unit square in half would also work, the square root
+	// keeps neighbouring random numbers close in the triangle.
+	r := math.Sqrt(s.Float32())
+	t := s.Float32()
+	return 1 - r, r * (1 - t), r * t
+}
+
+// inUnitDisk returns a uniform point in the unit disk, with the concentric
+// mapping of Shirley and Chiu which keeps the strata of the unit square.
+func (s *Sampler) inUnitDisk() glm.Vec2 {
+	x, y := 2*s.Float32()-1, 2*s.Float32()-1
+	if x == 0 && y == 0 {
+		return glm.Vec2{}
+	}
+	var r, theta float32
+	if math.Abs(x) > math.Abs(y) {
+		r, theta = x, math.Pi/4*(y/x)
+	} else {
+		r, theta = y, math.Pi/2-math.Pi/4*(x/y)
+	}
+	sin, cos := math.Sincos(theta)
+	return glm.Vec2{r * cos, r * sin}
+}
+
+// InDisk returns a uniform point in the disk of the given center, normal and
+// radius. normal must be normalized.
+func (s *Sampler) InDisk(center, normal *glm.Vec3, radius float32) glm.Vec3 {
+	t1, t2 := basis(normal)
+	d := s.inUnitDisk()
+	p := *center
+	p.AddScaledVec(radius*d[0], &t1)
+	p.AddScaledVec(radius*d[1], &t2)
+	return p
+}
+
+// InCircle returns a uniform point in the disk of c.
+func (s *Sampler) InCircle(c *geo2d.Circle) glm.Vec2 {
+	d := s.inUnitDisk()
+	p := c.Center
+	p.AddScaledVec(c.Radius, &d)
+	return p
+}
+
+// OnCircle returns a uniform point on the circle c.
+func (s *Sampler) OnCircle(c *geo2d.Circle) glm.Vec2 {
+	sin, cos := math.Sincos(2 * math.Pi * s.Float32())
+	return glm.Vec2{c.Center[0] + c.Radius*cos, c.Center[1] + c.Radius*sin}
+}
+
+// InAABB2D returns a uniform point in b.
+func (s *Sampler) InAABB2D(b *geo2d.AABB) glm.Vec2 {
+	return glm.Vec2{
+		b.Center[0] + b.HalfExtend[0]*(2*s.Float32()-1),
+		b.Center[1] + b.HalfExtend[1]*(2*s.Float32()-1),
+	}
+}
+
+// CosineHemisphere returns a direction in the hemisphere around normal with a
+// density proportional to the cosine of its angle with normal, the
+// distribution of diffuse reflections. normal must be normalized.
+func (s *Sampler) CosineHemisphere(normal *glm.Vec3) glm.Vec3 {
+	// Malley's method, points of the disk projected up on the hemisphere.
+	d := s.inUnitDisk()
+	z := math.Sqrt(math.Max(0, 1-d[0]*d[0]-d[1]*d[1]))
+	t1, t2 := basis(normal)
+	v := normal.Mul(z)
+	v.AddScaledVec(d[0], &t1)
+	v.AddScaledVec(d[1], &t2)
+	return v
+}
+
+// Quat returns a uniform random rotation, with Shoemake's method.
+func (s *Sampler) Quat() glm.Quat {
+	u1, u2, u3 := s.Float32(), s.Float32(), s.Float32()
+	r1, r2 := math.Sqrt(1-u1), math.Sqrt(u1)
+	sin2, cos2 := math.Sincos(2 * math.Pi * u2)
+	sin3, cos3 := math.Sincos(2 * math.Pi * u3)
+	return glm.Quat{W: r2 * cos3, V: glm.Vec3{r1 * sin2, r1 * cos2, r2 * sin3}}
+}
+
+// basis returns two unit vectors making an orthonormal basis with the unit
+// vector n, see Duff et al. "Building an Orthonormal Basis, Revisited".
+func basis(n *glm.Vec3) (t1, t2 glm.Vec3) {
+	sign := math.Copysign(1, n[2])
+	a := -1 / (sign + n[2])
+	b := n[0] * n[1] * a
+	t1 = glm.Vec3{1 + sign*n[0]*n[0]*a, sign * b, -sign * n[0]}
+	t2 = glm.Vec3{b, sign + n[1]*n[1]*a, -n[1]}
+	return
+}
@@ -0,0 +1,249 @@
+package sample
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"github.com/engoengine/glm/geo2d"
+	"testing"
+)
+
+const samples = 20000
+
+func TestSampler_Deterministic(t *testing.T) {
+	t.Parallel()
+	a, b := New(42), New(42)
+	for i := 0; i < 100; i++ {
+		if p, q := a.UnitVector(), b.UnitVector(); p != q {
+			t.Fatalf("[%d] %v != %v with the same seed", i, p, q)
+		}
+	}
+	c := New(43)
+	if p, q := a.Quat(), c.Quat(); p == q {
+		t.Errorf("seeds 42 and 43 give the same samples")
+	}
+}
+
+func TestSampler_Sphere(t *testing.T) {
+	t.Parallel()
+	s := New(1)
+	sp := geo.Sphere{Center: glm.Vec3{1, 2, 3}, Radius: 2, Radius2: 4}
+	var mean glm.Vec3
+	var inner int
+	for i := 0; i < samples; i++ {
+		p := s.InSphere(&sp)
+		d := p.Sub(&sp.Center)
+		if l := d.Len(); l > sp.Radius*(1+1e-5) {
+			t.Fatalf("InSphere() = %v, %f from the center", p, l)
+		} else if l < sp.Radius/2 {
+			inner++
+		}
+		mean.AddWith(&d)
+
+		p = s.OnSphere(&sp)
+		d = p.Sub(&sp.Center)
+		if l := d.Len(); math.Abs(l-sp.Radius) > 1e-5 {
+			t.Fatalf("OnSphere() = %v, %f from the center", p, l)
+		}
+	}
+	// An eighth of the volume is in the inner half radius.
+	if f := float32(inner) / samples; math.Abs(f-0.125) > 0.01 {
+		t.Errorf("InSphere() inner fraction = %f, want 0.125", f)
+	}
+	if mean.MulWith(1.0 / samples); mean.Len() > 0.05 {
+		t.Errorf("InSphere() mean offset = %v", mean)
+	}
+}
+
+func TestSampler_Box(t *testing.T) {
+	t.Parallel()
+	s := New(2)
+	b := geo.AABB{Center: glm.Vec3{1, -1, 0}, HalfExtend: glm.Vec3{1, 2, 0.5}}
+	rot := glm.QuatRotate(0.7, &glm.Vec3{1, 1, 0})
+	rot.Normalize()
+	m := rot.Mat3()
+	o := geo.OBB{Center: b.Center, HalfExtend: b.HalfExtend, Orientation: [3]glm.Vec3{m.Col(0), m.Col(1), m.Col(2)}}
+
+	// Count the samples on the faces perpendicular to each axis.
+	var faces [3]int
+	for i := 0; i < samples; i++ {
+		p := s.InAABB(&b)
+		for k := range p {
+			if math.Abs(p[k]-b.Center[k]) > b.HalfExtend[k] {
+				t.Fatalf("InAABB() = %v outside", p)
+			}
+		}
+
+		p = s.OnAABB(&b)
+		on := -1
+		for k := range p {
+			d := math.Abs(p[k] - b.Center[k])
+			if d > b.HalfExtend[k]+1e-5 {
+				t.Fatalf("OnAABB() = %v outside", p)
+			}
+			if math.Abs(d-b.HalfExtend[k]) < 1e-5 {
+				on = k
+			}
+		}
+		if on < 0 {
+			t.Fatalf("OnAABB() = %v not on a face", p)
+		}
+		faces[on]++
+
+		for _, p := range []glm.Vec3{s.InOBB(&o), s.OnOBB(&o)} {
+			d := p.Sub(&o.Center)
+			for k := range d {
+				if math.Abs(o.Orientation[k].Dot(&d)) > o.HalfExtend[k]+1e-5 {
+					t.Fatalf("InOBB() or OnOBB() = %v outside", p)
+				}
+			}
+		}
+	}
+	// The faces are 1x4, 2x1 and 2x4.
+	want := [3]float32{4.0 / 14, 2.0 / 14, 8.0 / 14}
+	for k := range faces {
+		if f := float32(faces[k]) / samples; math.Abs(f-want[k]) > 0.015 {
+			t.Errorf("OnAABB() fraction on the faces %d = %f, want %f", k, f, want[k])
+		}
+	}
+}
+
+// segmentDistance returns the distance from p to the segment ab.
+func segmentDistance(a, b, p *glm.Vec3) float32 {
+	ab, ap := b.Sub(a), p.Sub(a)
+	t := math.Clamp(ap.Dot(&ab)/ab.Len2(), 0, 1)
+	q := *a
+	q.AddScaledVec(t, &ab)
+	d := p.Sub(&q)
+	return d.Len()
+}
+
+func TestSampler_Capsule(t *testing.T) {
+	t.Parallel()
+	s := New(3)
+	c := geo.Capsule{A: glm.Vec3{0, 0, 0}, B: glm.Vec3{0, 3, 0}, Radius: 1}
+	var cylinderIn, cylinderOn int
+	for i := 0; i < samples; i++ {
+		p := s.InCapsule(&c)
+		if d := segmentDistance(&c.A, &c.B, &p); d > c.Radius+1e-5 {
+			t.Fatalf("InCapsule() = %v, %f from the axis", p, d)
+		}
+		if p[1] > 0 && p[1] < 3 {
+			cylinderIn++
+		}
+		p = s.OnCapsule(&c)
+		if d := segmentDistance(&c.A, &c.B, &p); math.Abs(d-c.Radius) > 1e-5 {
+			t.Fatalf("OnCapsule() = %v, %f from the axis", p, d)
+		}
+		if p[1] > 0 && p[1] < 3 {
+			cylinderOn++
+		}
+	}
+	// The cylinder holds 3π of the 3π + 4π/3 volume and 6π of the 6π + 4π
+	// area.
+	if f := float32(cylinderIn) / samples; math.Abs(f-9.0/13) > 0.015 {
+		t.Errorf("InCapsule() cylinder fraction = %f, want %f", f, 9.0/13)
+	}
+	if f := float32(cylinderOn) / samples; math.Abs(f-0.6) > 0.015 {
+		t.Errorf("OnCapsule() cylinder fraction = %f, want 0.6", f)
+	}
+}
+
+func TestSampler_Flat(t *testing.T) {
+	t.Parallel()
+	s := New(4)
+	a, b, c := glm.Vec3{0, 0, 0}, glm.Vec3{3, 0, 0}, glm.Vec3{0, 3, 3}
+	a2, b2, c2 := glm.Vec2{0, 0}, glm.Vec2{3, 0}, glm.Vec2{0, 3}
+	circle := geo2d.Circle{Center: glm.Vec2{1, 1}, Radius: 2}
+	normal := glm.Vec3{0, 0.6, 0.8}
+	var mean glm.Vec3
+	var mean2 glm.Vec2
+	var inner int
+	for i := 0; i < samples; i++ {
+		p := s.InTriangle(&a, &b, &c)
+		// In the plane x + y = z, with positive barycentric coordinates.
+		if math.Abs(p[1]-p[2]) > 1e-5 || p[0] < 0 || p[1] < 0 || p[0]+p[1] > 3+1e-5 {
+			t.Fatalf("InTriangle() = %v outside", p)
+		}
+		mean.AddWith(&p)
+		q := s.InTriangle2D(&a2, &b2, &c2)
+		mean2.AddWith(&q)
+
+		q = s.InCircle(&circle)
+		d := q.Sub(&circle.Center)
+		if l := d.Len(); l > circle.Radius+1e-5 {
+			t.Fatalf("InCircle() = %v outside", q)
+		} else if l < circle.Radius/2 {
+			inner++
+		}
+		q = s.OnCircle(&circle)
+		if d := q.Sub(&circle.Center); math.Abs(d.Len()-circle.Radius) > 1e-5 {
+			t.Fatalf("OnCircle() = %v not on the circle", q)
+		}
+
+		p = s.InDisk(&glm.Vec3{}, &normal, 2)
+		if math.Abs(p.Dot(&normal)) > 1e-5 || p.Len() > 2+1e-5 {
+			t.Fatalf("InDisk() = %v outside", p)
+		}
+	}
+	// The mean is the centroid.
+	mean.MulWith(1.0 / samples)
+	if want := (glm.Vec3{1, 1, 1}); !mean.EqualThreshold(&want, 0.03) {
+		t.Errorf("InTriangle() mean = %v, want %v", mean, want)
+	}
+	mean2.MulWith(1.0 / samples)
+	if want := (glm.Vec2{1, 1}); !mean2.EqualThreshold(&want, 0.03) {
+		t.Errorf("InTriangle2D() mean = %v, want %v", mean2, want)
+	}
+	if f := float32(inner) / samples; math.Abs(f-0.25) > 0.015 {
+		t.Errorf("InCircle() inner fraction = %f, want 0.25", f)
+	}
+}
+
+func TestSampler_CosineHemisphere(t *testing.T) {
+	t.Parallel()
+	s := New(5)
+	for _, n := range []glm.Vec3{{0, 0, 1}, {0, 0, -1}, {1, 0, 0}, {0.48, -0.6, 0.64}} {
+		var sum float32
+		for i := 0; i < samples; i++ {
+			d := s.CosineHemisphere(&n)
+			if math.Abs(d.Len()-1) > 1e-4 {
+				t.Fatalf("CosineHemisphere(%v) = %v not normalized", n, d)
+			}
+			cos := d.Dot(&n)
+			if cos < 0 {
+				t.Fatalf("CosineHemisphere(%v) = %v below", n, d)
+			}
+			sum += cos
+		}
+		// The mean cosine of the cosine distribution is 2/3.
+		if m := sum / samples; math.Abs(m-2.0/3) > 0.01 {
+			t.Errorf("CosineHemisphere(%v) mean cosine = %f, want 2/3", n, m)
+		}
+	}
+}
+
+func TestSampler_Quat(t *testing.T) {
+	t.Parallel()
+	s := New(6)
+	x := glm.Vec3{1, 0, 0}
+	var mean glm.Vec3
+	var w2 float32
+	for i := 0; i < samples; i++ {
+		q := s.Quat()
+		if math.Abs(q.Len()-1) > 1e-5 {
+			t.Fatalf("Quat() = %v not normalized", q)
+		}
+		v := q.Rotate(&x)
+		mean.AddWith(&v)
+		w2 += q.W * q.W
+	}
+	// Uniform rotations send x anywhere and each component squared averages
+	// 1/4.
+	if mean.MulWith(1.0 / samples); mean.Len() > 0.03 {
+		t.Errorf("Quat() rotated X mean = %v", mean)
+	}
+	if m := w2 / samples; math.Abs(m-0.25) > 0.01 {
+		t.Errorf("Quat() mean W² = %f, want 0.25", m)
+	}
+}
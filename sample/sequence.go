@@ -0,0 +1,114 @@
+package sample
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Halton returns the i-th number of the Halton sequence of the given prime
+// base, the radical inverse of i: its digits in base mirrored around the
+// decimal point. It's in [0, 1) and 0 for i = 0.
+func Halton(i int, base int) float32 {
+	var r float64
+	f := 1 / float64(base)
+	for w := f; i > 0; i /= base {
+		r += w * float64(i%base)
+		w *= f
+	}
+	return float32(r)
+}
+
+// Halton2 returns the i-th point of the 2D Halton sequence, of bases 2 and 3.
+func Halton2(i int) glm.Vec2 {
+	return glm.Vec2{Halton(i, 2), Halton(i, 3)}
+}
+
+// Halton3 returns the i-th point of the 3D Halton sequence, of bases 2, 3 and
+// 5.
+func Halton3(i int) glm.Vec3 {
+	return glm.Vec3{Halton(i, 2), Halton(i, 3), Halton(i, 5)}
+}
+
+// SobolDimensions is the number of dimensions of the Sobol sequence.
+const SobolDimensions = 8
+
+// sobolPolynomials are the degree s, coefficients a and initial direction
+// numbers m of the dimensions after the first, from Joe and Kuo's
+// new-joe-kuo-6.21201.
+var sobolPolynomials = [SobolDimensions - 1]struct {
+	s, a uint32
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+}
+
+// sobolDirections are the 32 direction numbers of each dimension.
+var sobolDirections = func() (v [SobolDimensions][32]uint32) {
+	// The first dimension is the van der Corput sequence in base 2.
+	for k := range v[0] {
+		v[0][k] = 1 << uint(31-k)
+	}
+	for d, p := range sobolPolynomials {
+		dir := &v[d+1]
+		for k := uint32(0); k < 32; k++ {
+			if k < p.s {
+				dir[k] = p.m[k] << (31 - k)
+				continue
+			}
+			dir[k] = dir[k-p.s] ^ dir[k-p.s]>>p.s
+			for j := uint32(1); j < p.s; j++ {
+				if p.a>>(p.s-1-j)&1 != 0 {
+					dir[k] ^= dir[k-j]
+				}
+			}
+		}
+	}
+	return
+}()
+
+// Sobol returns coordinate dim, below SobolDimensions, of the i-th point of
+// the Sobol sequence, in Gray code order. Every run of 2^k points starting at
+// a multiple of 2^k has one point in each interval of size 2^-k, in each
+// dimension.
+func Sobol(i uint32, dim int) float32 {
+	g := i ^ i>>1
+	var x uint32
+	for k := 0; g != 0; k, g = k+1, g>>1 {
+		if g&1 != 0 {
+			x ^= sobolDirections[dim][k]
+		}
+	}
+	// The 24 high bits fit a float32 exactly, keeping the result below 1.
+	return float32(x>>8) / (1 << 24)
+}
+
+// Sobol2 returns the i-th point of the 2D Sobol sequence.
+func Sobol2(i uint32) glm.Vec2 {
+	return glm.Vec2{Sobol(i, 0), Sobol(i, 1)}
+}
+
+// Sobol3 returns the i-th point of the 3D Sobol sequence.
+func Sobol3(i uint32) glm.Vec3 {
+	return glm.Vec3{Sobol(i, 0), Sobol(i, 1), Sobol(i, 2)}
+}
+
+// r2Alpha are the inverse of the plastic number and of its square, the steps
+// of the R2 sequence.
+var r2Alpha = [2]float64{0.7548776662466927, 0.5698402909980532}
+
+// R2 returns the i-th point of Roberts' R2 sequence, the additive recurrence
+// on the plastic number. It's more uniform than Halton and Sobol for small
+// counts and works with any number of points.
+func R2(i int) glm.Vec2 {
+	var p glm.Vec2
+	for k := range p {
+		x := 0.5 + r2Alpha[k]*float64(i)
+		p[k] = float32(x - float64(int64(x)))
+	}
+	return p
+}
@@ -0,0 +1,120 @@
+package sample
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestHalton(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		i, base int
+		want    float32
+	}{
+		{0, 2, 0},
+		{1, 2, 0.5},
+		{2, 2, 0.25},
+		{3, 2, 0.75},
+		{6, 2, 0.375},
+		{1, 3, 1.0 / 3},
+		{2, 3, 2.0 / 3},
+		{3, 3, 1.0 / 9},
+		{7, 3, 5.0 / 9},
+		{4, 5, 0.8},
+		{5, 5, 0.04},
+	}
+	for _, test := range tests {
+		if got := Halton(test.i, test.base); math.Abs(got-test.want) > 1e-7 {
+			t.Errorf("Halton(%d, %d) = %f, want %f", test.i, test.base, got, test.want)
+		}
+	}
+	if got, want := Halton3(7), (glm.Vec3{0.875, 5.0 / 9, 0.44}); !got.EqualThreshold(&want, 1e-6) {
+		t.Errorf("Halton3(7) = %v, want %v", got, want)
+	}
+}
+
+func TestSobol(t *testing.T) {
+	t.Parallel()
+	want := []glm.Vec3{
+		{0, 0, 0},
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+		{0.625, 0.125, 0.875},
+		{0.125, 0.625, 0.375},
+	}
+	for i := range want {
+		if got := Sobol3(uint32(i)); got != want[i] {
+			t.Errorf("Sobol3(%d) = %v, want %v", i, got, want[i])
+		}
+	}
+
+	// Every dimension is stratified: 2^k points from a multiple of 2^k
+	// fill the 2^k intervals.
+	for dim := 0; dim < SobolDimensions; dim++ {
+		for _, k := range []uint32{4, 7} {
+			n := uint32(1) << k
+			for start := uint32(0); start < 4*n; start += n {
+				seen := make([]bool, n)
+				for i := start; i < start+n; i++ {
+					seen[int(Sobol(i, dim)*float32(n))] = true
+				}
+				for j := range seen {
+					if !seen[j] {
+						t.Errorf("dimension %d: interval %d/%d empty from %d", dim, j, n, start)
+					}
+				}
+			}
+		}
+	}
+
+	// The first two dimensions are stratified together.
+	const n = 64
+	for _, shape := range [][2]int{{1, 64}, {2, 32}, {8, 8}, {16, 4}} {
+		seen := make(map[[2]int]bool)
+		for i := uint32(0); i < n; i++ {
+			p := Sobol2(i)
+			seen[[2]int{int(p[0] * float32(shape[0])), int(p[1] * float32(shape[1]))}] = true
+		}
+		if len(seen) != n {
+			t.Errorf("%dx%d boxes: %d filled, want %d", shape[0], shape[1], len(seen), n)
+		}
+	}
+}
+
+func TestR2(t *testing.T) {
+	t.Parallel()
+	if got := R2(0); got != (glm.Vec2{0.5, 0.5}) {
+		t.Errorf("R2(0) = %v, want [0.5 0.5]", got)
+	}
+	if got, want := R2(1), (glm.Vec2{0.2548777, 0.0698403}); !got.EqualThreshold(&want, 1e-6) {
+		t.Errorf("R2(1) = %v, want %v", got, want)
+	}
+
+	// The points are well spread: no two of the first n are closer than
+	// about 1/√n.
+	const n = 256
+	points := make([]glm.Vec2, n)
+	for i := range points {
+		points[i] = R2(i)
+		if points[i][0] < 0 || points[i][0] >= 1 || points[i][1] < 0 || points[i][1] >= 1 {
+			t.Errorf("R2(%d) = %v outside [0, 1)", i, points[i])
+		}
+	}
+	for i := range points {
+		for j := i + 1; j < n; j++ {
+			// On the torus.
+			d := points[i].Sub(&points[j])
+			for k := range d {
+				d[k] = math.Abs(d[k])
+				d[k] = math.Min(d[k], 1-d[k])
+			}
+			if d.Len() < 0.5/math.Sqrt(n) {
+				t.Errorf("R2(%d) and R2(%d) are %f apart", i, j, d.Len())
+			}
+		}
+	}
+}
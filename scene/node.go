@@ -0,0 +1,252 @@
+// Package scene implements a transform hierarchy. Every Node has a local
+// translation, rotation and scale relative to its parent, the world matrices
+// are computed lazily and cached until something above them changes.
+package scene
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// dirty flags of the cached matrices.
+const (
+	dirtyLocal = 1 << iota
+	dirtyWorld
+	dirtyInvWorld
+)
+
+// Node is an element of a transform hierarchy. Nodes must be created with
+// NewNode.
+//
+// The world matrix of a node is parent.World() * Local(). A dirty node always
+// has dirty descendants, so marking a subtree dirty stops as soon as it finds
+// a node that already is.
+type Node struct {
+	trs glm.TRS
+
+	parent   *Node
+	children []*Node
+
+	dirty                  uint8
+	local, world, invWorld glm.Mat3x4
+}
+
+// NewNode returns a new root node with the identity transform.
+func NewNode() *Node {
+	return &Node{
+		trs:   glm.TRSIdent(),
+		dirty: dirtyLocal | dirtyWorld | dirtyInvWorld,
+	}
+}
+
+// Translation returns the local translation of n.
+func (n *Node) Translation() glm.Vec3 {
+	return n.trs.Translation
+}
+
+// SetTranslation sets the local translation of n.
+func (n *Node) SetTranslation(t *glm.Vec3) {
+	n.trs.Translation = *t
+	n.invalidate()
+}
+
+// Rotation returns the local rotation of n.
+func (n *Node) Rotation() glm.Quat {
+	return n.trs.Rotation
+}
+
+// SetRotation sets the local rotation of n.
+func (n *Node) SetRotation(r *glm.Quat) {
+	n.trs.Rotation = *r
+	n.invalidate()
+}
+
+// Scale returns the local scale of n.
+func (n *Node) Scale() glm.Vec3 {
+	return n.trs.Scale
+}
+
+// SetScale sets the local scale of n.
+func (n *Node) SetScale(s *glm.Vec3) {
+	n.trs.Scale = *s
+	n.invalidate()
+}
+
+// TRS returns the local translation, rotation and scale of n.
+func (n *Node) TRS() glm.TRS {
+	return n.trs
+}
+
+// SetTRS sets the local translation, rotation and scale of n at once.
+func (n *Node) SetTRS(t *glm.TRS) {
+	n.trs = *t
+	n.invalidate()
+}
+
+// invalidate marks the local matrix of n and the world matrices of n and its
+// descendants dirty.
+func (n *Node) invalidate() {
+	n.dirty |= dirtyLocal
+	n.invalidateWorld()
+}
+
+// invalidateWorld marks the world matrices of n and its descendants dirty.
+func (n *Node) invalidateWorld() {
+	if n.dirty&dirtyWorld != 0 {
+		return
+	}
+	stack := []*Node{n}
+	for len(stack) > 0 {
+		m := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		m.dirty |= dirtyWorld | dirtyInvWorld
+		for _, c := range m.children {
+			if c.dirty&dirtyWorld == 0 {
+				stack = append(stack, c)
+			}
+		}
+	}
+}
+
+// Parent returns the parent of n, nil for roots.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the children of n. The slice must not be modified.
+func (n *Node) Children() []*Node {
+	return n.children
+}
+
+// SetParent moves n under parent, nil makes n a root. If keepWorld is true
+// the local transform is changed so the world transform stays the same,
+// except for shear which can't be represented by a translation, rotation and
+// scale. Otherwise the local transform is kept and n moves with its new
+// parent.
+//
+// SetParent panics if parent is n or one of its descendants.
+func (n *Node) SetParent(parent *Node, keepWorld bool) {
+	for p := parent; p != nil; p = p.parent {
+		if p == n {
+			panic("scene: node can't be its own ancestor")
+		}
+	}
+
+	if keepWorld {
+		local := n.World()
+		if parent != nil {
+			inv := parent.InverseWorld()
+			local = inv.Mul3x4(&local)
+		}
+		m := local.Mat4()
+		if trs, ok := glm.TRSFromMat4(&m); ok {
+			n.trs = trs
+			n.dirty |= dirtyLocal
+		}
+	}
+
+	if n.parent != nil {
+		siblings := n.parent.children
+		for i, c := range siblings {
+			if c == n {
+				n.parent.children = append(siblings[:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+
+	n.invalidateWorld()
+}
+
+// Local returns the transform of n relative to its parent.
+func (n *Node) Local() glm.Mat3x4 {
+	if n.dirty&dirtyLocal != 0 {
+		n.local = n.trs.Mat3x4()
+		n.dirty &^= dirtyLocal
+	}
+	return n.local
+}
+
+// World returns the transform of n relative to the world, recomputing the
+// dirty ancestors if needed.
+func (n *Node) World() glm.Mat3x4 {
+	if n.dirty&dirtyWorld != 0 {
+		// Walk up to the first clean ancestor then back down, a clean node
+		// never has a dirty ancestor.
+		var path []*Node
+		for m := n; m != nil && m.dirty&dirtyWorld != 0; m = m.parent {
+			path = append(path, m)
+		}
+		for i := len(path) - 1; i >= 0; i-- {
+			path[i].updateWorld()
+		}
+	}
+	return n.world
+}
+
+// updateWorld recomputes the world matrix of n, its parent must be clean.
+func (n *Node) updateWorld() {
+	local := n.Local()
+	if n.parent == nil {
+		n.world = local
+	} else {
+		n.world.Mul3x4Of(&n.parent.world, &local)
+	}
+	n.dirty &^= dirtyWorld
+}
+
+// WorldMat4 returns the transform of n relative to the world as a Mat4.
+func (n *Node) WorldMat4() glm.Mat4 {
+	w := n.World()
+	return w.Mat4()
+}
+
+// WorldTransform returns the transform of n relative to the world as a
+// Transform, ready to upload with Transform.Pointer.
+func (n *Node) WorldTransform() glm.Transform {
+	return glm.Transform(n.WorldMat4())
+}
+
+// InverseWorld returns the transform from world space to the local space of
+// n. It's cached until n or one of its ancestors changes.
+func (n *Node) InverseWorld() glm.Mat3x4 {
+	if n.dirty&dirtyInvWorld != 0 {
+		w := n.World()
+		n.invWorld = w.Inverse()
+		n.dirty &^= dirtyInvWorld
+	}
+	return n.invWorld
+}
+
+// LocalToWorld transforms the point v from the local space of n to world
+// space.
+func (n *Node) LocalToWorld(v *glm.Vec3) glm.Vec3 {
+	w := n.World()
+	return w.Mul3x1(v)
+}
+
+// WorldToLocal transforms the point v from world space to the local space of
+// n.
+func (n *Node) WorldToLocal(v *glm.Vec3) glm.Vec3 {
+	inv := n.InverseWorld()
+	return inv.Mul3x1(v)
+}
+
+// Update recomputes the world matrices of n and all its descendants, parents
+// before children. It's cheaper than calling World on every node when most of
+// the hierarchy changed.
+func (n *Node) Update() {
+	n.World()
+	stack := append([]*Node(nil), n.children...)
+	for len(stack) > 0 {
+		m := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if m.dirty&dirtyWorld != 0 {
+			m.updateWorld()
+		}
+		stack = append(stack, m.children...)
+	}
+}
@@ -0,0 +1,153 @@
+package scene
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// chain returns n nodes, each the child of the previous one, with a different
+// transform each.
+func chain(n int) []*Node {
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = NewNode()
+		f := float32(i + 1)
+		r := glm.QuatRotate(0.3*f, &glm.Vec3{1, f, -1})
+		r.Normalize()
+		nodes[i].SetTRS(&glm.TRS{
+			Translation: glm.Vec3{f, -f, 0.5 * f},
+			Rotation:    r,
+			Scale:       glm.Vec3{1, 1 + 0.1*f, 1},
+		})
+		if i > 0 {
+			nodes[i].SetParent(nodes[i-1], false)
+		}
+	}
+	return nodes
+}
+
+// expectedWorld computes the world matrix of n from scratch.
+func expectedWorld(n *Node) glm.Mat4 {
+	m := glm.Ident4()
+	for ; n != nil; n = n.parent {
+		local := n.trs.Mat4()
+		m = local.Mul4(&m)
+	}
+	return m
+}
+
+func mat4Near(a, b *glm.Mat4, tol float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNode_World(t *testing.T) {
+	t.Parallel()
+	nodes := chain(5)
+	check := func(step string) {
+		for i, n := range nodes {
+			want := expectedWorld(n)
+			if got := n.WorldMat4(); !mat4Near(&got, &want, 1e-4) {
+				t.Errorf("%s: [%d] World() = %v, want %v", step, i, got, want)
+			}
+			inv := n.InverseWorld()
+			w := n.World()
+			id := inv.Mul3x4(&w)
+			if got, ident := id.Mat4(), glm.Ident4(); !mat4Near(&got, &ident, 1e-4) {
+				t.Errorf("%s: [%d] InverseWorld() * World() = %v", step, i, id)
+			}
+		}
+	}
+
+	check("initial")
+	nodes[1].SetTranslation(&glm.Vec3{10, 0, 0})
+	check("translate middle")
+	r := glm.QuatRotate(1, &glm.Vec3{0, 0, 1})
+	nodes[0].SetRotation(&r)
+	check("rotate root")
+	nodes[3].SetScale(&glm.Vec3{2, 2, 2})
+	check("scale leaf")
+
+	// Only computing the leaf recomputes the ancestors.
+	nodes[0].SetTranslation(&glm.Vec3{0, 5, 0})
+	want := expectedWorld(nodes[4])
+	if got := nodes[4].WorldMat4(); !mat4Near(&got, &want, 1e-4) {
+		t.Errorf("leaf first: World() = %v, want %v", got, want)
+	}
+	check("leaf first")
+
+	p := glm.Vec3{1, 2, 3}
+	w := nodes[4].LocalToWorld(&p)
+	back := nodes[4].WorldToLocal(&w)
+	if d := back.Sub(&p); d.Len() > 1e-4 {
+		t.Errorf("WorldToLocal(LocalToWorld(%v)) = %v", p, back)
+	}
+}
+
+func TestNode_SetParent(t *testing.T) {
+	t.Parallel()
+	nodes := chain(4)
+	other := chain(2)
+
+	before := nodes[2].WorldMat4()
+	nodes[2].SetParent(other[1], true)
+	if got := nodes[2].WorldMat4(); !mat4Near(&got, &before, 1e-4) {
+		t.Errorf("keepWorld: World() = %v, want %v", got, before)
+	}
+	want := expectedWorld(nodes[3])
+	if got := nodes[3].WorldMat4(); !mat4Near(&got, &want, 1e-4) {
+		t.Errorf("keepWorld child: World() = %v, want %v", got, want)
+	}
+	if len(nodes[1].Children()) != 0 || len(other[1].Children()) != 1 || nodes[2].Parent() != other[1] {
+		t.Errorf("children not updated")
+	}
+
+	local := nodes[2].Translation()
+	nodes[2].SetParent(nil, false)
+	if got := nodes[2].Translation(); got != local {
+		t.Errorf("Translation() = %v, want %v", got, local)
+	}
+	want = expectedWorld(nodes[3])
+	if got := nodes[3].WorldMat4(); !mat4Near(&got, &want, 1e-4) {
+		t.Errorf("root: World() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetParent to a descendant didn't panic")
+		}
+	}()
+	nodes[2].SetParent(nodes[3], false)
+}
+
+func TestNode_Update(t *testing.T) {
+	t.Parallel()
+	root := NewNode()
+	var all []*Node
+	for i := 0; i < 3; i++ {
+		branch := chain(3)
+		branch[0].SetParent(root, false)
+		all = append(all, branch...)
+	}
+	root.SetTranslation(&glm.Vec3{1, 2, 3})
+	root.Update()
+	for i, n := range all {
+		if n.dirty&dirtyWorld != 0 {
+			t.Errorf("[%d] still dirty after Update", i)
+		}
+		want := expectedWorld(n)
+		if got := n.world.Mat4(); !mat4Near(&got, &want, 1e-4) {
+			t.Errorf("[%d] world = %v, want %v", i, got, want)
+		}
+	}
+
+	tr := root.WorldTransform()
+	if m := root.WorldMat4(); tr.Mat4() != m {
+		t.Errorf("WorldTransform() = %v, want %v", tr, m)
+	}
+}
@@ -0,0 +1,137 @@
+// Package shadow computes the matrices used to render shadow maps, cascaded
+// shadow maps for directional lights and cube maps for point lights.
+package shadow
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+)
+
+// CascadeSplits returns the count+1 view distances delimiting count cascades
+// between near and far. lambda blends between a uniform distribution (0) and
+// a logarithmic one (1), which matches the perspective aliasing better but
+// makes the first cascades very small. 0.5 to 0.9 is common.
+//
+// See Zhang et al. "Parallel-Split Shadow Maps for Large-scale Virtual
+// Environments".
+func CascadeSplits(near, far float32, count int, lambda float32) []float32 {
+	splits := make([]float32, count+1)
+	splits[0] = near
+	ratio := far / near
+	for i := 1; i < count; i++ {
+		p := float32(i) / float32(count)
+		log := near * math.Pow(ratio, p)
+		uniform := near + (far-near)*p
+		splits[i] = lambda*log + (1-lambda)*uniform
+	}
+	splits[count] = far
+	return splits
+}
+
+// FrustumCorners returns the world space corners of the view frustum of the
+// view and projection matrices in the cs convention. The 4 first are on the
+// near plane and the 4 last on the far plane, in the same order. The far plane
+// must be finite.
+func FrustumCorners(view, projection *glm.Mat4, cs *glm.ClipSpace) [8]glm.Vec3 {
+	nearDepth, farDepth := float32(0), float32(1)
+	if cs.ReverseZ {
+		nearDepth, farDepth = 1, 0
+	}
+	var corners [8]glm.Vec3
+	for i, xy := range [4][2]float32{{0, 0}, {1, 0}, {1, 1}, {0, 1}} {
+		corners[i] = cs.UnProject(&glm.Vec3{xy[0], xy[1], nearDepth}, view, projection, 0, 0, 1, 1)
+		corners[i+4] = cs.UnProject(&glm.Vec3{xy[0], xy[1], farDepth}, view, projection, 0, 0, 1, 1)
+	}
+	return corners
+}
+
+// SliceCorners returns the corners of the part of the frustum between the
+// view distances sliceNear and sliceFar. corners are the frustum corners, as
+// returned by FrustumCorners, and near and far the distances of its planes.
+func SliceCorners(corners *[8]glm.Vec3, near, far, sliceNear, sliceFar float32) [8]glm.Vec3 {
+	// The view distance is linear along the edges of the frustum.
+	tn, tf := (sliceNear-near)/(far-near), (sliceFar-near)/(far-near)
+	var slice [8]glm.Vec3
+	for i := 0; i < 4; i++ {
+		edge := corners[i+4].Sub(&corners[i])
+		slice[i] = corners[i]
+		slice[i].AddScaledVec(tn, &edge)
+		slice[i+4] = corners[i]
+		slice[i+4].AddScaledVec(tf, &edge)
+	}
+	return slice
+}
+
+// SliceBoundingSphere returns a sphere bounding the frustum slice corners, as
+// returned by SliceCorners. The sphere only depends on the shape of the slice,
+// not on its orientation, so its radius doesn't change when the camera turns.
+// This is what makes the shadows stable.
+func SliceBoundingSphere(corners *[8]glm.Vec3) geo.Sphere {
+	var nc, fc glm.Vec3
+	for i := 0; i < 4; i++ {
+		nc.AddWith(&corners[i])
+		fc.AddWith(&corners[i+4])
+	}
+	nc.MulWith(0.25)
+	fc.MulWith(0.25)
+
+	var rn2, rf2 float32
+	for i := 0; i < 4; i++ {
+		dn, df := corners[i].Sub(&nc), corners[i+4].Sub(&fc)
+		rn2 = math.Max(rn2, dn.Len2())
+		rf2 = math.Max(rf2, df.Len2())
+	}
+
+	// Find the point along the axis equidistant to the near and far corners,
+	// x² + rn² = (l - x)² + rf².
+	axis := fc.Sub(&nc)
+	l := axis.Len()
+	var x float32
+	if l > 0 {
+		x = math.Clamp((l*l+rf2-rn2)/(2*l), 0, l)
+		axis.MulWith(1 / l)
+	}
+	center := nc
+	center.AddScaledVec(x, &axis)
+	r2 := math.Max(x*x+rn2, (l-x)*(l-x)+rf2)
+
+	// Round the radius up to avoid flickering from rounding errors.
+	radius := math.Ceil(math.Sqrt(r2)*16) / 16
+	return geo.Sphere{Center: center, Radius: radius, Radius2: radius * radius}
+}
+
+// StableLightMatrices returns the view and orthographic projection matrices in
+// the cs convention rendering the shadow map of the directional light going in
+// direction dir, covering the sphere s. resolution is the size in texels of
+// the square shadow map and casters how far behind the sphere, towards the
+// light, shadow casters are included.
+//
+// The view matrix only depends on dir and the projection is snapped to texel
+// increments, so the shadows don't shimmer when the camera moves.
+func StableLightMatrices(s *geo.Sphere, dir *glm.Vec3, resolution int, casters float32, cs *glm.ClipSpace) (view, projection glm.Mat4) {
+	d := dir.Normalized()
+	up := glm.Vec3{0, 1, 0}
+	if math.Abs(d[1]) > 0.99 {
+		up = glm.Vec3{0, 0, 1}
+	}
+	view = glm.LookAtV(&glm.Vec3{}, &d, &up)
+
+	// Center of the sphere in light space, snapped to the texel grid. The
+	// map is half a texel larger than the sphere so it still covers it.
+	c4 := s.Center.Vec4(1)
+	c4 = view.Mul4x1(&c4)
+	texel := 2 * s.Radius / float32(resolution-1)
+	half := texel * float32(resolution) * 0.5
+	cx := math.Floor(c4[0]/texel+0.5) * texel
+	cy := math.Floor(c4[1]/texel+0.5) * texel
+
+	// The light looks down -Z in its space.
+	near, far := -c4[2]-s.Radius-casters, -c4[2]+s.Radius
+	projection = cs.Ortho(cx-half, cx+half, cy-half, cy+half, near, far)
+
+	if cs.LeftHanded {
+		view[2], view[6], view[10], view[14] = -view[2], -view[6], -view[10], -view[14]
+	}
+	return
+}
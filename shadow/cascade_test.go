@@ -0,0 +1,142 @@
+package shadow
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"github.com/engoengine/glm/geo"
+	"testing"
+)
+
+func TestCascadeSplits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		near, far float32
+		count     int
+		lambda    float32
+		want      []float32
+	}{
+		{1, 100, 1, 0.5, []float32{1, 100}},
+		{1, 101, 4, 0, []float32{1, 26, 51, 76, 101}},
+		{1, 1000, 3, 1, []float32{1, 10, 100, 1000}},
+		{1, 1000, 3, 0.5, []float32{1, (10 + 334) / 2.0, (100 + 667) / 2.0, 1000}},
+	}
+	for i, test := range tests {
+		got := CascadeSplits(test.near, test.far, test.count, test.lambda)
+		if len(got) != len(test.want) {
+			t.Errorf("[%d] CascadeSplits = %v, want %v", i, got, test.want)
+			continue
+		}
+		for j := range got {
+			if math.Abs(got[j]-test.want[j]) > 1e-3*test.want[j] {
+				t.Errorf("[%d] CascadeSplits = %v, want %v", i, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFrustumCorners(t *testing.T) {
+	t.Parallel()
+	view := glm.Ident4()
+	spaces := []glm.ClipSpace{glm.ClipOpenGL, glm.ClipVulkan, {ZeroToOne: true, ReverseZ: true}}
+	for i, cs := range spaces {
+		proj := cs.Perspective(math.Pi/2, 2, 1, 10)
+		corners := FrustumCorners(&view, &proj, &cs)
+		for j, c := range corners {
+			d := float32(1)
+			if j >= 4 {
+				d = 10
+			}
+			if math.Abs(c[2]+d) > 1e-3 || math.Abs(math.Abs(c[0])-2*d) > 1e-3 || math.Abs(math.Abs(c[1])-d) > 1e-3 {
+				t.Errorf("[%d] corner %d = %v, distance %f", i, j, c, d)
+			}
+		}
+
+		slice := SliceCorners(&corners, 1, 10, 2, 4)
+		for j, c := range slice {
+			d := float32(2)
+			if j >= 4 {
+				d = 4
+			}
+			if math.Abs(c[2]+d) > 1e-3 || math.Abs(math.Abs(c[0])-2*d) > 1e-3 || math.Abs(math.Abs(c[1])-d) > 1e-3 {
+				t.Errorf("[%d] slice corner %d = %v, distance %f", i, j, c, d)
+			}
+		}
+	}
+}
+
+func TestSliceBoundingSphere(t *testing.T) {
+	t.Parallel()
+	proj := glm.Perspective(math.Pi/3, 16.0/9, 0.5, 200)
+	var radius float32
+	for i, yaw := range []float32{0, 0.3, 1, 2.5} {
+		eye := glm.Vec3{float32(i) * 3.7, 1, -2}
+		dir := glm.Vec3{math.Sin(yaw), -0.2, -math.Cos(yaw)}
+		center := eye.Add(&dir)
+		view := glm.LookAtV(&eye, &center, &glm.Vec3{0, 1, 0})
+		corners := FrustumCorners(&view, &proj, &glm.ClipOpenGL)
+		for _, split := range [][2]float32{{0.5, 5}, {5, 30}, {30, 200}} {
+			slice := SliceCorners(&corners, 0.5, 200, split[0], split[1])
+			s := SliceBoundingSphere(&slice)
+			for j := range slice {
+				if d := slice[j].Sub(&s.Center); d.Len() > s.Radius*(1+1e-5) {
+					t.Errorf("[%d] %v: corner %v outside of sphere %v", i, split, slice[j], s)
+				}
+			}
+			if s.Radius2 != s.Radius*s.Radius {
+				t.Errorf("[%d] Radius2 = %f, Radius = %f", i, s.Radius2, s.Radius)
+			}
+			if split[0] == 5 {
+				// The radius doesn't depend on the camera orientation.
+				if i != 0 && s.Radius != radius {
+					t.Errorf("[%d] radius = %f, want %f", i, s.Radius, radius)
+				}
+				radius = s.Radius
+			}
+		}
+	}
+}
+
+func TestStableLightMatrices(t *testing.T) {
+	t.Parallel()
+	const resolution = 1024
+	dir := glm.Vec3{1, -2, 0.5}
+	spaces := []glm.ClipSpace{glm.ClipOpenGL, glm.ClipVulkan, glm.ClipDirect3D}
+	for i, cs := range spaces {
+		var firstView glm.Mat4
+		for j, offset := range []float32{0, 0.001, 0.37, 12.5} {
+			s := geo.Sphere{Center: glm.Vec3{3 + offset, -1, 2 * offset}, Radius: 10, Radius2: 100}
+			view, proj := StableLightMatrices(&s, &dir, resolution, 5, &cs)
+			if j == 0 {
+				firstView = view
+			} else if view != firstView {
+				t.Errorf("[%d,%d] view changed with the sphere position", i, j)
+			}
+
+			// The whole sphere is in the shadow map.
+			vp := proj.Mul4(&view)
+			for _, a := range []glm.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {-1, 0, 0}, {0, -1, 0}, {0, 0, -1}} {
+				p := s.Center
+				p.AddScaledVec(s.Radius, &a)
+				p4 := p.Vec4(1)
+				c := vp.Mul4x1(&p4)
+				minZ := float32(-1)
+				if cs.ZeroToOne {
+					minZ = 0
+				}
+				if math.Abs(c[0]) > 1 || math.Abs(c[1]) > 1 || c[2] < minZ-1e-5 || c[2] > 1+1e-5 {
+					t.Errorf("[%d,%d] %v is outside of the shadow map at %v", i, j, p, c)
+				}
+			}
+
+			// The projection is offset by whole texels.
+			texel := 2 / float32(resolution)
+			for _, o := range []float32{proj[12], proj[13]} {
+				n := o / texel
+				if math.Abs(n-math.Floor(n+0.5)) > 1e-2 {
+					t.Errorf("[%d,%d] offset %f is %f texels", i, j, o, n)
+				}
+			}
+		}
+	}
+}
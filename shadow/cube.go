@@ -0,0 +1,35 @@
+package shadow
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+)
+
+// cubeFaces are the directions and up vectors of the faces of a cube map, in
+// the order and orientation of GL_TEXTURE_CUBE_MAP_POSITIVE_X and following.
+var cubeFaces = [6][2]glm.Vec3{
+	{{1, 0, 0}, {0, -1, 0}},
+	{{-1, 0, 0}, {0, -1, 0}},
+	{{0, 1, 0}, {0, 0, 1}},
+	{{0, -1, 0}, {0, 0, -1}},
+	{{0, 0, 1}, {0, -1, 0}},
+	{{0, 0, -1}, {0, -1, 0}},
+}
+
+// CubeFaceViews returns the view matrices of the 6 faces of the shadow cube
+// map of a point light at position, in the order +X, -X, +Y, -Y, +Z, -Z. The
+// faces are oriented like OpenGL cube maps expect.
+func CubeFaceViews(position *glm.Vec3) [6]glm.Mat4 {
+	var views [6]glm.Mat4
+	for i := range cubeFaces {
+		center := position.Add(&cubeFaces[i][0])
+		views[i] = glm.LookAtV(position, &center, &cubeFaces[i][1])
+	}
+	return views
+}
+
+// CubeFaceProjection returns the projection matrix, in the cs convention, of
+// the faces of a shadow cube map. near and far are the range of the light.
+func CubeFaceProjection(near, far float32, cs *glm.ClipSpace) glm.Mat4 {
+	return cs.Perspective(math.Pi/2, 1, near, far)
+}
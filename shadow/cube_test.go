@@ -0,0 +1,38 @@
+package shadow
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestCubeFaceViews(t *testing.T) {
+	t.Parallel()
+	pos := glm.Vec3{1, 2, 3}
+	views := CubeFaceViews(&pos)
+	proj := CubeFaceProjection(0.1, 50, &glm.ClipOpenGL)
+	for i, face := range cubeFaces {
+		// The face direction is straight ahead.
+		p := pos.Add(&face[0])
+		p4 := p.Vec4(1)
+		v := views[i].Mul4x1(&p4)
+		if math.Abs(v[0]) > 1e-5 || math.Abs(v[1]) > 1e-5 || math.Abs(v[2]+1) > 1e-5 {
+			t.Errorf("[%d] face direction in view space = %v", i, v)
+		}
+		// The up vector is up.
+		p = pos.Add(&face[1])
+		p4 = p.Vec4(1)
+		if v = views[i].Mul4x1(&p4); math.Abs(v[1]-1) > 1e-5 {
+			t.Errorf("[%d] face up in view space = %v", i, v)
+		}
+		// The 90 degrees field of view covers the whole face.
+		corner := face[0].Add(&face[1])
+		p = pos.Add(&corner)
+		p4 = p.Vec4(1)
+		vp := proj.Mul4(&views[i])
+		c := vp.Mul4x1(&p4)
+		if math.Abs(c[1]/c[3]-1) > 1e-5 {
+			t.Errorf("[%d] edge of the face at y = %f, want 1", i, c[1]/c[3])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package skeleton implements the pose math of skeletal animation: joint
+// hierarchies stored as parent indices, model space poses, skinning palettes
+// and linear blend skinning on the CPU.
+package skeleton
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// Skeleton is a hierarchy of joints. Joints are referred to by their index
+// and every joint comes after its parent, so a single pass in order visits
+// parents before their children.
+type Skeleton struct {
+	// Parents are the index of the parent of each joint, -1 for roots.
+	Parents []int
+	// InverseBind are the inverse bind matrices of the joints, the
+	// transforms from model space to the space of each joint in the bind
+	// pose.
+	InverseBind []glm.Mat3x4
+}
+
+// New returns a skeleton with the given parents and identity inverse bind
+// matrices. It panics if a joint comes before its parent.
+func New(parents []int) *Skeleton {
+	for i, p := range parents {
+		if p >= i {
+			panic("skeleton: joint before its parent")
+		}
+	}
+	s := &Skeleton{Parents: parents, InverseBind: make([]glm.Mat3x4, len(parents))}
+	for i := range s.InverseBind {
+		s.InverseBind[i] = glm.Ident3x4()
+	}
+	return s
+}
+
+// Len returns the number of joints of s.
+func (s *Skeleton) Len() int {
+	return len(s.Parents)
+}
+
+// LocalMatrices writes in dst the matrices of the local poses of the joints,
+// relative to their parent.
+func (s *Skeleton) LocalMatrices(local []glm.TRS, dst []glm.Mat3x4) {
+	for i := range s.Parents {
+		dst[i] = local[i].Mat3x4()
+	}
+}
+
+// ModelPose writes in model the transforms of the joints relative to the
+// model, from the local ones relative to their parent. model may be local.
+func (s *Skeleton) ModelPose(local, model []glm.Mat3x4) {
+	for i, p := range s.Parents {
+		if p < 0 {
+			model[i] = local[i]
+		} else {
+			// local[i] may be model[i], Mul3x4Of can't alias.
+			l := local[i]
+			model[i].Mul3x4Of(&model[p], &l)
+		}
+	}
+}
+
+// ModelPoseTRS is like ModelPose for local poses stored as TRS.
+func (s *Skeleton) ModelPoseTRS(local []glm.TRS, model []glm.Mat3x4) {
+	for i, p := range s.Parents {
+		m := local[i].Mat3x4()
+		if p < 0 {
+			model[i] = m
+		} else {
+			model[i].Mul3x4Of(&model[p], &m)
+		}
+	}
+}
+
+// SetBindPose sets the inverse bind matrices of s so that the local poses
+// bind are the bind pose.
+func (s *Skeleton) SetBindPose(bind []glm.TRS) {
+	s.ModelPoseTRS(bind, s.InverseBind)
+	for i := range s.InverseBind {
+		s.InverseBind[i] = s.InverseBind[i].Inverse()
+	}
+}
+
+// Palette writes in palette the skinning matrices of the model space poses,
+// the transforms from the bind pose to the current pose in model space.
+// palette may be model.
+func (s *Skeleton) Palette(model, palette []glm.Mat3x4) {
+	for i := range s.Parents {
+		m := model[i]
+		palette[i].Mul3x4Of(&m, &s.InverseBind[i])
+	}
+}
@@ -0,0 +1,113 @@
+package skeleton
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+// pose returns local poses of n joints, all different.
+func pose(n int, angle float32) []glm.TRS {
+	local := make([]glm.TRS, n)
+	for i := range local {
+		f := float32(i + 1)
+		r := glm.QuatRotate(angle*f, &glm.Vec3{1, f, -1})
+		r.Normalize()
+		local[i] = glm.TRS{
+			Translation: glm.Vec3{f, -f, 0.5 * f},
+			Rotation:    r,
+			Scale:       glm.Vec3{1, 1 + 0.1*f, 1},
+		}
+	}
+	return local
+}
+
+func mat3x4Near(a, b *glm.Mat3x4, tol float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSkeleton_ModelPose(t *testing.T) {
+	t.Parallel()
+	// Two branches from the root.
+	s := New([]int{-1, 0, 1, 0, 3, 4})
+	local := pose(s.Len(), 0.3)
+
+	// The model pose of a joint is the product of the local poses of its
+	// ancestors.
+	want := make([]glm.Mat3x4, s.Len())
+	for i := range want {
+		m := glm.Ident3x4()
+		for j := i; j >= 0; j = s.Parents[j] {
+			l := local[j].Mat3x4()
+			m = l.Mul3x4(&m)
+		}
+		want[i] = m
+	}
+
+	model := make([]glm.Mat3x4, s.Len())
+	s.ModelPoseTRS(local, model)
+	for i := range model {
+		if !mat3x4Near(&model[i], &want[i], 1e-4) {
+			t.Errorf("ModelPoseTRS: [%d] = %v, want %v", i, model[i], want[i])
+		}
+	}
+
+	m := make([]glm.Mat3x4, s.Len())
+	s.LocalMatrices(local, m)
+	s.ModelPose(m, m)
+	for i := range m {
+		if !mat3x4Near(&m[i], &want[i], 1e-4) {
+			t.Errorf("ModelPose: [%d] = %v, want %v", i, m[i], want[i])
+		}
+	}
+}
+
+func TestSkeleton_Palette(t *testing.T) {
+	t.Parallel()
+	s := New([]int{-1, 0, 1, 1})
+	bind := pose(s.Len(), 0.3)
+	s.SetBindPose(bind)
+
+	// In the bind pose the palette is the identity.
+	palette := make([]glm.Mat3x4, s.Len())
+	s.ModelPoseTRS(bind, palette)
+	s.Palette(palette, palette)
+	ident := glm.Ident3x4()
+	for i := range palette {
+		if !mat3x4Near(&palette[i], &ident, 1e-4) {
+			t.Errorf("bind pose: [%d] = %v, want identity", i, palette[i])
+		}
+	}
+
+	// Otherwise it takes a point from the bind pose of a joint to its
+	// current pose.
+	current := pose(s.Len(), 0.7)
+	model := make([]glm.Mat3x4, s.Len())
+	s.ModelPoseTRS(current, model)
+	s.Palette(model, palette)
+	bindModel := make([]glm.Mat3x4, s.Len())
+	s.ModelPoseTRS(bind, bindModel)
+	p := glm.Vec3{0.5, -1, 2}
+	for i := range palette {
+		want := model[i].Mul3x1(&p)
+		b := bindModel[i].Mul3x1(&p)
+		if got := palette[i].Mul3x1(&b); !got.EqualThreshold(&want, 1e-3) {
+			t.Errorf("[%d] palette * bind = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New with a joint before its parent didn't panic")
+		}
+	}()
+	New([]int{-1, 2, 0})
+}
@@ -0,0 +1,43 @@
+package skeleton
+
+import (
+	"github.com/engoengine/glm"
+)
+
+// blend returns the sum of the palette matrices of joints weighted by
+// weights.
+func blend(palette []glm.Mat3x4, joints *[4]uint16, weights *glm.Vec4) glm.Mat3x4 {
+	var m glm.Mat3x4
+	for k, w := range weights {
+		if w == 0 {
+			continue
+		}
+		p := &palette[joints[k]]
+		for j := range m {
+			m[j] += w * p[j]
+		}
+	}
+	return m
+}
+
+// Skin writes in dst the positions deformed by linear blend skinning. Each
+// vertex is influenced by 4 joints of the palette, as returned by
+// Skeleton.Palette, with weights summing to 1. dst may be positions.
+func Skin(palette []glm.Mat3x4, joints [][4]uint16, weights []glm.Vec4, positions, dst []glm.Vec3) {
+	for i := range positions {
+		m := blend(palette, &joints[i], &weights[i])
+		dst[i] = m.Mul3x1(&positions[i])
+	}
+}
+
+// SkinNormals writes in dst the normals or tangents deformed by linear blend
+// skinning, see Skin. The results are normalized. They are exact when the
+// joints only have uniform scales. dst may be normals.
+func SkinNormals(palette []glm.Mat3x4, joints [][4]uint16, weights []glm.Vec4, normals, dst []glm.Vec3) {
+	for i := range normals {
+		m := blend(palette, &joints[i], &weights[i])
+		n := m.TransformDirection(&normals[i])
+		n.Normalize()
+		dst[i] = n
+	}
+}
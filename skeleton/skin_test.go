@@ -0,0 +1,45 @@
+package skeleton
+
+import (
+	"github.com/EngoEngine/math"
+	"github.com/engoengine/glm"
+	"testing"
+)
+
+func TestSkin(t *testing.T) {
+	t.Parallel()
+	// An arm along X, the elbow at x = 1 bends 90° around Z.
+	s := New([]int{-1, 0})
+	bind := []glm.TRS{glm.TRSIdent(), glm.TRSIdent()}
+	bind[1].Translation = glm.Vec3{1, 0, 0}
+	s.SetBindPose(bind)
+
+	current := []glm.TRS{bind[0], bind[1]}
+	current[1].Rotation = glm.QuatRotate(math.Pi/2, &glm.Vec3{0, 0, 1})
+	palette := make([]glm.Mat3x4, s.Len())
+	s.ModelPoseTRS(current, palette)
+	s.Palette(palette, palette)
+
+	positions := []glm.Vec3{{0.5, 0, 0}, {2, 0, 0}, {1, 1, 0}}
+	normals := []glm.Vec3{{0, 1, 0}, {0, 1, 0}, {0, 1, 0}}
+	joints := [][4]uint16{{0, 1, 0, 0}, {1, 0, 0, 0}, {0, 1, 0, 0}}
+	weights := []glm.Vec4{{1, 0, 0, 0}, {1, 0, 0, 0}, {0.5, 0.5, 0, 0}}
+
+	dst := make([]glm.Vec3, len(positions))
+	Skin(palette, joints, weights, positions, dst)
+	want := []glm.Vec3{{0.5, 0, 0}, {1, 1, 0}, {0.5, 0.5, 0}}
+	for i := range dst {
+		if d := dst[i].Sub(&want[i]); d.Len() > 1e-5 {
+			t.Errorf("Skin: [%d] = %v, want %v", i, dst[i], want[i])
+		}
+	}
+
+	SkinNormals(palette, joints, weights, normals, normals)
+	h := 1 / math.Sqrt(2)
+	want = []glm.Vec3{{0, 1, 0}, {-1, 0, 0}, {-h, h, 0}}
+	for i := range normals {
+		if d := normals[i].Sub(&want[i]); d.Len() > 1e-5 {
+			t.Errorf("SkinNormals: [%d] = %v, want %v", i, normals[i], want[i])
+		}
+	}
+}
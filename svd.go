@@ -0,0 +1,158 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// float32Epsilon is the difference between 1 and the next float32.
+const float32Epsilon = 1.1920929e-7
+
+// SVD computes the singular value decomposition of m1 such that
+// m1 = u * Diag3(s) * v^T.
+//
+// Like McAdams et al. "Computing the Singular Value Decomposition of 3x3
+// matrices with minimal branching and elementary floating point operations",
+// u and v are always rotations (determinant +1) and the singular values are
+// sorted by decreasing magnitude. To make this possible the last singular
+// value is negative when m1 contains a reflection.
+//
+// This is the building block of shape matching, Kabsch alignment and
+// deformation gradients.
+func (m1 *Mat3) SVD() (u Mat3, s Vec3, v Mat3) {
+	// 1. The right singular vectors are the eigenvectors of m^T * m.
+	mt := m1.Transposed()
+	mtm := mt.Mul3(m1)
+	_, v = mtm.SymEigen()
+	if v.Det() < 0 {
+		v[6], v[7], v[8] = -v[6], -v[7], -v[8]
+	}
+
+	// 2. m * v = u * Σ, the columns of b are orthogonal and sorted by
+	// decreasing length. Recover u and Σ with a QR decomposition using
+	// Givens rotations, this keeps u a rotation.
+	b := m1.Mul3(&v)
+	u.Ident()
+	for _, ij := range [3][2]int{{0, 1}, {0, 2}, {1, 2}} {
+		j, i := ij[0], ij[1]
+		x, y := b[j*3+j], b[j*3+i]
+		r := math.Hypot(x, y)
+		if r < MinNormal {
+			continue
+		}
+		c, sn := x/r, y/r
+		// b = G * b, mixing rows j and i.
+		for k := 0; k < 3; k++ {
+			bj, bi := b[k*3+j], b[k*3+i]
+			b[k*3+j] = c*bj + sn*bi
+			b[k*3+i] = -sn*bj + c*bi
+		}
+		// u = u * G^T, mixing columns j and i.
+		for k := 0; k < 3; k++ {
+			uj, ui := u[j*3+k], u[i*3+k]
+			u[j*3+k] = c*uj + sn*ui
+			u[i*3+k] = -sn*uj + c*ui
+		}
+	}
+	s = b.Diag()
+	return
+}
+
+// PseudoInverse returns the Moore-Penrose pseudo-inverse of m1. It's the same
+// as the inverse when m1 is invertible and the least squares inverse when it's
+// singular.
+func (m1 *Mat3) PseudoInverse() Mat3 {
+	u, s, v := m1.SVD()
+	tol := svdTolerance(&s)
+	for i := range s {
+		if math.Abs(s[i]) > tol {
+			s[i] = 1 / s[i]
+		} else {
+			s[i] = 0
+		}
+	}
+	d := Diag3(&s)
+	v.Mul3With(&d)
+	u.Transpose()
+	return v.Mul3(&u)
+}
+
+// Rank returns the number of linearly independent columns of m1, using the
+// singular values and a tolerance relative to the largest one.
+func (m1 *Mat3) Rank() int {
+	_, s, _ := m1.SVD()
+	tol := svdTolerance(&s)
+	var rank int
+	for i := range s {
+		if math.Abs(s[i]) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+// ConditionNumber returns the ratio between the largest and the smallest
+// singular value of m1. The larger it is the more m1 amplifies errors when
+// inverting it. Singular matrices return InfPos.
+func (m1 *Mat3) ConditionNumber() float32 {
+	_, s, _ := m1.SVD()
+	min := math.Abs(s[2])
+	if min == 0 {
+		return InfPos
+	}
+	return math.Abs(s[0]) / min
+}
+
+// svdTolerance returns the value under which singular values are considered
+// zero.
+func svdTolerance(s *Vec3) float32 {
+	return 3 * float32Epsilon * math.Abs(s[0])
+}
+
+// Orthonormalized returns m1 with its columns made orthonormal using the
+// Gram-Schmidt process. The first column keeps its direction.
+func (m1 *Mat2) Orthonormalized() Mat2 {
+	var m Mat2
+	m.OrthonormalizeOf(m1)
+	return m
+}
+
+// OrthonormalizeOf is a memory friendly version of Orthonormalized.
+func (m1 *Mat2) OrthonormalizeOf(m2 *Mat2) {
+	c0, c1 := m2.Cols()
+	c0.Normalize()
+	c1.AddScaledVec(-c1.Dot(&c0), &c0)
+	c1.Normalize()
+	*m1 = Mat2FromCols(&c0, &c1)
+}
+
+// Orthonormalize is a memory friendly version of Orthonormalized.
+func (m1 *Mat2) Orthonormalize() {
+	m1.OrthonormalizeOf(m1)
+}
+
+// Orthonormalized returns m1 with its columns made orthonormal using the
+// Gram-Schmidt process. The first column keeps its direction and the second
+// one stays in the plane of the first two. This is the usual fix for rotation
+// matrices that drifted after many multiplications.
+func (m1 *Mat3) Orthonormalized() Mat3 {
+	var m Mat3
+	m.OrthonormalizeOf(m1)
+	return m
+}
+
+// OrthonormalizeOf is a memory friendly version of Orthonormalized.
+func (m1 *Mat3) OrthonormalizeOf(m2 *Mat3) {
+	c0, c1, c2 := m2.Cols()
+	c0.Normalize()
+	c1.AddScaledVec(-c1.Dot(&c0), &c0)
+	c1.Normalize()
+	c2.AddScaledVec(-c2.Dot(&c0), &c0)
+	c2.AddScaledVec(-c2.Dot(&c1), &c1)
+	c2.Normalize()
+	*m1 = Mat3FromCols(&c0, &c1, &c2)
+}
+
+// Orthonormalize is a memory friendly version of Orthonormalized.
+func (m1 *Mat3) Orthonormalize() {
+	m1.OrthonormalizeOf(m1)
+}
@@ -0,0 +1,135 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+var svdTests = []Mat3{
+	Ident3(),
+	{1, 2, 3, 4, 5, 6, 7, 8, 10},
+	{2, 0, 0, 0, -3, 0, 0, 0, 1},
+	{1, 2, 3, 2, 4, 6, 3, 6, 9},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{0.5, -1, 2, 3, 0.1, -0.2, 1, 1, 1},
+}
+
+func TestMat3_SVD(t *testing.T) {
+	t.Parallel()
+	for i, m := range svdTests {
+		u, s, v := m.SVD()
+		if d := u.Det(); !FloatEqualThreshold(d, 1, 1e-4) {
+			t.Errorf("[%d] det(u) = %v, want 1", i, d)
+		}
+		if d := v.Det(); !FloatEqualThreshold(d, 1, 1e-4) {
+			t.Errorf("[%d] det(v) = %v, want 1", i, d)
+		}
+		if s[0] < 0 || s[1] < 0 || s[0] < s[1] || s[1] < math.Abs(s[2])-1e-4 {
+			t.Errorf("[%d] singular values are not sorted %v", i, s)
+		}
+		d := Diag3(&s)
+		vt := v.Transposed()
+		r := u.Mul3(&d)
+		r = r.Mul3(&vt)
+		if !mat3Near(&r, &m, 1e-4) {
+			t.Errorf("[%d] u*s*vt = %v, want %v", i, r, m)
+		}
+	}
+}
+
+func TestMat3_PseudoInverse(t *testing.T) {
+	t.Parallel()
+	for i, m := range svdTests {
+		p := m.PseudoInverse()
+		// Moore-Penrose conditions: m*p*m = m and p*m*p = p
+		mpm := m.Mul3(&p)
+		mpm = mpm.Mul3(&m)
+		if !mat3Near(&mpm, &m, 1e-4) {
+			t.Errorf("[%d] m*p*m = %v, want %v", i, mpm, m)
+		}
+		pmp := p.Mul3(&m)
+		pmp = pmp.Mul3(&p)
+		if !mat3Near(&pmp, &p, 1e-4) {
+			t.Errorf("[%d] p*m*p = %v, want %v", i, pmp, p)
+		}
+	}
+
+	m := svdTests[1]
+	inv, p := m.Inverse(), m.PseudoInverse()
+	if !mat3Near(&p, &inv, 1e-4) {
+		t.Errorf("PseudoInverse = %v, want %v", p, inv)
+	}
+}
+
+func TestMat3_RankConditionNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		rank int
+		cond float32
+	}{
+		{3, 1},
+		{3, 88.4482},
+		{3, 3},
+		{1, InfPos},
+		{0, InfPos},
+	}
+
+	for i, test := range tests {
+		if r := svdTests[i].Rank(); r != test.rank {
+			t.Errorf("[%d] rank = %d, want %d", i, r, test.rank)
+		}
+		c := svdTests[i].ConditionNumber()
+		if test.cond == InfPos {
+			if c < 1e5 {
+				t.Errorf("[%d] condition number = %v, want a very large value", i, c)
+			}
+		} else if !FloatEqualThreshold(c, test.cond, 1e-3) {
+			t.Errorf("[%d] condition number = %v, want %v", i, c, test.cond)
+		}
+	}
+}
+
+func TestMat3_Orthonormalize(t *testing.T) {
+	t.Parallel()
+	q := QuatRotate(0.9, &Vec3{0.6, 0, 0.8})
+	r := q.Mat3()
+	drifted := r.Add(&Mat3{0.01, -0.02, 0, 0.003, 0.01, 0.02, -0.01, 0, 0.015})
+
+	o := drifted.Orthonormalized()
+	ot := o.Transposed()
+	if i := ot.Mul3(&o); !mat3Near(&i, &Mat3{1, 0, 0, 0, 1, 0, 0, 0, 1}, 1e-5) {
+		t.Errorf("Orthonormalized is not orthonormal %v", o)
+	}
+	if !mat3Near(&o, &r, 5e-2) {
+		t.Errorf("Orthonormalized = %v, too far from %v", o, r)
+	}
+	c0, dc0 := o.Col(0), drifted.Col(0)
+	dc0.Normalize()
+	if !c0.EqualThreshold(&dc0, 1e-4) {
+		t.Errorf("first column changed direction %v != %v", c0, dc0)
+	}
+
+	drifted.Orthonormalize()
+	if drifted != o {
+		t.Errorf("Orthonormalize = %v, want %v", drifted, o)
+	}
+
+	m2 := Mat2{1, 0.1, 0.2, 1}
+	m2.Orthonormalize()
+	a, b := m2.Cols()
+	if !FloatEqualThreshold(a.Len(), 1, 1e-4) || !FloatEqualThreshold(b.Len(), 1, 1e-4) || math.Abs(a.Dot(&b)) > 1e-4 {
+		t.Errorf("Mat2.Orthonormalize = %v", m2)
+	}
+}
+
+// mat3Near returns true if every element of a and b are within tol of each
+// other. Unlike EqualThreshold this is an absolute comparison, which is what
+// we want for elements that should be 0.
+func mat3Near(a, b *Mat3, tol float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+)
+
+// SwingTwist decomposes the rotation q1 into a twist around axis followed by a
+// swing around an axis perpendicular to it, such that q1 = swing * twist. axis
+// must be normalized.
+//
+// When q1 is a rotation of π around an axis perpendicular to axis the twist is
+// undefined and the identity is returned in its place.
+func (q1 *Quat) SwingTwist(axis *Vec3) (swing, twist Quat) {
+	// The twist is the projection of the vector part on the twist axis.
+	d := q1.V.Dot(axis)
+	twist = Quat{q1.W, axis.Mul(d)}
+	if l := twist.Len(); l < 1e-6 {
+		twist = QuatIdent()
+	} else {
+		twist.ScaleWith(1 / l)
+	}
+
+	inv := twist.Conjugated()
+	swing = q1.Mul(&inv)
+	return
+}
+
+// QuatFromSwingTwist recomposes a rotation from its swing and twist parts.
+// It's the inverse of SwingTwist.
+func QuatFromSwingTwist(swing, twist *Quat) Quat {
+	return swing.Mul(twist)
+}
+
+// TwistAngle returns the signed angle, between -π and π, of the rotation of
+// twist around axis. twist is expected to be the twist returned by SwingTwist
+// for the same axis.
+func (q1 *Quat) TwistAngle(axis *Vec3) float32 {
+	w, s := q1.W, q1.V.Dot(axis)
+	// q and -q are the same rotation, pick the one giving the shortest angle.
+	if w < 0 {
+		w, s = -w, -s
+	}
+	return 2 * math.Atan2(s, w)
+}
+
+// ClampTwist returns twist with its angle around axis clamped between min and
+// max, in radians.
+func ClampTwist(twist *Quat, axis *Vec3, min, max float32) Quat {
+	angle := twist.TwistAngle(axis)
+	if angle >= min && angle <= max {
+		return *twist
+	}
+	return QuatRotate(Clamp(angle, min, max), axis)
+}
+
+// ClampSwingCone returns swing limited to a circular cone of half angle
+// maxAngle around axis. That is, swing.Rotate(axis) will be at most maxAngle
+// radians away from axis.
+func ClampSwingCone(swing *Quat, axis *Vec3, maxAngle float32) Quat {
+	d := swing.Rotate(axis)
+	if axis.Dot(&d) >= math.Cos(maxAngle) {
+		return *swing
+	}
+
+	dir := axis.Cross(&d)
+	if dir.Len2() < 1e-12 {
+		// d is opposite to axis, any perpendicular will do.
+		dir = swing.V
+	}
+	dir.Normalize()
+	return QuatRotate(maxAngle, &dir)
+}
+
+// ClampSwingEllipse returns swing limited to an elliptical cone around axis.
+// ref is a unit vector perpendicular to axis, maxRef is the maximum swing
+// angle around ref and maxOther is the maximum swing angle around
+// axis.Cross(ref). A limit of 0 locks the swing around that axis.
+//
+// Swings outside the ellipse are scaled back to its boundary along their
+// direction, which is not the closest point on the boundary but avoids
+// popping when the limits are very different.
+func ClampSwingEllipse(swing *Quat, axis, ref *Vec3, maxRef, maxOther float32) Quat {
+	angle, dir := swing.AxisAngle()
+	if angle > math.Pi {
+		angle = 2*math.Pi - angle
+		dir.Invert()
+	}
+	other := axis.Cross(ref)
+
+	// The swing as a rotation vector expressed in the (ref, other) plane.
+	x, y := angle*dir.Dot(ref), angle*dir.Dot(&other)
+	if maxRef <= 0 {
+		x = 0
+	}
+	if maxOther <= 0 {
+		y = 0
+	}
+
+	var e float32
+	if x != 0 {
+		e += (x / maxRef) * (x / maxRef)
+	}
+	if y != 0 {
+		e += (y / maxOther) * (y / maxOther)
+	}
+	if e > 1 {
+		k := 1 / math.Sqrt(e)
+		x *= k
+		y *= k
+	} else if maxRef > 0 && maxOther > 0 {
+		return *swing
+	}
+
+	r := ref.Mul(x)
+	r.AddScaledVec(y, &other)
+	l := r.Len()
+	if l < 1e-6 {
+		return QuatIdent()
+	}
+	r.MulWith(1 / l)
+	return QuatRotate(l, &r)
+}
+
+// ConstrainSwingTwist limits the rotation q1 to a twist around axis between
+// twistMin and twistMax and a swing inside a cone of half angle swingMax, then
+// recomposes it. This is the usual joint limit for shoulders and hips.
+func ConstrainSwingTwist(q1 *Quat, axis *Vec3, twistMin, twistMax, swingMax float32) Quat {
+	swing, twist := q1.SwingTwist(axis)
+	twist = ClampTwist(&twist, axis, twistMin, twistMax)
+	swing = ClampSwingCone(&swing, axis, swingMax)
+	return QuatFromSwingTwist(&swing, &twist)
+}
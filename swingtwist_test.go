@@ -0,0 +1,128 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+func TestQuat_SwingTwist(t *testing.T) {
+	t.Parallel()
+	axis := Vec3{0, 1, 0}
+	tests := []struct {
+		swingAngle float32
+		swingAxis  Vec3
+		twistAngle float32
+	}{
+		{0, Vec3{1, 0, 0}, 0},
+		{0.5, Vec3{1, 0, 0}, 0},
+		{0, Vec3{1, 0, 0}, 1.2},
+		{0.7, Vec3{0.6, 0, 0.8}, -2},
+		{-1.5, Vec3{0, 0, 1}, 3},
+	}
+
+	for i, test := range tests {
+		swing := QuatRotate(test.swingAngle, &test.swingAxis)
+		twist := QuatRotate(test.twistAngle, &axis)
+		q := QuatFromSwingTwist(&swing, &twist)
+
+		s, tw := q.SwingTwist(&axis)
+		if !s.OrientationEqualThreshold(&swing, 1e-4) {
+			t.Errorf("[%d] swing = %v, want %v", i, s, swing)
+		}
+		if !tw.OrientationEqualThreshold(&twist, 1e-4) {
+			t.Errorf("[%d] twist = %v, want %v", i, tw, twist)
+		}
+		if a := tw.TwistAngle(&axis); !FloatEqualThreshold(a, test.twistAngle, 1e-3) {
+			t.Errorf("[%d] twist angle = %v, want %v", i, a, test.twistAngle)
+		}
+		if r := QuatFromSwingTwist(&s, &tw); !r.OrientationEqualThreshold(&q, 1e-4) {
+			t.Errorf("[%d] recomposed %v, want %v", i, r, q)
+		}
+	}
+}
+
+func TestClampTwist(t *testing.T) {
+	t.Parallel()
+	axis := Vec3{0, 0, 1}
+	tests := []struct {
+		angle, min, max, expected float32
+	}{
+		{0.5, -1, 1, 0.5},
+		{1.5, -1, 1, 1},
+		{-1.5, -1, 1, -1},
+		{-1.5, 0, 0, 0},
+	}
+
+	for i, test := range tests {
+		q := QuatRotate(test.angle, &axis)
+		c := ClampTwist(&q, &axis, test.min, test.max)
+		if a := c.TwistAngle(&axis); math.Abs(a-test.expected) > 1e-3 {
+			t.Errorf("[%d] ClampTwist(%v) = %v, want %v", i, test.angle, a, test.expected)
+		}
+	}
+}
+
+func TestClampSwingCone(t *testing.T) {
+	t.Parallel()
+	axis := Vec3{0, 1, 0}
+	tests := []struct {
+		angle    float32
+		dir      Vec3
+		max      float32
+		expected float32
+	}{
+		{0.3, Vec3{1, 0, 0}, 0.5, 0.3},
+		{1, Vec3{1, 0, 0}, 0.5, 0.5},
+		{3, Vec3{0.6, 0, 0.8}, 1, 1},
+	}
+
+	for i, test := range tests {
+		swing := QuatRotate(test.angle, &test.dir)
+		c := ClampSwingCone(&swing, &axis, test.max)
+		want := QuatRotate(test.expected, &test.dir)
+		if !c.OrientationEqualThreshold(&want, 1e-4) {
+			t.Errorf("[%d] ClampSwingCone = %v, want %v", i, c, want)
+		}
+	}
+}
+
+func TestClampSwingEllipse(t *testing.T) {
+	t.Parallel()
+	axis, ref := Vec3{0, 1, 0}, Vec3{1, 0, 0}
+	other := axis.Cross(&ref)
+	tests := []struct {
+		angle            float32
+		dir              Vec3
+		maxRef, maxOther float32
+		expected         float32
+	}{
+		{0.3, ref, 0.5, 1, 0.3},
+		{0.8, ref, 0.5, 1, 0.5},
+		{0.8, other, 0.5, 1, 0.8},
+		{1.5, other, 0.5, 1, 1},
+		{0.4, other, 0.5, 0, 0},
+	}
+
+	for i, test := range tests {
+		swing := QuatRotate(test.angle, &test.dir)
+		c := ClampSwingEllipse(&swing, &axis, &ref, test.maxRef, test.maxOther)
+		want := QuatRotate(test.expected, &test.dir)
+		if !c.OrientationEqualThreshold(&want, 1e-4) {
+			t.Errorf("[%d] ClampSwingEllipse = %v, want %v", i, c, want)
+		}
+	}
+}
+
+func TestConstrainSwingTwist(t *testing.T) {
+	t.Parallel()
+	axis, x := Vec3{0, 1, 0}, Vec3{1, 0, 0}
+	swing, twist := QuatRotate(1, &x), QuatRotate(2, &axis)
+	q := QuatFromSwingTwist(&swing, &twist)
+
+	c := ConstrainSwingTwist(&q, &axis, -0.5, 0.5, 0.25)
+	ws, wt := QuatRotate(0.25, &x), QuatRotate(0.5, &axis)
+	want := QuatFromSwingTwist(&ws, &wt)
+	if !c.OrientationEqualThreshold(&want, 1e-4) {
+		t.Errorf("ConstrainSwingTwist = %v, want %v", c, want)
+	}
+}
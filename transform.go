@@ -2,6 +2,8 @@ package glm
 
 import (
 	"unsafe"
+
+	"github.com/EngoEngine/math"
 )
 
 // Transform is a utility type used to aggregate transformations. Transform
@@ -164,14 +166,140 @@ func (t *Transform2D) LocalToWorld(v *Vec2) Vec2 {
 }
 
 // WorldToLocal transform a given point and returns the local point that this
-// transform generates.
+// transform generates. It inverts the transform on every call, when converting
+// many points use Inverse once and LocalToWorld on the result.
 func (t *Transform2D) WorldToLocal(v *Vec2) Vec2 {
-	// BUG(hydroflame): the current implementation currently inverse the matrix
-	// on every call ... that may not be the most efficient.
-	inv := (*Mat3)(t).Inverse()
-	v3 := v.Vec3(1)
-	v3 = inv.Mul3x1(&v3)
-	return v3.Vec2()
+	inv := t.Inverse()
+	return inv.LocalToWorld(v)
+}
+
+// Inverse returns the inverse of this transform. It assumes the transform is
+// affine, which is cheaper than a full Mat3 inverse. If the transform is not
+// invertible the result is the zero transform.
+func (t *Transform2D) Inverse() Transform2D {
+	var inv Transform2D
+	inv.InverseOf(t)
+	return inv
+}
+
+// InverseOf is a memory friendly version of Inverse.
+func (t *Transform2D) InverseOf(t2 *Transform2D) {
+	a, b, c, d, x, y := t2[0], t2[1], t2[3], t2[4], t2[6], t2[7]
+	det := a*d - b*c
+	if det == 0 {
+		*t = Transform2D{}
+		return
+	}
+	det = 1 / det
+	*t = Transform2D{
+		d * det, -b * det, 0,
+		-c * det, a * det, 0,
+		(c*y - d*x) * det, (b*x - a*y) * det, 1,
+	}
+}
+
+// Invert is a memory friendly version of Inverse.
+func (t *Transform2D) Invert() {
+	t.InverseOf(t)
+}
+
+// Scale concatenates a uniform scale of s.
+func (t *Transform2D) Scale(s float32) {
+	t.Scale2f(s, s)
+}
+
+// Scale2f concatenates a scale of x horizontally and y vertically.
+func (t *Transform2D) Scale2f(x, y float32) {
+	scale := Scale2D(x, y)
+	((*Mat3)(t)).Mul3With(&scale)
+}
+
+// ScaleVec2 concatenates a scale of v.
+func (t *Transform2D) ScaleVec2(v *Vec2) {
+	t.Scale2f(v[0], v[1])
+}
+
+// SetScale2f sets the transform to a scale transform of {x, y}.
+func (t *Transform2D) SetScale2f(x, y float32) {
+	*t = Transform2D(Scale2D(x, y))
+}
+
+// Skew concatenates a skew of angle x (radian) along the X axis and angle y
+// along the Y axis, like the CSS skew function.
+func (t *Transform2D) Skew(x, y float32) {
+	skew := Mat3{
+		1, math.Tan(y), 0,
+		math.Tan(x), 1, 0,
+		0, 0, 1,
+	}
+	((*Mat3)(t)).Mul3With(&skew)
+}
+
+// RotateAround concatenates a rotation of angle (radian) around pivot.
+func (t *Transform2D) RotateAround(angle float32, pivot *Vec2) {
+	t.Translate2f(pivot[0], pivot[1])
+	t.Rotate(angle)
+	t.Translate2f(-pivot[0], -pivot[1])
+}
+
+// ScaleAround concatenates a scale of x horizontally and y vertically around
+// pivot.
+func (t *Transform2D) ScaleAround(x, y float32, pivot *Vec2) {
+	t.Translate2f(pivot[0], pivot[1])
+	t.Scale2f(x, y)
+	t.Translate2f(-pivot[0], -pivot[1])
+}
+
+// ComposeTransform2D returns the transform that scales by scale, skews along
+// the X axis by skew (radian), rotates by rotation (radian) and then
+// translates by translation. This is the inverse of Decompose.
+func ComposeTransform2D(translation *Vec2, rotation float32, scale *Vec2, skew float32) Transform2D {
+	sin, cos := math.Sincos(rotation)
+	k := math.Tan(skew)
+	return Transform2D{
+		cos * scale[0], sin * scale[0], 0,
+		(cos*k - sin) * scale[1], (sin*k + cos) * scale[1], 0,
+		translation[0], translation[1], 1,
+	}
+}
+
+// Decompose splits this affine transform into a translation, a rotation, a
+// scale and a skew along the X axis such that
+// ComposeTransform2D(&translation, rotation, &scale, skew) gives it back. A
+// reflection is represented as a negative Y scale.
+func (t *Transform2D) Decompose() (translation Vec2, rotation float32, scale Vec2, skew float32) {
+	a, b, c, d := t[0], t[1], t[3], t[4]
+	translation = Vec2{t[6], t[7]}
+	scale[0] = math.Hypot(a, b)
+	if scale[0] == 0 {
+		return
+	}
+	rotation = math.Atan2(b, a)
+	cos, sin := a/scale[0], b/scale[0]
+	scale[1] = cos*d - sin*c
+	if scale[1] != 0 {
+		skew = math.Atan((cos*c + sin*d) / scale[1])
+	}
+	return
+}
+
+// Transform2DInterpolate interpolates between t1 and t2 in decomposed space:
+// translation, scale and skew linearly and rotation along the shortest arc.
+// Unlike interpolating the matrices this doesn't shrink rotating sprites.
+func Transform2DInterpolate(t1, t2 *Transform2D, amount float32) Transform2D {
+	tr1, r1, s1, k1 := t1.Decompose()
+	tr2, r2, s2, k2 := t2.Decompose()
+
+	dr := math.Mod(r2-r1, 2*math.Pi)
+	if dr > math.Pi {
+		dr -= 2 * math.Pi
+	} else if dr < -math.Pi {
+		dr += 2 * math.Pi
+	}
+
+	tr := Vec2{tr1[0] + (tr2[0]-tr1[0])*amount, tr1[1] + (tr2[1]-tr1[1])*amount}
+	s := Vec2{s1[0] + (s2[0]-s1[0])*amount, s1[1] + (s2[1]-s1[1])*amount}
+	return ComposeTransform2D(&tr, r1+dr*amount, &s, k1+(k2-k1)*amount)
 }
 
 // Concatenate Transform t2 into t.
@@ -185,6 +313,12 @@ func (t *Transform2D) Mat3() Mat3 {
 	return *((*Mat3)(t))
 }
 
+// Mat2x3 returns the top 2 rows of this transform, the last row of an affine
+// transform is always [0 0 1]. This is smaller to upload.
+func (t *Transform2D) Mat2x3() Mat2x3 {
+	return ((*Mat3)(t)).Mat2x3()
+}
+
 // Pointer returns the pointer to the first element of the underlying 4x4
 // matrix. This is can be passed directly to OpenGL function.
 func (t *Transform2D) Pointer() unsafe.Pointer {
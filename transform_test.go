@@ -1,6 +1,7 @@
 package glm
 
 import (
+	"github.com/EngoEngine/math"
 	"testing"
 )
 
@@ -73,6 +74,144 @@ func TestTransform_SetTranslate(t *testing.T) {
 	}
 }
 
+func mat3NearT(a, b *Transform2D, tol float32) bool {
+	return mat3Near((*Mat3)(a), (*Mat3)(b), tol)
+}
+
+func TestTransform2D_ScaleSkewPivot(t *testing.T) {
+	t.Parallel()
+	var tr Transform2D
+	tr.Iden()
+	tr.Scale(2)
+	if p := tr.LocalToWorld(&Vec2{1, 3}); p != (Vec2{2, 6}) {
+		t.Errorf("Scale(2) of {1, 3} = %v", p)
+	}
+	tr.SetScale2f(2, -1)
+	tr.ScaleVec2(&Vec2{0.5, 3})
+	if p := tr.LocalToWorld(&Vec2{1, 1}); p != (Vec2{1, -3}) {
+		t.Errorf("Scale2f of {1, 1} = %v", p)
+	}
+
+	tr.Iden()
+	tr.Skew(math.Pi/4, 0)
+	if p := tr.LocalToWorld(&Vec2{1, 2}); !p.EqualThreshold(&Vec2{3, 2}, 1e-5) {
+		t.Errorf("Skew(π/4, 0) of {1, 2} = %v", p)
+	}
+	tr.Iden()
+	tr.Skew(0, math.Pi/4)
+	if p := tr.LocalToWorld(&Vec2{1, 2}); !p.EqualThreshold(&Vec2{1, 3}, 1e-5) {
+		t.Errorf("Skew(0, π/4) of {1, 2} = %v", p)
+	}
+
+	// The pivot doesn't move.
+	pivot := Vec2{3, -2}
+	tr.SetTranslate2f(5, 5)
+	tr.RotateAround(1.2, &pivot)
+	tr.ScaleAround(2, 3, &pivot)
+	if p := tr.LocalToWorld(&pivot); !p.EqualThreshold(&Vec2{8, 3}, 1e-5) {
+		t.Errorf("pivot moved to %v", p)
+	}
+	tr.SetRotate(0)
+	tr.RotateAround(math.Pi/2, &pivot)
+	if p := tr.LocalToWorld(&Vec2{4, -2}); !p.EqualThreshold(&Vec2{3, -1}, 1e-5) {
+		t.Errorf("RotateAround of {4, -2} = %v", p)
+	}
+}
+
+func TestTransform2D_Inverse(t *testing.T) {
+	t.Parallel()
+	ident := NewTransform2D()
+	for i, tr := range []Transform2D{
+		NewTransform2D(),
+		ComposeTransform2D(&Vec2{1, 2}, 0.5, &Vec2{2, 3}, 0.3),
+		ComposeTransform2D(&Vec2{-4, 0}, -2, &Vec2{0.5, -1}, -0.6),
+	} {
+		inv := tr.Inverse()
+		want := Transform2D((*Mat3)(&tr).Inverse())
+		if !mat3NearT(&inv, &want, 1e-5) {
+			t.Errorf("[%d] Inverse() = %v, want %v", i, inv, want)
+		}
+		c := tr
+		c.Concatenate(&inv)
+		if !mat3NearT(&c, &ident, 1e-5) {
+			t.Errorf("[%d] t * t^-1 = %v", i, c)
+		}
+		c = tr
+		c.Invert()
+		if c != inv {
+			t.Errorf("[%d] Invert() = %v, want %v", i, c, inv)
+		}
+
+		v := Vec2{0.3, -7}
+		w := tr.LocalToWorld(&v)
+		if l := tr.WorldToLocal(&w); !l.EqualThreshold(&v, 1e-4) {
+			t.Errorf("[%d] WorldToLocal(LocalToWorld(%v)) = %v", i, v, l)
+		}
+	}
+
+	var zero Transform2D
+	singular := Transform2D(Scale2D(0, 1))
+	if inv := singular.Inverse(); inv != zero {
+		t.Errorf("singular Inverse() = %v", inv)
+	}
+}
+
+func TestTransform2D_Decompose(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		translation Vec2
+		rotation    float32
+		scale       Vec2
+		skew        float32
+	}{
+		{Vec2{0, 0}, 0, Vec2{1, 1}, 0},
+		{Vec2{1, -2}, 0.7, Vec2{2, 2}, 0},
+		{Vec2{3, 4}, -2.5, Vec2{0.5, 3}, 0.4},
+		{Vec2{0, 5}, 3, Vec2{1, -2}, -0.8},
+	}
+	for i, test := range tests {
+		tr := ComposeTransform2D(&test.translation, test.rotation, &test.scale, test.skew)
+
+		var built Transform2D
+		built.SetTranslateVec2(&test.translation)
+		built.Rotate(test.rotation)
+		built.Skew(test.skew, 0)
+		built.ScaleVec2(&test.scale)
+		if !mat3NearT(&tr, &built, 1e-5) {
+			t.Errorf("[%d] ComposeTransform2D = %v, want %v", i, tr, built)
+		}
+
+		translation, rotation, scale, skew := tr.Decompose()
+		if translation != test.translation || math.Abs(rotation-test.rotation) > 1e-5 ||
+			!scale.EqualThreshold(&test.scale, 1e-5) || math.Abs(skew-test.skew) > 1e-5 {
+			t.Errorf("[%d] Decompose() = %v, %f, %v, %f, want %v", i, translation, rotation, scale, skew, test)
+		}
+
+		m := tr.Mat2x3()
+		if m3 := m.Mat3(); !mat3NearT((*Transform2D)(&m3), &tr, 0) {
+			t.Errorf("[%d] Mat2x3() = %v", i, m)
+		}
+	}
+}
+
+func TestTransform2DInterpolate(t *testing.T) {
+	t.Parallel()
+	a := ComposeTransform2D(&Vec2{0, 0}, 3, &Vec2{1, 1}, 0)
+	b := ComposeTransform2D(&Vec2{4, 2}, -3, &Vec2{3, 1}, 0.4)
+	if got := Transform2DInterpolate(&a, &b, 0); !mat3NearT(&got, &a, 1e-5) {
+		t.Errorf("amount 0 = %v, want %v", got, a)
+	}
+	if got := Transform2DInterpolate(&a, &b, 1); !mat3NearT(&got, &b, 1e-5) {
+		t.Errorf("amount 1 = %v, want %v", got, b)
+	}
+	// 3 to -3 goes through π, not 0.
+	got := Transform2DInterpolate(&a, &b, 0.5)
+	want := ComposeTransform2D(&Vec2{2, 1}, math.Pi, &Vec2{2, 1}, 0.2)
+	if !mat3NearT(&got, &want, 1e-5) {
+		t.Errorf("amount 0.5 = %v, want %v", got, want)
+	}
+}
+
 /*
 func TestSpecial(t *testing.T) {
 	var tr Transform
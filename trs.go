@@ -0,0 +1,156 @@
+package glm
+
+// TRS is a transform kept decomposed in a translation, a rotation and a
+// scale, applied in the order scale, rotate then translate. Unlike Transform
+// the components can be read back and interpolated, which is what animation
+// blending and network interpolation need.
+//
+// TRS can't represent shear. Composing or inverting transforms with
+// non-uniform scale and rotation drops the shear that a matrix would have, the
+// results are exact when the scales are uniform.
+type TRS struct {
+	Translation Vec3
+	Rotation    Quat
+	Scale       Vec3
+}
+
+// TRSIdent returns the identity transform.
+func TRSIdent() TRS {
+	return TRS{Rotation: QuatIdent(), Scale: Vec3{1, 1, 1}}
+}
+
+// TRSFromMat4 decomposes the affine transform m, see DecomposeTRS. ok is false
+// if m is singular or projective.
+func TRSFromMat4(m *Mat4) (t TRS, ok bool) {
+	t.Translation, t.Rotation, t.Scale, ok = DecomposeTRS(m)
+	return
+}
+
+// Iden sets t1 to the identity transform.
+func (t1 *TRS) Iden() {
+	*t1 = TRSIdent()
+}
+
+// Mul returns the transform applying t2 then t1, like the matrix product
+// t1 * t2.
+func (t1 *TRS) Mul(t2 *TRS) TRS {
+	var t TRS
+	t.MulOf(t1, t2)
+	return t
+}
+
+// MulOf is a memory friendly version of Mul.
+func (t1 *TRS) MulOf(t2, t3 *TRS) {
+	st := t3.Translation.ComponentProduct(&t2.Scale)
+	tr := t2.Rotation.Rotate(&st)
+	tr.AddWith(&t2.Translation)
+	t1.Rotation = t2.Rotation.Mul(&t3.Rotation)
+	t1.Scale.ComponentProductOf(&t2.Scale, &t3.Scale)
+	t1.Translation = tr
+}
+
+// MulWith is a memory friendly version of Mul.
+func (t1 *TRS) MulWith(t2 *TRS) {
+	t1.MulOf(t1, t2)
+}
+
+// Inverse returns the inverse transform of t1.
+func (t1 *TRS) Inverse() TRS {
+	var t TRS
+	t.InverseOf(t1)
+	return t
+}
+
+// InverseOf is a memory friendly version of Inverse.
+func (t1 *TRS) InverseOf(t2 *TRS) {
+	s := Vec3{1 / t2.Scale[0], 1 / t2.Scale[1], 1 / t2.Scale[2]}
+	r := t2.Rotation.Conjugated()
+	tr := r.Rotate(&t2.Translation)
+	tr.ComponentProductWith(&s)
+	tr.Invert()
+	t1.Translation, t1.Rotation, t1.Scale = tr, r, s
+}
+
+// Invert is a memory friendly version of Inverse.
+func (t1 *TRS) Invert() {
+	t1.InverseOf(t1)
+}
+
+// TransformPoint returns the point v transformed by t1.
+func (t1 *TRS) TransformPoint(v *Vec3) Vec3 {
+	p := t1.TransformDirection(v)
+	p.AddWith(&t1.Translation)
+	return p
+}
+
+// TransformDirection returns the vector v transformed by t1, ignoring the
+// translation.
+func (t1 *TRS) TransformDirection(v *Vec3) Vec3 {
+	s := v.ComponentProduct(&t1.Scale)
+	return t1.Rotation.Rotate(&s)
+}
+
+// TransformNormal returns the normal n transformed by t1. The result is
+// normalized, as non-uniform scales change the length of normals.
+func (t1 *TRS) TransformNormal(n *Vec3) Vec3 {
+	s := Vec3{n[0] / t1.Scale[0], n[1] / t1.Scale[1], n[2] / t1.Scale[2]}
+	r := t1.Rotation.Rotate(&s)
+	r.Normalize()
+	return r
+}
+
+// InverseTransformPoint returns the point v transformed by the inverse of t1.
+// It's exact even when t1 has non-uniform scale.
+func (t1 *TRS) InverseTransformPoint(v *Vec3) Vec3 {
+	p := v.Sub(&t1.Translation)
+	r := t1.Rotation.Conjugated()
+	p = r.Rotate(&p)
+	return Vec3{p[0] / t1.Scale[0], p[1] / t1.Scale[1], p[2] / t1.Scale[2]}
+}
+
+// Mat4 returns the matrix of t1.
+func (t1 *TRS) Mat4() Mat4 {
+	return ComposeTRS(&t1.Translation, &t1.Rotation, &t1.Scale)
+}
+
+// Mat3x4 returns the matrix of t1 without the constant last row.
+func (t1 *TRS) Mat3x4() Mat3x4 {
+	var m Mat3x4
+	m.SetOrientationAndPos(&t1.Rotation, &t1.Translation)
+	for i := 0; i < 3; i++ {
+		m[i*3+0] *= t1.Scale[i]
+		m[i*3+1] *= t1.Scale[i]
+		m[i*3+2] *= t1.Scale[i]
+	}
+	return m
+}
+
+// Transform returns t1 as a Transform.
+func (t1 *TRS) Transform() Transform {
+	return Transform(t1.Mat4())
+}
+
+// EqualThreshold returns true if all the components of t1 and t2 are equal
+// within the given threshold. The rotations are compared as orientations so q
+// and -q are equal.
+func (t1 *TRS) EqualThreshold(t2 *TRS, threshold float32) bool {
+	return t1.Translation.EqualThreshold(&t2.Translation, threshold) &&
+		t1.Rotation.OrientationEqualThreshold(&t2.Rotation, threshold) &&
+		t1.Scale.EqualThreshold(&t2.Scale, threshold)
+}
+
+// TRSInterpolate interpolates between t1 and t2, linearly for the translation
+// and the scale and with QuatSlerp along the shortest path for the rotation.
+func TRSInterpolate(t1, t2 *TRS, amount float32) TRS {
+	var t TRS
+	t.Translation = t1.Translation.Mul(1 - amount)
+	t.Translation.AddScaledVec(amount, &t2.Translation)
+	t.Scale = t1.Scale.Mul(1 - amount)
+	t.Scale.AddScaledVec(amount, &t2.Scale)
+	r2 := t2.Rotation
+	if t1.Rotation.Dot(&r2) < 0 {
+		r2.ScaleWith(-1)
+	}
+	t.Rotation = QuatSlerp(&t1.Rotation, &r2, amount)
+	return t
+}
@@ -0,0 +1,126 @@
+package glm
+
+import (
+	"github.com/EngoEngine/math"
+	"testing"
+)
+
+var trsTests = []TRS{
+	TRSIdent(),
+	{Vec3{1, 2, 3}, QuatIdent(), Vec3{1, 1, 1}},
+	{Vec3{-1, 0.5, 4}, QuatRotate(0.7, &Vec3{0, 1, 0}), Vec3{2, 2, 2}},
+	{Vec3{3, -2, 1}, QuatRotate(2.1, &Vec3{0.6, 0, 0.8}), Vec3{0.5, 0.5, 0.5}},
+	{Vec3{0, 0, -7}, QuatRotate(-1.2, &Vec3{0, 0, 1}), Vec3{1, 2, 3}},
+}
+
+func mat4Near(a, b *Mat4, tol float32) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTRS_Mat4(t *testing.T) {
+	t.Parallel()
+	for i, test := range trsTests {
+		m := test.Mat4()
+		m34 := test.Mat3x4()
+		if got := m34.Mat4(); !mat4Near(&got, &m, 1e-5) {
+			t.Errorf("[%d] Mat3x4() = %v, want %v", i, m34, m)
+		}
+		if tr := test.Transform(); tr.Mat4() != m {
+			t.Errorf("[%d] Transform() = %v, want %v", i, tr, m)
+		}
+		back, ok := TRSFromMat4(&m)
+		if !ok || !back.EqualThreshold(&test, 1e-4) {
+			t.Errorf("[%d] TRSFromMat4(Mat4()) = %v, %t", i, back, ok)
+		}
+
+		v := Vec3{0.3, -1, 2}
+		v4 := v.Vec4(1)
+		want := m.Mul4x1(&v4)
+		if p := test.TransformPoint(&v); !p.EqualThreshold(&Vec3{want[0], want[1], want[2]}, 1e-4) {
+			t.Errorf("[%d] TransformPoint(%v) = %v, want %v", i, v, p, want)
+		}
+		v4[3] = 0
+		want = m.Mul4x1(&v4)
+		if d := test.TransformDirection(&v); !d.EqualThreshold(&Vec3{want[0], want[1], want[2]}, 1e-4) {
+			t.Errorf("[%d] TransformDirection(%v) = %v, want %v", i, v, d, want)
+		}
+		p := test.TransformPoint(&v)
+		if back := test.InverseTransformPoint(&p); !back.EqualThreshold(&v, 1e-4) {
+			t.Errorf("[%d] InverseTransformPoint(TransformPoint(%v)) = %v", i, v, back)
+		}
+
+		// Normals stay perpendicular to the transformed surface.
+		tangent := Vec3{1, 2, 0}
+		normal := Vec3{-2, 1, 0}
+		tt := test.TransformDirection(&tangent)
+		tn := test.TransformNormal(&normal)
+		if d := tt.Dot(&tn); math.Abs(d) > 1e-4 {
+			t.Errorf("[%d] transformed normal . tangent = %f", i, d)
+		}
+		if math.Abs(tn.Len()-1) > 1e-5 {
+			t.Errorf("[%d] |TransformNormal| = %f", i, tn.Len())
+		}
+	}
+}
+
+func TestTRS_MulInverse(t *testing.T) {
+	t.Parallel()
+	ident := TRSIdent()
+	for i, a := range trsTests {
+		inv := a.Inverse()
+		if id := a.Mul(&inv); !id.EqualThreshold(&ident, 1e-4) && !mat4NearTRS(&id, &ident) {
+			t.Errorf("[%d] t * t^-1 = %v", i, id)
+		}
+		for j, b := range trsTests {
+			// The non-uniform scale can't be composed exactly.
+			if a.Scale[0] != a.Scale[1] || a.Scale[0] != a.Scale[2] {
+				continue
+			}
+			got := a.Mul(&b)
+			gm := got.Mat4()
+			am, bm := a.Mat4(), b.Mat4()
+			if want := am.Mul4(&bm); !mat4Near(&gm, &want, 1e-4) {
+				t.Errorf("[%d,%d] Mul = %v, want %v", i, j, gm, want)
+			}
+			c := a
+			c.MulWith(&b)
+			if !c.EqualThreshold(&got, 1e-5) {
+				t.Errorf("[%d,%d] MulWith = %v, want %v", i, j, c, got)
+			}
+		}
+	}
+}
+
+// mat4NearTRS compares transforms through their matrices, for when the
+// components are close to 0.
+func mat4NearTRS(a, b *TRS) bool {
+	am, bm := a.Mat4(), b.Mat4()
+	return mat4Near(&am, &bm, 1e-4)
+}
+
+func TestTRSInterpolate(t *testing.T) {
+	t.Parallel()
+	a := TRS{Vec3{0, 0, 0}, QuatIdent(), Vec3{1, 1, 1}}
+	b := TRS{Vec3{2, 4, -6}, QuatRotate(math.Pi/2, &Vec3{0, 0, 1}), Vec3{3, 1, 1}}
+	// The same orientation on the other hemisphere.
+	b2 := b
+	b2.Rotation.ScaleWith(-1)
+
+	for _, other := range []TRS{b, b2} {
+		if got := TRSInterpolate(&a, &other, 0); !mat4NearTRS(&got, &a) {
+			t.Errorf("amount 0 = %v, want %v", got, a)
+		}
+		if got := TRSInterpolate(&a, &other, 1); !mat4NearTRS(&got, &b) {
+			t.Errorf("amount 1 = %v, want %v", got, b)
+		}
+		half := TRS{Vec3{1, 2, -3}, QuatRotate(math.Pi/4, &Vec3{0, 0, 1}), Vec3{2, 1, 1}}
+		if got := TRSInterpolate(&a, &other, 0.5); !mat4NearTRS(&got, &half) {
+			t.Errorf("amount 0.5 = %v, want %v", got, half)
+		}
+	}
+}